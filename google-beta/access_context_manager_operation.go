@@ -53,7 +53,7 @@ func AccessContextManagerOperationWaitTimeWithResponse(config *Config, op map[st
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("access_context_manager")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -69,5 +69,5 @@ func AccessContextManagerOperationWaitTime(config *Config, op map[string]interfa
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("access_context_manager"))
 }
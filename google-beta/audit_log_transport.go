@@ -0,0 +1,352 @@
+// A http.RoundTripper that writes a structured JSONL audit record for every
+// request sent to GCP APIs, with secrets redacted.
+//
+// Unlike the debug logging transport, the audit log is written to a dedicated
+// file regardless of TF_LOG, so it can be used to review which identity
+// changed which resources, and when.
+
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const auditLogRedacted = "REDACTED"
+
+// defaultAuditLogRedactFields are always redacted from audit records, in
+// addition to the fields configured on the provider. They're matched against
+// header names, query parameters and JSON field names after normalisation, see
+// normalizeAuditLogField.
+var defaultAuditLogRedactFields = append([]string{
+	"authorization",
+	"x-goog-api-key",
+	// Secret Manager secret versions carry their data in payload.data
+	"payload",
+}, sensitiveFieldNames...)
+
+// AuditLogConfig configures the audit log written by the provider.
+type AuditLogConfig struct {
+	// Path is the file audit records are appended to.
+	Path string
+	// RedactFields are additional header, query parameter and JSON field names
+	// whose values are redacted.
+	RedactFields []string
+}
+
+// auditLogRecord is a single line of the audit log.
+type auditLogRecord struct {
+	Time           string              `json:"time"`
+	Identity       string              `json:"identity,omitempty"`
+	Method         string              `json:"method"`
+	URL            string              `json:"url"`
+	Resource       string              `json:"resource,omitempty"`
+	Operation      string              `json:"operation,omitempty"`
+	Attempt        int                 `json:"attempt"`
+	Status         int                 `json:"status,omitempty"`
+	LatencyMs      int64               `json:"latency_ms"`
+	Error          string              `json:"error,omitempty"`
+	RequestHeaders map[string][]string `json:"request_headers,omitempty"`
+	RequestBody    interface{}         `json:"request_body,omitempty"`
+	ResponseBody   interface{}         `json:"response_body,omitempty"`
+}
+
+// auditLogger appends records to an audit log file. A single auditLogger is
+// shared per path, so the SDK and plugin-framework halves of the provider
+// don't interleave partial lines.
+type auditLogger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+var (
+	auditLoggersMu sync.Mutex
+	auditLoggers   = map[string]*auditLogger{}
+)
+
+func getAuditLogger(path string) (*auditLogger, error) {
+	auditLoggersMu.Lock()
+	defer auditLoggersMu.Unlock()
+
+	if l, ok := auditLoggers[path]; ok {
+		return l, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log %q: %s", path, err)
+	}
+
+	l := &auditLogger{file: f}
+	auditLoggers[path] = l
+	return l, nil
+}
+
+func (l *auditLogger) write(record *auditLogRecord) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		log.Printf("[WARN] Audit Log Transport: unable to marshal audit record: %s", err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(b, '\n')); err != nil {
+		log.Printf("[WARN] Audit Log Transport: unable to write audit record: %s", err)
+	}
+}
+
+type auditLogTransport struct {
+	logger   *auditLogger
+	redact   map[string]struct{}
+	identity string
+	internal http.RoundTripper
+}
+
+// newTransportWithAuditLog wraps the given transport with one writing audit
+// records to the file configured in cfg. If cfg is nil, the transport is
+// returned as is.
+func newTransportWithAuditLog(t http.RoundTripper, cfg *AuditLogConfig, identity string) (http.RoundTripper, error) {
+	if cfg == nil || cfg.Path == "" {
+		return t, nil
+	}
+
+	logger, err := getAuditLogger(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	redact := make(map[string]struct{})
+	for _, f := range append(defaultAuditLogRedactFields, cfg.RedactFields...) {
+		redact[normalizeAuditLogField(f)] = struct{}{}
+	}
+
+	return &auditLogTransport{
+		logger:   logger,
+		redact:   redact,
+		identity: identity,
+		internal: t,
+	}, nil
+}
+
+// RoundTrip implements the RoundTripper interface method.
+// It sends the request and writes an audit record for it, whether or not it
+// succeeded.
+func (t *auditLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	record := &auditLogRecord{
+		Time:           time.Now().UTC().Format(time.RFC3339Nano),
+		Identity:       t.identity,
+		Method:         req.Method,
+		URL:            t.redactURL(req.URL),
+		Resource:       auditLogResourceName(req.URL),
+		Attempt:        retryAttemptFromContext(req.Context()),
+		RequestHeaders: t.redactHeaders(req.Header),
+	}
+	if strings.Contains(record.Resource, "/operations/") {
+		record.Operation = record.Resource
+	}
+
+	if body, err := peekRequestBody(req); err != nil {
+		log.Printf("[WARN] Audit Log Transport: unable to read request body: %s", err)
+	} else {
+		record.RequestBody = t.redactBody(body)
+	}
+
+	start := time.Now()
+	resp, err := t.internal.RoundTrip(req)
+	record.LatencyMs = time.Since(start).Milliseconds()
+
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if resp != nil {
+		record.Status = resp.StatusCode
+		if body, err := peekResponseBody(resp); err != nil {
+			log.Printf("[WARN] Audit Log Transport: unable to read response body: %s", err)
+		} else {
+			record.ResponseBody = t.redactBody(body)
+			if op := auditLogOperationName(record.ResponseBody); op != "" {
+				record.Operation = op
+			}
+		}
+	}
+
+	t.logger.write(record)
+	return resp, err
+}
+
+func (t *auditLogTransport) shouldRedact(field string) bool {
+	_, ok := t.redact[normalizeAuditLogField(field)]
+	return ok
+}
+
+func (t *auditLogTransport) redactURL(u *url.URL) string {
+	redacted := *u
+	q := redacted.Query()
+	for k := range q {
+		// API keys are sent in the "key" query parameter, which is too generic
+		// a name to redact from bodies.
+		if k == "key" || t.shouldRedact(k) {
+			q.Set(k, auditLogRedacted)
+		}
+	}
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
+
+func (t *auditLogTransport) redactHeaders(h http.Header) map[string][]string {
+	if len(h) == 0 {
+		return nil
+	}
+
+	redacted := make(map[string][]string, len(h))
+	for k, v := range h {
+		if t.shouldRedact(k) {
+			redacted[k] = []string{auditLogRedacted}
+			continue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// redactBody returns the JSON body with the values of all redacted fields,
+// at any depth, replaced. Bodies that aren't JSON are omitted from the record.
+func (t *auditLogTransport) redactBody(body []byte) interface{} {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+	return t.redactValue(v)
+}
+
+func (t *auditLogTransport) redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range val {
+			if t.shouldRedact(k) {
+				val[k] = auditLogRedacted
+				continue
+			}
+			val[k] = t.redactValue(fv)
+		}
+		return val
+	case []interface{}:
+		for i, iv := range val {
+			val[i] = t.redactValue(iv)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// normalizeAuditLogField lowercases a field name and drops separators, so that
+// "private_key" matches both the "privateKey" API field and a "Private-Key"
+// header. Normalised names are matched exactly, so that "secret" doesn't
+// match "secretId".
+func normalizeAuditLogField(field string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(field))
+}
+
+var auditLogResourceRegex = regexp.MustCompile(`/(?:v[0-9]+[a-z0-9]*|beta|alpha)/(.+)$`)
+
+// auditLogResourceName returns the name of the API resource a request is
+// made against, e.g. "projects/my-project/zones/us-central1-a/instances/foo".
+func auditLogResourceName(u *url.URL) string {
+	path := u.Path
+	for _, prefix := range []string{"projects/", "organizations/", "folders/", "billingAccounts/"} {
+		if i := strings.Index(path, "/"+prefix); i >= 0 {
+			return trimCustomMethod(path[i+1:])
+		}
+	}
+	if m := auditLogResourceRegex.FindStringSubmatch(path); m != nil {
+		return trimCustomMethod(m[1])
+	}
+	return trimCustomMethod(strings.TrimPrefix(path, "/"))
+}
+
+// trimCustomMethod strips a custom method such as ":setIamPolicy" from a
+// resource path.
+func trimCustomMethod(path string) string {
+	last := strings.LastIndex(path, "/")
+	if i := strings.LastIndex(path, ":"); i > last {
+		return path[:i]
+	}
+	return path
+}
+
+// auditLogOperationName returns the name of the long-running operation a
+// response body describes, if any.
+func auditLogOperationName(body interface{}) string {
+	m, ok := body.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	name, ok := m["name"].(string)
+	if !ok {
+		return ""
+	}
+	if kind, ok := m["kind"].(string); ok && strings.HasSuffix(strings.ToLower(kind), "operation") {
+		return name
+	}
+	if _, ok := m["done"]; ok {
+		return name
+	}
+	if _, ok := m["operationType"]; ok {
+		return name
+	}
+	return ""
+}
+
+// isJSONContentType returns whether the headers declare a JSON body. Other
+// bodies, such as object media, aren't buffered for the audit log.
+func isJSONContentType(h http.Header) bool {
+	return strings.Contains(h.Get("Content-Type"), "json")
+}
+
+// peekRequestBody returns the request body, leaving the request readable.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody || !isJSONContentType(req.Header) {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return ioutil.ReadAll(body)
+	}
+
+	b, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return b, err
+}
+
+// peekResponseBody returns the response body, leaving the response readable.
+func peekResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil || resp.Body == http.NoBody || !isJSONContentType(resp.Header) {
+		return nil, nil
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return b, err
+}
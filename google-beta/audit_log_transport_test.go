@@ -0,0 +1,165 @@
+package google
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAuditLogTransport_writesRedactedRecords(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"kind": "compute#operation", "name": "operation-123", "status": "RUNNING", "secret": "hmac-s3cr3t"}`))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	tr, err := newTransportWithAuditLog(http.DefaultTransport, &AuditLogConfig{Path: path, RedactFields: []string{"custom_secret"}}, "user@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client := &http.Client{Transport: NewTransportWithDefaultRetries(tr)}
+
+	body := `{"name": "foo", "rootPassword": "hunter2", "nested": {"privateKeyData": "abc", "customSecret": "xyz"}}`
+	req, err := http.NewRequest("POST", ts.URL+"/compute/beta/projects/my-project/zones/us-central1-a/instances?alt=json&key=abc", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer ya29.secret")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(respBody), "operation-123") {
+		t.Fatalf("expected response body to be readable after auditing, got %q", respBody)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer f.Close()
+
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid audit record %q: %s", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 audit record, got %d", len(records))
+	}
+
+	raw, _ := json.Marshal(records[0])
+	for _, secret := range []string{"ya29.secret", "hunter2", `"abc"`, "xyz", "key=abc", "hmac-s3cr3t"} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("expected %q to be redacted from audit record %s", secret, raw)
+		}
+	}
+
+	expected := map[string]interface{}{
+		"identity":  "user@example.com",
+		"method":    "POST",
+		"resource":  "projects/my-project/zones/us-central1-a/instances",
+		"operation": "operation-123",
+		"attempt":   1.0,
+		"status":    200.0,
+	}
+	for k, v := range expected {
+		if records[0][k] != v {
+			t.Errorf("expected %s to be %v, got %v", k, v, records[0][k])
+		}
+	}
+	if name := records[0]["request_body"].(map[string]interface{})["name"]; name != "foo" {
+		t.Errorf("expected non-secret request fields to be kept, got %v", name)
+	}
+}
+
+func TestAuditLogTransport_redactsExactFields(t *testing.T) {
+	tr, err := newTransportWithAuditLog(http.DefaultTransport, &AuditLogConfig{Path: filepath.Join(t.TempDir(), "audit.jsonl")}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	body := `{"secretId": "my-secret", "secret": "hmac", "sharedSecret": "vpn", "passwordValidationPolicy": {"minLength": 8}, "user": {"password": "pw"}}`
+	expected := map[string]interface{}{
+		"secretId":                 "my-secret",
+		"secret":                   auditLogRedacted,
+		"sharedSecret":             auditLogRedacted,
+		"passwordValidationPolicy": map[string]interface{}{"minLength": 8.0},
+		"user":                     map[string]interface{}{"password": auditLogRedacted},
+	}
+	if got := tr.(*auditLogTransport).redactBody([]byte(body)); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestAuditLogResourceName(t *testing.T) {
+	cases := map[string]string{
+		"https://compute.googleapis.com/compute/beta/projects/p/zones/z/instances/i":   "projects/p/zones/z/instances/i",
+		"https://cloudresourcemanager.googleapis.com/v1/projects/p:setIamPolicy":       "projects/p",
+		"https://storage.googleapis.com/storage/v1/b/my-bucket/o/my-object":            "b/my-bucket/o/my-object",
+		"https://container.googleapis.com/v1beta1/projects/p/locations/l/operations/o": "projects/p/locations/l/operations/o",
+		"https://example.com/custom": "custom",
+	}
+
+	for raw, expected := range cases {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if actual := auditLogResourceName(u); actual != expected {
+			t.Errorf("bad: %s, expected %q, got %q", raw, expected, actual)
+		}
+	}
+}
+
+func TestAuditLogOperationName(t *testing.T) {
+	cases := map[string]struct {
+		Body     interface{}
+		Expected string
+	}{
+		"compute operation": {
+			Body:     map[string]interface{}{"kind": "compute#operation", "name": "operation-1"},
+			Expected: "operation-1",
+		},
+		"long-running operation": {
+			Body:     map[string]interface{}{"name": "projects/p/locations/l/operations/o", "done": false},
+			Expected: "projects/p/locations/l/operations/o",
+		},
+		"sql operation": {
+			Body:     map[string]interface{}{"name": "abc", "operationType": "CREATE"},
+			Expected: "abc",
+		},
+		"resource": {
+			Body:     map[string]interface{}{"kind": "compute#instance", "name": "instance-1"},
+			Expected: "",
+		},
+		"not an object": {
+			Body:     []interface{}{"a"},
+			Expected: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		if actual := auditLogOperationName(tc.Body); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %q, got %q", tn, tc.Expected, actual)
+		}
+	}
+}
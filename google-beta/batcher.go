@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"sync"
 	"time"
 
@@ -16,13 +17,17 @@ const DefaultBatchSendIntervalSec = 3
 // It should be created at a provider level. In general, one
 // should be created per service that requires batching to:
 //   - prevent blocking batching for one service due to another,
-//   - minimize the possibility of overlap in batchKey formats (see SendRequestWithTimeout)
+//   - minimize the possibility of overlap in batchKey formats (see SendBatchRequestWithTimeout)
+//
+// A single RequestBatcher can batch requests with different body types, as
+// long as a given batchKey is always used with the same types.
 type RequestBatcher struct {
 	sync.Mutex
 
 	*batchingConfig
 	parentCtx context.Context
-	batches   map[string]*startedBatch
+	batches   map[string]pendingBatch
+	inFlight  map[string]chan struct{}
 	debugId   string
 }
 
@@ -30,8 +35,9 @@ type RequestBatcher struct {
 // batch data format and logic to send/combine batches, i.e. they require
 // specific implementations per type of request.
 type (
-	// BatchRequest represents a single request to a global batcher.
-	BatchRequest struct {
+	// BatchRequest represents a single request to a global batcher. B is the
+	// type of the request body and R the type of the response of SendF.
+	BatchRequest[B any, R any] struct {
 		// ResourceName represents the underlying resource for which
 		// a request is made. Its format is determined by what SendF expects, but
 		// typically should be the name of the parent GCP resource being changed.
@@ -39,16 +45,16 @@ type (
 
 		// Body is this request's data to be passed to SendF, and may be combined
 		// with other bodies using CombineF.
-		Body interface{}
+		Body B
 
 		// CombineF function determines how to combine bodies from two batches.
-		CombineF BatcherCombineFunc
+		CombineF BatcherCombineFunc[B]
 
 		// SendF function determines how to actually send a batched request to a
 		// third party service. The arguments given to this function are
 		// (ResourceName, Body) where Body may have been combined with other request
 		// Bodies.
-		SendF BatcherSendFunc
+		SendF BatcherSendFunc[B, R]
 
 		// ID for debugging request. This should be specific to a single request
 		// (i.e. per Terraform resource)
@@ -56,50 +62,65 @@ type (
 	}
 
 	// BatcherCombineFunc is a function type for combine existing batches and additional batch data
-	BatcherCombineFunc func(body interface{}, toAdd interface{}) (interface{}, error)
+	BatcherCombineFunc[B any] func(body B, toAdd B) (B, error)
 
 	// BatcherSendFunc is a function type for sending a batch request
-	BatcherSendFunc func(resourceName string, body interface{}) (interface{}, error)
+	BatcherSendFunc[B any, R any] func(resourceName string, body B) (R, error)
 )
 
 // batchResponse bundles an API response (data, error) tuple.
-type batchResponse struct {
-	body interface{}
+type batchResponse[R any] struct {
+	body R
 	err  error
 }
 
-func (br *batchResponse) IsError() bool {
+func (br *batchResponse[R]) IsError() bool {
 	return br.err != nil
 }
 
+// pendingBatch is the type-independent view of a startedBatch the
+// RequestBatcher uses to manage its batches.
+type pendingBatch interface {
+	// cancel stops the batch from being sent and releases its subscribers.
+	cancel()
+}
+
 // startedBatch refers to a registered batch to group batch requests coming in.
 // The timer manages the time after which a given batch is sent.
-type startedBatch struct {
+type startedBatch[B any, R any] struct {
 	batchKey string
 
 	// Combined Batch Request
-	*BatchRequest
+	*BatchRequest[B, R]
 
 	// subscribers is a registry of the requests (batchSubscriber) combined into this batcher.
 
-	subscribers []batchSubscriber
+	subscribers []batchSubscriber[B, R]
 
 	timer *time.Timer
 }
 
 // batchSubscriber contains information required for a single request for a startedBatch.
-type batchSubscriber struct {
+type batchSubscriber[B any, R any] struct {
 	// singleRequest is the original request this subscriber represents
-	singleRequest *BatchRequest
+	singleRequest *BatchRequest[B, R]
 
 	// respCh is the channel created to communicate the result to a waiting goroutine.s
-	respCh chan batchResponse
+	respCh chan batchResponse[R]
 }
 
 // batchingConfig contains user configuration for controlling batch requests.
 type batchingConfig struct {
 	SendAfter      time.Duration
 	EnableBatching bool
+
+	// MaxBatchSize is the maximum number of requests combined into a batch.
+	// A batch reaching this size is sent immediately. Zero means no limit.
+	MaxBatchSize int
+
+	// MaxConcurrentPerKey is the maximum number of batches with the same
+	// batchKey being sent at the same time. Zero means no limit.
+	MaxConcurrentPerKey int
 }
 
 // Initializes a new batcher.
@@ -108,7 +129,8 @@ func NewRequestBatcher(debugId string, ctx context.Context, config *batchingConf
 		debugId:        debugId,
 		parentCtx:      ctx,
 		batchingConfig: config,
-		batches:        make(map[string]*startedBatch),
+		batches:        make(map[string]pendingBatch),
+		inFlight:       make(map[string]chan struct{}),
 	}
 
 	// Start goroutine to managing stopping the batcher if the provider-level parent context is closed.
@@ -130,14 +152,35 @@ func (b *RequestBatcher) stop() {
 	log.Printf("[DEBUG] Stopping batcher %q", b.debugId)
 	for batchKey, batch := range b.batches {
 		log.Printf("[DEBUG] Cancelling started batch for batchKey %q", batchKey)
-		batch.timer.Stop()
-		for _, l := range batch.subscribers {
-			close(l.respCh)
-		}
+		batch.cancel()
+	}
+}
+
+// acquire blocks until fewer than MaxConcurrentPerKey batches with the given
+// batchKey are being sent, and returns a function to call once the batch has
+// been sent.
+func (b *RequestBatcher) acquire(batchKey string) (func(), error) {
+	if b.MaxConcurrentPerKey <= 0 {
+		return func() {}, nil
+	}
+
+	b.Lock()
+	sem, ok := b.inFlight[batchKey]
+	if !ok {
+		sem = make(chan struct{}, b.MaxConcurrentPerKey)
+		b.inFlight[batchKey] = sem
+	}
+	b.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-b.parentCtx.Done():
+		return nil, fmt.Errorf("parent context of batch %q done while waiting to send: %v", batchKey, b.parentCtx.Err())
 	}
 }
 
-// SendRequestWithTimeout is a blocking call for making a single request, run alone or as part of a batch.
+// SendBatchRequestWithTimeout is a blocking call for making a single request, run alone or as part of a batch.
 // It manages registering the single request with the batcher and waiting on the result.
 //
 // Params:
@@ -151,33 +194,42 @@ func (b *RequestBatcher) stop() {
 // $PROJECT. The calling code uses the template
 // "serviceusage:projects/$PROJECT/services:batchEnable", which mirrors the HTTP request:
 // POST https://serviceusage.googleapis.com/v1/projects/$PROJECT/services:batchEnable
-func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchRequest, timeout time.Duration) (interface{}, error) {
+func SendBatchRequestWithTimeout[B any, R any](b *RequestBatcher, batchKey string, request *BatchRequest[B, R], timeout time.Duration) (R, error) {
+	var empty R
 	if request == nil {
-		return nil, fmt.Errorf("error, cannot request batching for nil BatchRequest")
+		return empty, fmt.Errorf("error, cannot request batching for nil BatchRequest")
 	}
 	if request.CombineF == nil {
-		return nil, fmt.Errorf("error, cannot request batching for BatchRequest with nil CombineF")
+		return empty, fmt.Errorf("error, cannot request batching for BatchRequest with nil CombineF")
 	}
 	if request.SendF == nil {
-		return nil, fmt.Errorf("error, cannot request batching for BatchRequest with nil SendF")
+		return empty, fmt.Errorf("error, cannot request batching for BatchRequest with nil SendF")
 	}
 	if !b.EnableBatching {
 		log.Printf("[DEBUG] Batching is disabled, sending single request for %q", request.DebugId)
+		release, err := b.acquire(batchKey)
+		if err != nil {
+			return empty, err
+		}
+		defer release()
 		return request.SendF(request.ResourceName, request.Body)
 	}
 
-	respCh, err := b.registerBatchRequest(batchKey, request)
+	respCh, err := registerBatchRequest(b, batchKey, request)
 	if err != nil {
-		return nil, fmt.Errorf("error adding request to batch: %s", err)
+		return empty, fmt.Errorf("error adding request to batch: %s", err)
 	}
 
 	ctx, cancel := context.WithTimeout(b.parentCtx, timeout)
 	defer cancel()
 
 	select {
-	case resp := <-respCh:
+	case resp, ok := <-respCh:
+		if !ok {
+			return empty, fmt.Errorf("Request `%s` was cancelled before its batch was sent", request.DebugId)
+		}
 		if resp.err != nil {
-			return nil, errwrap.Wrapf(
+			return empty, errwrap.Wrapf(
 				fmt.Sprintf("Request `%s` returned error: {{err}}", request.DebugId),
 				resp.err)
 		}
@@ -188,20 +240,20 @@ func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchR
 	if b.parentCtx.Err() != nil {
 		switch b.parentCtx.Err() {
 		case context.Canceled:
-			return nil, fmt.Errorf("Parent context of request %s canceled", batchKey)
+			return empty, fmt.Errorf("Parent context of request %s canceled", batchKey)
 		case context.DeadlineExceeded:
-			return nil, fmt.Errorf("Parent context of request %s timed out", batchKey)
+			return empty, fmt.Errorf("Parent context of request %s timed out", batchKey)
 		default:
-			return nil, fmt.Errorf("Parent context of request %s encountered an error: %v", batchKey, ctx.Err())
+			return empty, fmt.Errorf("Parent context of request %s encountered an error: %v", batchKey, ctx.Err())
 		}
 	}
 	switch ctx.Err() {
 	case context.Canceled:
-		return nil, fmt.Errorf("Request %s canceled", batchKey)
+		return empty, fmt.Errorf("Request %s canceled", batchKey)
 	case context.DeadlineExceeded:
-		return nil, fmt.Errorf("Request %s timed out after %v", batchKey, timeout)
+		return empty, fmt.Errorf("Request %s timed out after %v", batchKey, timeout)
 	default:
-		return nil, fmt.Errorf("Error making request %s: %v", batchKey, ctx.Err())
+		return empty, fmt.Errorf("Error making request %s: %v", batchKey, ctx.Err())
 	}
 }
 
@@ -209,13 +261,30 @@ func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchR
 // with the given batchKey. If a batch exists, this will combine the new
 // request into this existing batch. Else, this method manages starting a new
 // batch and adding it to the RequestBatcher's started batches.
-func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *BatchRequest) (<-chan batchResponse, error) {
+func registerBatchRequest[B any, R any](b *RequestBatcher, batchKey string, newRequest *BatchRequest[B, R]) (<-chan batchResponse[R], error) {
 	b.Lock()
 	defer b.Unlock()
 
 	// If batch already exists, combine this request into existing request.
-	if batch, ok := b.batches[batchKey]; ok {
-		return batch.addRequest(newRequest)
+	if existing, ok := b.batches[batchKey]; ok {
+		batch, ok := existing.(*startedBatch[B, R])
+		if !ok {
+			return nil, fmt.Errorf("Provider Error: batch %q has type %T, which can't be combined with request %q", batchKey, existing, newRequest.DebugId)
+		}
+
+		respCh, err := batch.addRequest(newRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		// Send full batches right away instead of waiting for the timer. If the
+		// timer already fired, the batch is already being sent.
+		if b.MaxBatchSize > 0 && len(batch.subscribers) >= b.MaxBatchSize && batch.timer.Stop() {
+			log.Printf("[DEBUG] Batch %q reached maximum size of %d, sending now", batchKey, b.MaxBatchSize)
+			delete(b.batches, batchKey)
+			go sendBatchWithBisection(b, batchKey, batch)
+		}
+		return respCh, nil
 	}
 
 	// Batch doesn't exist for given batch key - create a new batch.
@@ -223,15 +292,15 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 	log.Printf("[DEBUG] Creating new batch %q from request %q", newRequest.DebugId, batchKey)
 
 	// The calling goroutine will need a channel to wait on for a response.
-	respCh := make(chan batchResponse, 1)
-	sub := batchSubscriber{
+	respCh := make(chan batchResponse[R], 1)
+	sub := batchSubscriber[B, R]{
 		singleRequest: newRequest,
 		respCh:        respCh,
 	}
 
 	// Create a new batch with copy of the given batch request.
-	b.batches[batchKey] = &startedBatch{
-		BatchRequest: &BatchRequest{
+	batch := &startedBatch[B, R]{
+		BatchRequest: &BatchRequest[B, R]{
 			ResourceName: newRequest.ResourceName,
 			Body:         newRequest.Body,
 			CombineF:     newRequest.CombineF,
@@ -239,71 +308,170 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 			DebugId:      fmt.Sprintf("Combined batch for started batch %q", batchKey),
 		},
 		batchKey:    batchKey,
-		subscribers: []batchSubscriber{sub},
+		subscribers: []batchSubscriber[B, R]{sub},
 	}
+	b.batches[batchKey] = batch
 
 	// Start a timer to send the request
-	b.batches[batchKey].timer = time.AfterFunc(b.SendAfter, func() {
-		batch := b.popBatch(batchKey)
-		if batch == nil {
+	batch.timer = time.AfterFunc(b.SendAfter, func() {
+		popped := b.popBatch(batchKey, batch)
+		if popped == nil {
 			log.Printf("[ERROR] batch should have been added to saved batches - just run as single request %q", newRequest.DebugId)
 			respCh <- newRequest.send()
 			close(respCh)
 		} else {
-			b.sendBatchWithSingleRetry(batchKey, batch)
+			sendBatchWithBisection(b, batchKey, batch)
 		}
 	})
 
+	if b.MaxBatchSize == 1 && batch.timer.Stop() {
+		delete(b.batches, batchKey)
+		go sendBatchWithBisection(b, batchKey, batch)
+	}
+
 	return respCh, nil
 }
 
-func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *startedBatch) {
+func sendBatchWithBisection[B any, R any](b *RequestBatcher, batchKey string, batch *startedBatch[B, R]) {
+	release, err := b.acquire(batchKey)
+	if err != nil {
+		for _, sub := range batch.subscribers {
+			sub.respCh <- batchResponse[R]{err: err}
+			close(sub.respCh)
+		}
+		return
+	}
+	defer release()
+
 	log.Printf("[DEBUG] Sending batch %q combining %d requests)", batchKey, len(batch.subscribers))
 	resp := batch.send()
 
-	// If the batch failed and combines more than one request, retry each single request.
-	if resp.IsError() && len(batch.subscribers) > 1 {
+	// If the batch failed because of one of the several requests it combines,
+	// bisect it to find the failing requests.
+	if resp.IsError() && shouldBisectBatch(batch.subscribers, resp.err) {
 		log.Printf("[DEBUG] Batch failed with error: %v", resp.err)
-		log.Printf("[DEBUG] Sending each request in batch separately")
+		log.Printf("[DEBUG] Bisecting batch %q to isolate failing requests", batchKey)
+		mid := len(batch.subscribers) / 2
+		sendSubscribersWithBisection(batch, batch.subscribers[:mid])
+		sendSubscribersWithBisection(batch, batch.subscribers[mid:])
+	} else {
+		// Send result to all subscribers
 		for _, sub := range batch.subscribers {
-			log.Printf("[DEBUG] Retrying single request %q", sub.singleRequest.DebugId)
-			singleResp := sub.singleRequest.send()
-			log.Printf("[DEBUG] Retried single request %q returned response: %v", sub.singleRequest.DebugId, singleResp)
-
-			if singleResp.IsError() {
-				singleResp.err = errwrap.Wrapf(
-					fmt.Sprintf("Batch request and retried single request %q both failed. Final error: {{err}}", sub.singleRequest.DebugId),
-					singleResp.err)
+			sub.respCh <- resp
+			close(sub.respCh)
+		}
+	}
+}
+
+// sendSubscribersWithBisection sends the requests of the given subscribers as
+// a single batch. If it fails with an error shouldBisectBatch accepts, the
+// subscribers are split in two halves that are sent separately, until each
+// failing request is sent on its own. Each
+// subscriber receives the result of the smallest batch it was sent in, so
+// a single bad request doesn't fail the requests it was batched with.
+func sendSubscribersWithBisection[B any, R any](batch *startedBatch[B, R], subscribers []batchSubscriber[B, R]) {
+	if len(subscribers) == 1 {
+		sub := subscribers[0]
+		log.Printf("[DEBUG] Retrying single request %q", sub.singleRequest.DebugId)
+		singleResp := sub.singleRequest.send()
+		log.Printf("[DEBUG] Retried single request %q returned response: %v", sub.singleRequest.DebugId, singleResp)
+
+		if singleResp.IsError() {
+			singleResp.err = errwrap.Wrapf(
+				fmt.Sprintf("Batch request and retried single request %q both failed. Final error: {{err}}", sub.singleRequest.DebugId),
+				singleResp.err)
+		}
+		sub.respCh <- singleResp
+		close(sub.respCh)
+		return
+	}
+
+	body := subscribers[0].singleRequest.Body
+	for _, sub := range subscribers[1:] {
+		var err error
+		body, err = batch.CombineF(body, sub.singleRequest.Body)
+		if err != nil {
+			// The requests were combined before, so this shouldn't happen. Fall
+			// back to sending each request on its own.
+			log.Printf("[WARN] Unable to recombine requests of batch %q: %v", batch.batchKey, err)
+			for _, sub := range subscribers {
+				sendSubscribersWithBisection(batch, []batchSubscriber[B, R]{sub})
 			}
-			sub.respCh <- singleResp
+			return
+		}
+	}
+
+	log.Printf("[DEBUG] Sending partial batch %q combining %d requests", batch.batchKey, len(subscribers))
+	partial := &BatchRequest[B, R]{
+		ResourceName: batch.ResourceName,
+		Body:         body,
+		SendF:        batch.SendF,
+	}
+	resp := partial.send()
+	if !resp.IsError() {
+		for _, sub := range subscribers {
+			sub.respCh <- resp
 			close(sub.respCh)
 		}
-	} else {
-		// Send result to all subscribers
-		for _, sub := range batch.subscribers {
+		return
+	}
+
+	log.Printf("[DEBUG] Partial batch failed with error: %v", resp.err)
+	if !shouldBisectBatch(subscribers, resp.err) {
+		for _, sub := range subscribers {
 			sub.respCh <- resp
 			close(sub.respCh)
 		}
+		return
 	}
+	mid := len(subscribers) / 2
+	sendSubscribersWithBisection(batch, subscribers[:mid])
+	sendSubscribersWithBisection(batch, subscribers[mid:])
 }
 
-// popBatch safely gets and removes a batch with given batchkey from the
-// RequestBatcher's started batches.
-func (b *RequestBatcher) popBatch(batchKey string) *startedBatch {
+// shouldBisectBatch returns whether a batch that failed with err should be
+// bisected to isolate its failing requests. That's only the case for errors a
+// single request can cause, 400 and 404, in a batch of differing requests.
+// Other errors, such as quota or permission errors, and batches of identical
+// requests would fail every smaller batch the same way, so the error is
+// returned to every request instead.
+func shouldBisectBatch[B any, R any](subscribers []batchSubscriber[B, R], err error) bool {
+	if len(subscribers) < 2 || !(IsGoogleApiErrorWithCode(err, 400) || IsGoogleApiErrorWithCode(err, 404)) {
+		return false
+	}
+	for _, sub := range subscribers[1:] {
+		if !reflect.DeepEqual(sub.singleRequest.Body, subscribers[0].singleRequest.Body) {
+			return true
+		}
+	}
+	return false
+}
+
+// popBatch safely gets and removes the given batch with given batchkey from
+// the RequestBatcher's started batches. It returns nil if the batch is no
+// longer registered under batchKey.
+func (b *RequestBatcher) popBatch(batchKey string, batch pendingBatch) pendingBatch {
 	b.Lock()
 	defer b.Unlock()
 
-	batch, ok := b.batches[batchKey]
-	if !ok {
+	registered, ok := b.batches[batchKey]
+	if !ok || registered != batch {
 		log.Printf("[DEBUG] Batch with ID %q not found in batcher", batchKey)
 		return nil
 	}
 
 	delete(b.batches, batchKey)
-	return batch
+	return registered
+}
+
+func (batch *startedBatch[B, R]) cancel() {
+	batch.timer.Stop()
+	for _, l := range batch.subscribers {
+		close(l.respCh)
+	}
 }
 
-func (batch *startedBatch) addRequest(newRequest *BatchRequest) (<-chan batchResponse, error) {
+func (batch *startedBatch[B, R]) addRequest(newRequest *BatchRequest[B, R]) (<-chan batchResponse[R], error) {
 	log.Printf("[DEBUG] Adding batch request %q to existing batch %q", newRequest.DebugId, batch.batchKey)
 	if batch.CombineF == nil {
 		return nil, fmt.Errorf("Provider Error: unable to add request %q to batch %q with no CombineF", newRequest.DebugId, batch.batchKey)
@@ -316,8 +484,8 @@ func (batch *startedBatch) addRequest(newRequest *BatchRequest) (<-chan batchRes
 
 	log.Printf("[DEBUG] Added batch request %q to batch. New batch body: %v", newRequest.DebugId, batch.Body)
 
-	respCh := make(chan batchResponse, 1)
-	sub := batchSubscriber{
+	respCh := make(chan batchResponse[R], 1)
+	sub := batchSubscriber[B, R]{
 		singleRequest: newRequest,
 		respCh:        respCh,
 	}
@@ -325,12 +493,12 @@ func (batch *startedBatch) addRequest(newRequest *BatchRequest) (<-chan batchRes
 	return respCh, nil
 }
 
-func (req *BatchRequest) send() batchResponse {
+func (req *BatchRequest[B, R]) send() batchResponse[R] {
 	if req.SendF == nil {
-		return batchResponse{
+		return batchResponse[R]{
 			err: fmt.Errorf("provider error: Batch request has no SendBatch function"),
 		}
 	}
 	v, err := req.SendF(req.ResourceName, req.Body)
-	return batchResponse{v, err}
+	return batchResponse[R]{v, err}
 }
@@ -9,6 +9,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"google.golang.org/api/googleapi"
 )
 
 func TestRequestBatcher_batchSingle(t *testing.T) {
@@ -28,11 +30,11 @@ func TestRequestBatcher_disableBatching(t *testing.T) {
 			EnableBatching: false,
 		})
 
-	testCombine := func(currV interface{}, toAddV interface{}) (interface{}, error) {
-		return currV.(int) + toAddV.(int), nil
+	testCombine := func(currV int, toAddV int) (int, error) {
+		return currV + toAddV, nil
 	}
 
-	testSendBatch := func(name string, body interface{}) (interface{}, error) {
+	testSendBatch := func(name string, body int) (string, error) {
 		return fmt.Sprintf("%s: %d", name, body), nil
 	}
 
@@ -43,7 +45,7 @@ func TestRequestBatcher_disableBatching(t *testing.T) {
 		go func(idx int) {
 			defer wg.Done()
 
-			req := &BatchRequest{
+			req := &BatchRequest[int, string]{
 				DebugId:      fmt.Sprintf("Test Single Requests #%d", idx),
 				ResourceName: "testNoBatching",
 				Body:         1,
@@ -51,15 +53,11 @@ func TestRequestBatcher_disableBatching(t *testing.T) {
 				SendF:        testSendBatch,
 			}
 
-			respV, err := testBatcher.SendRequestWithTimeout(
-				"testDisableBatching", req, time.Duration(1)*time.Second)
+			resp, err := SendBatchRequestWithTimeout(
+				testBatcher, "testDisableBatching", req, time.Duration(1)*time.Second)
 			if err != nil {
 				t.Errorf("got unexpected error %s", err)
 			}
-			resp, ok := respV.(string)
-			if !ok {
-				t.Errorf("test returned an non-string response: %v", resp)
-			}
 			if resp != "testNoBatching: 1" {
 				t.Errorf("expected single request response, got %s", resp)
 			}
@@ -93,7 +91,7 @@ func TestRequestBatcher_errInCombine(t *testing.T) {
 	go func() {
 		defer wg.Done()
 
-		req := &BatchRequest{
+		req := &BatchRequest[interface{}, interface{}]{
 			DebugId:      "errInCombine first",
 			ResourceName: "test-resource",
 			Body:         nil,
@@ -101,7 +99,7 @@ func TestRequestBatcher_errInCombine(t *testing.T) {
 			SendF:        testSendBatch,
 		}
 
-		_, err := testBatcher.SendRequestWithTimeout("testCombineErr", req, time.Duration(10)*time.Second)
+		_, err := SendBatchRequestWithTimeout(testBatcher, "testCombineErr", req, time.Duration(10)*time.Second)
 		if err != nil {
 			t.Errorf("expected no error, got: %s", err)
 		}
@@ -112,7 +110,7 @@ func TestRequestBatcher_errInCombine(t *testing.T) {
 		time.Sleep(time.Second)
 		defer wg.Done()
 
-		req := &BatchRequest{
+		req := &BatchRequest[interface{}, interface{}]{
 			DebugId:      "errInCombine second",
 			ResourceName: "test-resource",
 			Body:         nil,
@@ -120,7 +118,7 @@ func TestRequestBatcher_errInCombine(t *testing.T) {
 			SendF:        testSendBatch,
 		}
 
-		_, err := testBatcher.SendRequestWithTimeout("testCombineErr", req, time.Duration(10)*time.Second)
+		_, err := SendBatchRequestWithTimeout(testBatcher, "testCombineErr", req, time.Duration(10)*time.Second)
 		if err == nil {
 			t.Errorf("expected error, got none")
 		} else if !strings.Contains(err.Error(), combineErrText) {
@@ -141,22 +139,22 @@ func TestRequestBatcher_errInSend(t *testing.T) {
 		})
 
 	// combineF keeps track of the batched indexes
-	testCombine := func(body interface{}, toAdd interface{}) (interface{}, error) {
-		return append(body.([]int), toAdd.([]int)...), nil
+	testCombine := func(body []int, toAdd []int) ([]int, error) {
+		return append(body, toAdd...), nil
 	}
 
 	failIdx := 0
 	testResource := "RESOURCE-SEND-ERROR"
 	expectedErrMsg := fmt.Sprintf("Error - batch %q contains idx %d", testResource, failIdx)
 
-	testSendBatch := func(resourceName string, body interface{}) (interface{}, error) {
+	testSendBatch := func(resourceName string, body []int) (struct{}, error) {
 		log.Printf("[DEBUG] sendBatch body: %+v", body)
-		for _, v := range body.([]int) {
+		for _, v := range body {
 			if v == failIdx {
-				return nil, fmt.Errorf(expectedErrMsg)
+				return struct{}{}, &googleapi.Error{Code: 400, Message: expectedErrMsg}
 			}
 		}
-		return nil, nil
+		return struct{}{}, nil
 	}
 
 	numRequests := 3
@@ -168,7 +166,7 @@ func TestRequestBatcher_errInSend(t *testing.T) {
 		go func(idx int) {
 			defer wg.Done()
 
-			req := &BatchRequest{
+			req := &BatchRequest[[]int, struct{}]{
 				DebugId:      fmt.Sprintf("sendError %d", idx),
 				ResourceName: testResource,
 				Body:         []int{idx},
@@ -176,7 +174,7 @@ func TestRequestBatcher_errInSend(t *testing.T) {
 				SendF:        testSendBatch,
 			}
 
-			_, err := testBatcher.SendRequestWithTimeout("batchSendError", req, time.Duration(10)*time.Second)
+			_, err := SendBatchRequestWithTimeout(testBatcher, "batchSendError", req, time.Duration(10)*time.Second)
 			// Requests without index 0 should have succeeded
 			if idx == failIdx {
 				// We expect an error
@@ -212,12 +210,12 @@ func TestRequestBatcher_errTimeout(t *testing.T) {
 	testResource := "resource for send error"
 
 	// no-op
-	testCombine := func(v interface{}, _ interface{}) (interface{}, error) {
+	testCombine := func(v int, _ int) (int, error) {
 		return v, nil
 	}
 	// no-op
-	testSendBatch := func(resourceName string, cnt interface{}) (interface{}, error) {
-		return nil, nil
+	testSendBatch := func(resourceName string, cnt int) (struct{}, error) {
+		return struct{}{}, nil
 	}
 
 	wg := sync.WaitGroup{}
@@ -226,7 +224,7 @@ func TestRequestBatcher_errTimeout(t *testing.T) {
 	go func() {
 		defer wg.Done()
 
-		req := &BatchRequest{
+		req := &BatchRequest[int, struct{}]{
 			DebugId:      fmt.Sprintf("timeout test"),
 			ResourceName: testResource,
 			Body:         1,
@@ -234,7 +232,7 @@ func TestRequestBatcher_errTimeout(t *testing.T) {
 			SendF:        testSendBatch,
 		}
 
-		_, err := testBatcher.SendRequestWithTimeout("batchTimeout", req, time.Duration(1)*time.Second)
+		_, err := SendBatchRequestWithTimeout(testBatcher, "batchTimeout", req, time.Duration(1)*time.Second)
 		if err == nil {
 			t.Errorf("expected error, got none")
 		} else if !strings.Contains(err.Error(), "timed out") {
@@ -254,11 +252,11 @@ func testBasicCountBatches(t *testing.T, testName string, numBatches int) {
 			EnableBatching: true,
 		})
 
-	testCombine := func(currV interface{}, toAddV interface{}) (interface{}, error) {
-		return currV.(int) + toAddV.(int), nil
+	testCombine := func(currV int, toAddV int) (int, error) {
+		return currV + toAddV, nil
 	}
 
-	testSendBatch := func(name string, body interface{}) (interface{}, error) {
+	testSendBatch := func(name string, body int) (string, error) {
 		return fmt.Sprintf("%s: %d", name, body), nil
 	}
 
@@ -269,7 +267,7 @@ func testBasicCountBatches(t *testing.T, testName string, numBatches int) {
 		go func(idx int) {
 			defer wg.Done()
 
-			req := &BatchRequest{
+			req := &BatchRequest[int, string]{
 				DebugId:      fmt.Sprintf("Test '%s' Request #%d", testName, idx),
 				ResourceName: testName,
 				Body:         1,
@@ -277,14 +275,10 @@ func testBasicCountBatches(t *testing.T, testName string, numBatches int) {
 				SendF:        testSendBatch,
 			}
 
-			respV, err := testBatcher.SendRequestWithTimeout("testBatching", req, time.Duration(6)*time.Second)
+			resp, err := SendBatchRequestWithTimeout(testBatcher, "testBatching", req, time.Duration(6)*time.Second)
 			if err != nil {
 				t.Errorf("got unexpected error %s", err)
 			}
-			resp, ok := respV.(string)
-			if !ok {
-				t.Errorf("test returned an non-string response: %v", resp)
-			}
 			expected := fmt.Sprintf("%s: %d", testName, numBatches)
 			if resp != expected {
 				t.Errorf("expected response %s, got %s", expected, resp)
@@ -292,3 +286,306 @@ func testBasicCountBatches(t *testing.T, testName string, numBatches int) {
 		}(i)
 	}
 }
+
+func TestRequestBatcher_maxBatchSize(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&batchingConfig{
+			SendAfter:      time.Duration(10) * time.Second,
+			EnableBatching: true,
+			MaxBatchSize:   2,
+		})
+
+	testCombine := func(body []int, toAdd []int) ([]int, error) {
+		return append(body, toAdd...), nil
+	}
+
+	testSendBatch := func(_ string, body []int) (int, error) {
+		return len(body), nil
+	}
+
+	numRequests := 4
+	start := time.Now()
+
+	wg := sync.WaitGroup{}
+	wg.Add(numRequests)
+
+	for i := 0; i < numRequests; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			req := &BatchRequest[[]int, int]{
+				DebugId:      fmt.Sprintf("maxBatchSize %d", idx),
+				ResourceName: "test-resource",
+				Body:         []int{idx},
+				CombineF:     testCombine,
+				SendF:        testSendBatch,
+			}
+
+			size, err := SendBatchRequestWithTimeout(testBatcher, "batchMaxSize", req, time.Duration(20)*time.Second)
+			if err != nil {
+				t.Errorf("got unexpected error %s", err)
+			}
+			if size != 2 {
+				t.Errorf("expected request %d to be sent in a batch of 2, got %d", idx, size)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	// Full batches are sent right away, without waiting for SendAfter.
+	if elapsed := time.Since(start); elapsed > time.Duration(5)*time.Second {
+		t.Errorf("expected full batches to be sent immediately, took %s", elapsed)
+	}
+}
+
+func TestRequestBatcher_maxConcurrentPerKey(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&batchingConfig{
+			SendAfter:           time.Duration(1) * time.Second,
+			EnableBatching:      false,
+			MaxConcurrentPerKey: 1,
+		})
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	testCombine := func(body int, toAdd int) (int, error) {
+		return body + toAdd, nil
+	}
+
+	testSendBatch := func(_ string, _ int) (struct{}, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return struct{}{}, nil
+	}
+
+	numRequests := 5
+
+	wg := sync.WaitGroup{}
+	wg.Add(numRequests)
+
+	for i := 0; i < numRequests; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			req := &BatchRequest[int, struct{}]{
+				DebugId:      fmt.Sprintf("maxConcurrentPerKey %d", idx),
+				ResourceName: "test-resource",
+				Body:         1,
+				CombineF:     testCombine,
+				SendF:        testSendBatch,
+			}
+
+			if _, err := SendBatchRequestWithTimeout(testBatcher, "batchConcurrency", req, time.Duration(10)*time.Second); err != nil {
+				t.Errorf("got unexpected error %s", err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if maxInFlight != 1 {
+		t.Errorf("expected at most 1 request in flight per key, got %d", maxInFlight)
+	}
+}
+
+func TestRequestBatcher_mismatchedTypes(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&batchingConfig{
+			SendAfter:      time.Duration(2) * time.Second,
+			EnableBatching: true,
+		})
+
+	intReq := &BatchRequest[int, struct{}]{
+		DebugId:      "int request",
+		ResourceName: "test-resource",
+		Body:         1,
+		CombineF:     func(a int, b int) (int, error) { return a + b, nil },
+		SendF:        func(_ string, _ int) (struct{}, error) { return struct{}{}, nil },
+	}
+	stringReq := &BatchRequest[string, struct{}]{
+		DebugId:      "string request",
+		ResourceName: "test-resource",
+		Body:         "a",
+		CombineF:     func(a string, b string) (string, error) { return a + b, nil },
+		SendF:        func(_ string, _ string) (struct{}, error) { return struct{}{}, nil },
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := SendBatchRequestWithTimeout(testBatcher, "batchMismatch", intReq, time.Duration(5)*time.Second); err != nil {
+			t.Errorf("got unexpected error %s", err)
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	if _, err := SendBatchRequestWithTimeout(testBatcher, "batchMismatch", stringReq, time.Duration(5)*time.Second); err == nil {
+		t.Errorf("expected error combining requests of different types, got none")
+	}
+
+	wg.Wait()
+}
+
+func TestRequestBatcher_bisectFailingRequest(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&batchingConfig{
+			SendAfter:      time.Duration(1) * time.Second,
+			EnableBatching: true,
+		})
+
+	testCombine := func(body []int, toAdd []int) ([]int, error) {
+		return append(body, toAdd...), nil
+	}
+
+	failIdx := 3
+	var mu sync.Mutex
+	sends := 0
+
+	testSendBatch := func(_ string, body []int) (int, error) {
+		mu.Lock()
+		sends++
+		mu.Unlock()
+		for _, v := range body {
+			if v == failIdx {
+				return 0, &googleapi.Error{Code: 400, Message: fmt.Sprintf("invalid request %d", v)}
+			}
+		}
+		return len(body), nil
+	}
+
+	numRequests := 8
+
+	wg := sync.WaitGroup{}
+	wg.Add(numRequests)
+
+	for i := 0; i < numRequests; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			req := &BatchRequest[[]int, int]{
+				DebugId:      fmt.Sprintf("bisect %d", idx),
+				ResourceName: "test-resource",
+				Body:         []int{idx},
+				CombineF:     testCombine,
+				SendF:        testSendBatch,
+			}
+
+			size, err := SendBatchRequestWithTimeout(testBatcher, "batchBisect", req, time.Duration(10)*time.Second)
+			if idx == failIdx {
+				if err == nil || !strings.Contains(err.Error(), "invalid request 3") {
+					t.Errorf("expected error for request %d, got %v", idx, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected request %d to succeed, got error: %v", idx, err)
+			}
+			if size < 1 {
+				t.Errorf("expected request %d to get the response of its partial batch, got %d", idx, size)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	// The full batch, then two halves at each of the three levels of bisection,
+	// instead of the full batch and each of the 8 requests on its own.
+	if sends != 7 {
+		t.Errorf("expected 7 requests to be sent, got %d", sends)
+	}
+}
+
+func TestRequestBatcher_sharedErrorNotBisected(t *testing.T) {
+	cases := map[string]struct {
+		Body func(idx int) []int
+		Err  error
+	}{
+		"quota error": {
+			Body: func(idx int) []int { return []int{idx} },
+			Err:  &googleapi.Error{Code: 429, Message: "quota exceeded"},
+		},
+		"permission error": {
+			Body: func(idx int) []int { return []int{idx} },
+			Err:  &googleapi.Error{Code: 403, Message: "permission denied"},
+		},
+		"identical requests": {
+			Body: func(idx int) []int { return []int{0} },
+			Err:  &googleapi.Error{Code: 400, Message: "invalid request"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testBatcher := NewRequestBatcher(
+				"testBatcher",
+				context.Background(),
+				&batchingConfig{
+					SendAfter:      time.Duration(1) * time.Second,
+					EnableBatching: true,
+				})
+
+			testCombine := func(body []int, toAdd []int) ([]int, error) {
+				return append(body, toAdd...), nil
+			}
+
+			var mu sync.Mutex
+			sends := 0
+			testSendBatch := func(_ string, body []int) (struct{}, error) {
+				mu.Lock()
+				sends++
+				mu.Unlock()
+				return struct{}{}, tc.Err
+			}
+
+			numRequests := 4
+
+			wg := sync.WaitGroup{}
+			wg.Add(numRequests)
+
+			for i := 0; i < numRequests; i++ {
+				go func(idx int) {
+					defer wg.Done()
+
+					req := &BatchRequest[[]int, struct{}]{
+						DebugId:      fmt.Sprintf("shared error %d", idx),
+						ResourceName: "test-resource",
+						Body:         tc.Body(idx),
+						CombineF:     testCombine,
+						SendF:        testSendBatch,
+					}
+
+					if _, err := SendBatchRequestWithTimeout(testBatcher, "batchSharedError", req, time.Duration(10)*time.Second); err == nil {
+						t.Errorf("expected error for request %d, got none", idx)
+					}
+				}(i)
+			}
+
+			wg.Wait()
+
+			if sends != 1 {
+				t.Errorf("expected the batch to be sent once, got %d requests", sends)
+			}
+		})
+	}
+}
@@ -58,5 +58,5 @@ func CertificateManagerOperationWaitTime(config *Config, op map[string]interface
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("certificate_manager"))
 }
@@ -55,7 +55,7 @@ func CloudBuildOperationWaitTimeWithResponse(config *Config, op map[string]inter
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("cloud_build")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -71,5 +71,5 @@ func CloudBuildOperationWaitTime(config *Config, op map[string]interface{}, proj
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("cloud_build"))
 }
@@ -55,7 +55,7 @@ func Cloudfunctions2OperationWaitTimeWithResponse(config *Config, op map[string]
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("cloudfunctions2")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -71,5 +71,5 @@ func Cloudfunctions2OperationWaitTime(config *Config, op map[string]interface{},
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("cloudfunctions2"))
 }
@@ -26,5 +26,5 @@ func cloudFunctionsOperationWait(config *Config, op *cloudfunctions.Operation, a
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("cloud_functions"))
 }
@@ -1,11 +1,15 @@
 package google
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 )
 
@@ -51,6 +55,30 @@ type Waiter interface {
 	TargetStates() []string
 }
 
+// ProgressReporter is implemented by Waiters whose operations report their
+// progress, which is logged while waiting on them.
+type ProgressReporter interface {
+	// Progress returns a human readable description of the progress of the
+	// operation, or an empty string if it doesn't report any.
+	Progress() string
+}
+
+// OperationWaitTimeoutError is returned by OperationWait when the operation
+// didn't finish within the timeout. The operation may still be running.
+type OperationWaitTimeoutError struct {
+	// OpName is the name of the operation that was being waited on.
+	OpName string
+	err    error
+}
+
+func (e *OperationWaitTimeoutError) Error() string {
+	return e.err.Error()
+}
+
+func (e *OperationWaitTimeoutError) Unwrap() error {
+	return e.err
+}
+
 type CommonOperationWaiter struct {
 	Op CommonOperation
 }
@@ -89,6 +117,35 @@ func (w *CommonOperationWaiter) OpName() string {
 	return w.Op.Name
 }
 
+// Progress returns the progress reported in the operation metadata, if any.
+// Long-running operations don't have a standard progress field, so the
+// fields used by the most common APIs are checked.
+func (w *CommonOperationWaiter) Progress() string {
+	if w == nil || len(w.Op.Metadata) == 0 {
+		return ""
+	}
+
+	var md map[string]interface{}
+	if err := json.Unmarshal(w.Op.Metadata, &md); err != nil {
+		return ""
+	}
+
+	var parts []string
+	for _, k := range []string{"progressPercent", "progressPercentage"} {
+		if v, ok := md[k]; ok {
+			parts = append(parts, fmt.Sprintf("%v%%", v))
+			break
+		}
+	}
+	for _, k := range []string{"statusDetail", "statusMessage"} {
+		if v, ok := md[k].(string); ok && v != "" {
+			parts = append(parts, v)
+			break
+		}
+	}
+	return strings.Join(parts, " - ")
+}
+
 func (w *CommonOperationWaiter) PendingStates() []string {
 	return []string{"done: false"}
 }
@@ -107,6 +164,7 @@ func OperationDone(w Waiter) bool {
 }
 
 func CommonRefreshFunc(w Waiter) resource.StateRefreshFunc {
+	var lastProgress string
 	return func() (interface{}, string, error) {
 		op, err := w.QueryOp()
 		if err != nil {
@@ -131,11 +189,17 @@ func CommonRefreshFunc(w Waiter) resource.StateRefreshFunc {
 		}
 
 		log.Printf("[DEBUG] Got %v while polling for operation %s's status", w.State(), w.OpName())
+		if pr, ok := w.(ProgressReporter); ok {
+			if progress := pr.Progress(); progress != "" && progress != lastProgress {
+				log.Printf("[INFO] Operation %s progress: %s", w.OpName(), progress)
+				lastProgress = progress
+			}
+		}
 		return op, w.State(), nil
 	}
 }
 
-func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
+func OperationWait(w Waiter, activity string, timeout time.Duration, polling PollingStrategy) error {
 	if OperationDone(w) {
 		if w.Error() != nil {
 			return w.Error()
@@ -144,16 +208,19 @@ func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterva
 	}
 
 	c := &resource.StateChangeConf{
-		Pending:      w.PendingStates(),
-		Target:       w.TargetStates(),
-		Refresh:      CommonRefreshFunc(w),
-		Timeout:      timeout,
-		MinTimeout:   2 * time.Second,
-		PollInterval: pollInterval,
-	}
-	opRaw, err := c.WaitForState()
+		Pending: w.PendingStates(),
+		Target:  w.TargetStates(),
+		Refresh: CommonRefreshFunc(w),
+		Timeout: timeout,
+	}
+	opRaw, err := polling.WaitForState(c)
 	if err != nil {
-		return fmt.Errorf("Error waiting for %s: %s", activity, err)
+		waitErr := fmt.Errorf("Error waiting for %s: %s", activity, err)
+		var timeoutErr *resource.TimeoutError
+		if opName := w.OpName(); errors.As(err, &timeoutErr) && !strings.HasPrefix(opName, "<nil>") {
+			return &OperationWaitTimeoutError{OpName: opName, err: waitErr}
+		}
+		return waitErr
 	}
 
 	err = w.SetOp(opRaw)
@@ -167,6 +234,73 @@ func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterva
 	return nil
 }
 
+// PersistPendingOperation stores the name of an operation whose wait failed
+// in the computed "operation" field of a resource, if the operation may still
+// be running: either the wait timed out, or Terraform is being terminated.
+// The Read function of the resource should then resume waiting on the
+// operation with ResumePendingOperation, instead of the resource being
+// recreated. It returns whether the operation was persisted, in which case
+// the caller should return the error it returns, which is nil unless the
+// operation couldn't be stored: the apply succeeds and the resource isn't
+// tainted.
+func PersistPendingOperation(d *schema.ResourceData, config *Config, opName string, waitErr error) (bool, error) {
+	var timeoutErr *OperationWaitTimeoutError
+	timedOut := errors.As(waitErr, &timeoutErr)
+
+	terminated := false
+	if config.context != nil {
+		select {
+		case <-config.context.Done():
+			terminated = true
+		default:
+			// leaving default case to ensure this is non blocking
+		}
+	}
+
+	if !timedOut && !terminated {
+		return false, nil
+	}
+	if timedOut && timeoutErr.OpName != "" {
+		opName = timeoutErr.OpName
+	}
+
+	log.Printf("[WARN] Persisting operation %s so that waiting on it is resumed on the next refresh: %s", opName, waitErr)
+	if err := d.Set("operation", opName); err != nil {
+		return false, fmt.Errorf("Error setting operation: %s", err)
+	}
+	return true, nil
+}
+
+// ResumePendingOperation calls wait with the name of the operation persisted
+// by PersistPendingOperation, if any, and clears it. If the operation still
+// doesn't finish in time, it's persisted again and no error is returned, so
+// that refreshing the resource doesn't fail while it's being created.
+func ResumePendingOperation(d *schema.ResourceData, config *Config, wait func(opName string) error) error {
+	opName := d.Get("operation").(string)
+	if opName == "" {
+		return nil
+	}
+
+	log.Printf("[DEBUG] in progress operation detected at %v, attempting to resume", opName)
+	if err := d.Set("operation", ""); err != nil {
+		return fmt.Errorf("Error setting operation: %s", err)
+	}
+
+	waitErr := wait(opName)
+	if waitErr == nil {
+		return nil
+	}
+	persisted, err := PersistPendingOperation(d, config, opName, waitErr)
+	if persisted {
+		// The operation is still running, it's resumed again on the next refresh.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return waitErr
+}
+
 // The cloud resource manager API operation is an example of one of many
 // interchangeable API operations. Choose it somewhat arbitrarily to represent
 // the "common" operation.
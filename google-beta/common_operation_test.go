@@ -1,9 +1,13 @@
 package google
 
 import (
+	"context"
+	"errors"
 	"net/url"
 	"testing"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type TestWaiter struct {
@@ -55,7 +59,7 @@ func TestOperationWait_TimeoutsShouldRetry(t *testing.T) {
 	testWaiter := TestWaiter{
 		runCount: 0,
 	}
-	err := OperationWait(&testWaiter, "my-activity", 1*time.Minute, 0*time.Second)
+	err := OperationWait(&testWaiter, "my-activity", 1*time.Minute, PollingStrategy{})
 	if err != nil {
 		t.Fatalf("unexpected error waiting for operation: got '%v', want 'nil'", err)
 	}
@@ -65,3 +69,106 @@ func TestOperationWait_TimeoutsShouldRetry(t *testing.T) {
 			expectedRunCount, testWaiter.runCount)
 	}
 }
+
+type runningWaiter struct {
+	TestWaiter
+}
+
+func (w *runningWaiter) State() string {
+	return "RUNNING"
+}
+
+func (w *runningWaiter) QueryOp() (interface{}, error) {
+	return "my return value", nil
+}
+
+func (runningWaiter) PendingStates() []string {
+	return []string{"RUNNING"}
+}
+
+func TestOperationWait_TimeoutReturnsOperationName(t *testing.T) {
+	err := OperationWait(&runningWaiter{}, "my-activity", 1*time.Second, PollingStrategy{})
+
+	var timeoutErr *OperationWaitTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected an OperationWaitTimeoutError, got %v", err)
+	}
+	if timeoutErr.OpName != "my-operation-name" {
+		t.Errorf("expected operation name %q, got %q", "my-operation-name", timeoutErr.OpName)
+	}
+}
+
+func TestPersistAndResumePendingOperation(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"operation": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+	d := r.TestResourceData()
+	config := &Config{context: context.Background()}
+
+	persisted, err := PersistPendingOperation(d, config, "my-operation-name", errors.New("operation failed"))
+	if err != nil || persisted {
+		t.Fatalf("expected a failed operation not to be persisted, got %v, %v", persisted, err)
+	}
+
+	timeoutErr := &OperationWaitTimeoutError{OpName: "my-operation-name", err: errors.New("timeout")}
+	persisted, err = PersistPendingOperation(d, config, "", timeoutErr)
+	if err != nil || !persisted {
+		t.Fatalf("expected a timed out operation to be persisted without error, got %v, %v", persisted, err)
+	}
+	if got := d.Get("operation").(string); got != "my-operation-name" {
+		t.Fatalf("expected operation %q to be persisted, got %q", "my-operation-name", got)
+	}
+
+	// The operation still doesn't finish, so it stays persisted.
+	if err := ResumePendingOperation(d, config, func(opName string) error {
+		return timeoutErr
+	}); err != nil {
+		t.Fatalf("unexpected error resuming operation: %s", err)
+	}
+	if got := d.Get("operation").(string); got != "my-operation-name" {
+		t.Fatalf("expected operation %q to stay persisted, got %q", "my-operation-name", got)
+	}
+
+	// An interrupted run persists the operation without error.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	persisted, err = PersistPendingOperation(d, &Config{context: ctx}, "my-operation-name", errors.New("context canceled"))
+	if err != nil || !persisted {
+		t.Fatalf("expected an interrupted operation to be persisted without error, got %v, %v", persisted, err)
+	}
+
+	var resumed string
+	if err := ResumePendingOperation(d, config, func(opName string) error {
+		resumed = opName
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error resuming operation: %s", err)
+	}
+	if resumed != "my-operation-name" {
+		t.Errorf("expected operation %q to be resumed, got %q", "my-operation-name", resumed)
+	}
+	if got := d.Get("operation").(string); got != "" {
+		t.Errorf("expected operation to be cleared, got %q", got)
+	}
+}
+
+func TestCommonOperationWaiter_Progress(t *testing.T) {
+	w := &CommonOperationWaiter{
+		Op: CommonOperation{
+			Metadata: []byte(`{"progressPercent": 40, "statusDetail": "Creating instances"}`),
+		},
+	}
+	if got, want := w.Progress(), "40% - Creating instances"; got != want {
+		t.Errorf("expected progress %q, got %q", want, got)
+	}
+
+	w.Op.Metadata = []byte(`{"createTime": "2022-01-01T00:00:00Z"}`)
+	if got := w.Progress(); got != "" {
+		t.Errorf("expected no progress, got %q", got)
+	}
+}
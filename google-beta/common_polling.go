@@ -32,26 +32,282 @@ func SuccessPollResult() PollResult {
 	return nil
 }
 
+// PollingStrategy controls how often an operation or resource is polled while
+// waiting for it to reach a state. Polling starts InitialInterval apart, and
+// the interval grows geometrically by Multiplier up to MaxInterval, so fast
+// operations are noticed quickly and slow ones don't use up read quota.
+//
+// The zero value polls without waiting.
+type PollingStrategy struct {
+	InitialInterval time.Duration
+	// MaxInterval caps the interval. Zero means no cap.
+	MaxInterval time.Duration
+	// Multiplier is applied to the interval after each poll. Values below 1
+	// keep the interval fixed.
+	Multiplier float64
+}
+
+// DefaultPollingStrategy returns the strategy used when the provider doesn't
+// configure a `polling` block: polls start 1 second apart, and back off by
+// 1.5x up to 30 seconds.
+func DefaultPollingStrategy() PollingStrategy {
+	return PollingStrategy{
+		InitialInterval: 1 * time.Second,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      1.5,
+	}
+}
+
+// FixedPollingStrategy returns a strategy polling at a fixed interval.
+func FixedPollingStrategy(interval time.Duration) PollingStrategy {
+	return PollingStrategy{
+		InitialInterval: interval,
+		MaxInterval:     interval,
+		Multiplier:      1,
+	}
+}
+
+// Interval returns the wait before the poll following the given poll
+// (starting at 1).
+func (s PollingStrategy) Interval(poll int) time.Duration {
+	interval := float64(s.InitialInterval)
+	for i := 1; i < poll && s.Multiplier > 1; i++ {
+		if s.MaxInterval > 0 && interval >= float64(s.MaxInterval) {
+			break
+		}
+		interval *= s.Multiplier
+	}
+	if s.MaxInterval > 0 && interval > float64(s.MaxInterval) {
+		return s.MaxInterval
+	}
+	return time.Duration(interval)
+}
+
+// WaitForState runs c.WaitForState, polling c.Refresh with the strategy.
+// StateChangeConf only supports a fixed poll interval, so the strategy waits
+// in the refresh function instead, and the interval of c is kept negligible.
+//
+// Once a target state has been read, the polls confirming it for
+// c.ContinuousTargetOccurence are InitialInterval apart: backing off there
+// would only delay a state that is already reached. The backoff restarts if
+// the state goes back to pending.
+func (s PollingStrategy) WaitForState(c *resource.StateChangeConf) (interface{}, error) {
+	deadline := time.Now().Add(c.Timeout)
+	refresh := c.Refresh
+	polls := 0
+	confirming := false
+
+	c.MinTimeout = 0
+	c.PollInterval = time.Millisecond
+	c.Refresh = func() (interface{}, string, error) {
+		if polls > 0 {
+			wait := s.Interval(polls)
+			if confirming {
+				wait = s.InitialInterval
+			}
+			// Don't wait past the timeout, so the last poll happens before
+			// StateChangeConf gives up.
+			if remaining := time.Until(deadline); wait > remaining {
+				wait = remaining
+			}
+			if wait > 0 {
+				log.Printf("[TRACE] Waiting %s before next poll", wait)
+				time.Sleep(wait)
+			}
+		}
+		res, state, err := refresh()
+		confirming = false
+		for _, target := range c.Target {
+			if state == target {
+				confirming = true
+			}
+		}
+		if confirming {
+			polls = 1
+		} else {
+			polls++
+		}
+		return res, state, err
+	}
+	return c.WaitForState()
+}
+
+// PollingServices are the services whose polling strategy can be overridden
+// with a `polling` block, as passed to PollingConfig.For.
+var PollingServices = []string{
+	"access_context_manager",
+	"active_directory",
+	"alloydb",
+	"api_gateway",
+	"apigee",
+	"app_engine",
+	"artifact_registry",
+	"beyondcorp",
+	"big_query",
+	"certificate_manager",
+	"cloud_build",
+	"cloud_functions",
+	"cloud_identity",
+	"cloud_ids",
+	"cloud_run",
+	"cloud_run_v2",
+	"cloudfunctions2",
+	"composer",
+	"compute",
+	"container",
+	"container_attached",
+	"data_fusion",
+	"data_loss_prevention",
+	"dataproc",
+	"dataproc_metastore",
+	"datastore",
+	"datastream",
+	"deployment_manager",
+	"dialogflow_cx",
+	"filestore",
+	"firebase",
+	"firestore",
+	"game_services",
+	"gke_backup",
+	"gke_hub",
+	"iam",
+	"iam2",
+	"iam_beta",
+	"iam_workforce_pool",
+	"iap",
+	"logging",
+	"memcache",
+	"ml_engine",
+	"monitoring",
+	"network_management",
+	"network_security",
+	"network_services",
+	"notebooks",
+	"privateca",
+	"pubsub",
+	"redis",
+	"resource_manager",
+	"service_management",
+	"service_networking",
+	"service_usage",
+	"spanner",
+	"sql",
+	"storage",
+	"tags",
+	"tags_location",
+	"tpu",
+	"vertex_ai",
+	"vpc_access",
+	"workflows",
+	"workstations",
+}
+
+// PollingConfig holds the polling strategies configured on the provider.
+type PollingConfig struct {
+	Default PollingStrategy
+	// Overrides are per service strategies, keyed by the service name used in
+	// its `<service>_custom_endpoint` provider field, e.g. "container".
+	Overrides map[string]PollingStrategy
+}
+
+// PollingSettings are the settings of a single `polling` block. Zero values
+// are unset, and are inherited from the default strategy.
+type PollingSettings struct {
+	// Service is empty for the block setting the default strategy.
+	Service         string
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+}
+
+// NewPollingConfig returns the polling configuration for the given `polling`
+// blocks.
+func NewPollingConfig(settings []PollingSettings) (PollingConfig, error) {
+	cfg := PollingConfig{
+		Default: DefaultPollingStrategy(),
+	}
+
+	var overrides []PollingSettings
+	seenDefault := false
+	for _, ps := range settings {
+		if ps.Service != "" {
+			overrides = append(overrides, ps)
+			continue
+		}
+		if seenDefault {
+			return cfg, fmt.Errorf("only one 'polling' block may omit 'service'")
+		}
+		seenDefault = true
+		cfg.Default = ps.apply(cfg.Default)
+	}
+	if err := cfg.Default.validate(); err != nil {
+		return cfg, fmt.Errorf("invalid default 'polling' block: %s", err)
+	}
+
+	for _, ps := range overrides {
+		if cfg.Overrides == nil {
+			cfg.Overrides = make(map[string]PollingStrategy)
+		}
+		if _, ok := cfg.Overrides[ps.Service]; ok {
+			return cfg, fmt.Errorf("'polling' is set more than once for service %q", ps.Service)
+		}
+		s := ps.apply(cfg.Default)
+		if err := s.validate(); err != nil {
+			return cfg, fmt.Errorf("invalid 'polling' block for service %q: %s", ps.Service, err)
+		}
+		cfg.Overrides[ps.Service] = s
+	}
+
+	return cfg, nil
+}
+
+func (ps PollingSettings) apply(s PollingStrategy) PollingStrategy {
+	if ps.InitialInterval > 0 {
+		s.InitialInterval = ps.InitialInterval
+	}
+	if ps.MaxInterval > 0 {
+		s.MaxInterval = ps.MaxInterval
+	}
+	if ps.Multiplier > 0 {
+		s.Multiplier = ps.Multiplier
+	}
+	return s
+}
+
+func (s PollingStrategy) validate() error {
+	if s.MaxInterval > 0 && s.MaxInterval < s.InitialInterval {
+		return fmt.Errorf("'max_interval' (%s) must not be less than 'initial_interval' (%s)", s.MaxInterval, s.InitialInterval)
+	}
+	if s.Multiplier < 1 {
+		return fmt.Errorf("'multiplier' (%v) must be at least 1", s.Multiplier)
+	}
+	return nil
+}
+
+// For returns the strategy used to poll operations and resources of the
+// given service.
+func (c PollingConfig) For(service string) PollingStrategy {
+	if s, ok := c.Overrides[service]; ok {
+		return s
+	}
+	return c.Default
+}
+
 func PollingWaitTime(pollF PollReadFunc, checkResponse PollCheckResponseFunc, activity string,
-	timeout time.Duration, targetOccurrences int) error {
+	timeout time.Duration, targetOccurrences int, polling PollingStrategy) error {
 	log.Printf("[DEBUG] %s: Polling until expected state is read", activity)
 	log.Printf("[DEBUG] Target occurrences: %d", targetOccurrences)
-	if targetOccurrences == 1 {
-		return resource.Retry(timeout, func() *resource.RetryError {
-			readResp, readErr := pollF()
-			return checkResponse(readResp, readErr)
-		})
-	}
-	return RetryWithTargetOccurrences(timeout, targetOccurrences, func() *resource.RetryError {
+	return RetryWithTargetOccurrences(timeout, targetOccurrences, polling, func() *resource.RetryError {
 		readResp, readErr := pollF()
 		return checkResponse(readResp, readErr)
 	})
 }
 
 // RetryWithTargetOccurrences is a basic wrapper around StateChangeConf that will retry
-// a function until it returns the specified amount of target occurrences continuously.
+// a function until it returns the specified amount of target occurrences continuously,
+// waiting between tries as set by the polling strategy.
 // Adapted from the Retry function in the go SDK.
-func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
+func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int, polling PollingStrategy,
 	f resource.RetryFunc) error {
 	// These are used to pull the error out of the function; need a mutex to
 	// avoid a data race.
@@ -62,7 +318,6 @@ func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
 		Pending:                   []string{"retryableerror"},
 		Target:                    []string{"success"},
 		Timeout:                   timeout,
-		MinTimeout:                500 * time.Millisecond,
 		ContinuousTargetOccurence: targetOccurrences,
 		Refresh: func() (interface{}, string, error) {
 			rerr := f()
@@ -84,7 +339,7 @@ func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
 		},
 	}
 
-	_, waitErr := c.WaitForState()
+	_, waitErr := polling.WaitForState(c)
 
 	// Need to acquire the lock here to be able to avoid race using resultErr as
 	// the return value
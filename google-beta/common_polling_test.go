@@ -0,0 +1,182 @@
+package google
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestPollingStrategy_Interval(t *testing.T) {
+	s := PollingStrategy{
+		InitialInterval: 1 * time.Second,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+	}
+
+	expected := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := s.Interval(i + 1); got != want {
+			t.Errorf("expected interval %s after poll %d, got %s", want, i+1, got)
+		}
+	}
+
+	fixed := FixedPollingStrategy(3 * time.Second)
+	for poll := 1; poll <= 3; poll++ {
+		if got := fixed.Interval(poll); got != 3*time.Second {
+			t.Errorf("expected fixed interval %s after poll %d, got %s", 3*time.Second, poll, got)
+		}
+	}
+}
+
+func TestPollingStrategy_WaitForState(t *testing.T) {
+	s := PollingStrategy{
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     40 * time.Millisecond,
+		Multiplier:      2,
+	}
+
+	var polls []time.Time
+	c := &resource.StateChangeConf{
+		Pending: []string{"RUNNING"},
+		Target:  []string{"DONE"},
+		Timeout: 1 * time.Minute,
+		Refresh: func() (interface{}, string, error) {
+			polls = append(polls, time.Now())
+			if len(polls) == 5 {
+				return "done", "DONE", nil
+			}
+			return "running", "RUNNING", nil
+		},
+	}
+	if _, err := s.WaitForState(c); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(polls) != 5 {
+		t.Fatalf("expected 5 polls, got %d", len(polls))
+	}
+	for i := 1; i < len(polls); i++ {
+		if wait, min := polls[i].Sub(polls[i-1]), s.Interval(i); wait < min {
+			t.Errorf("expected at least %s between polls %d and %d, got %s", min, i, i+1, wait)
+		}
+	}
+}
+
+func TestNewPollingConfig(t *testing.T) {
+	cases := map[string]struct {
+		settings []PollingSettings
+		service  string
+		expected PollingStrategy
+		wantErr  bool
+	}{
+		"no settings": {
+			service:  "container",
+			expected: DefaultPollingStrategy(),
+		},
+		"override inherits default": {
+			settings: []PollingSettings{
+				{MaxInterval: 1 * time.Minute},
+				{Service: "container", InitialInterval: 5 * time.Second},
+			},
+			service:  "container",
+			expected: PollingStrategy{InitialInterval: 5 * time.Second, MaxInterval: 1 * time.Minute, Multiplier: 1.5},
+		},
+		"other service uses default": {
+			settings: []PollingSettings{
+				{Service: "container", InitialInterval: 5 * time.Second},
+			},
+			service:  "compute",
+			expected: DefaultPollingStrategy(),
+		},
+		"duplicate default": {
+			settings: []PollingSettings{{}, {}},
+			wantErr:  true,
+		},
+		"duplicate service": {
+			settings: []PollingSettings{{Service: "sql"}, {Service: "sql"}},
+			wantErr:  true,
+		},
+		"max below initial": {
+			settings: []PollingSettings{{Service: "sql", InitialInterval: 1 * time.Minute}},
+			wantErr:  true,
+		},
+	}
+
+	for tn, tc := range cases {
+		cfg, err := NewPollingConfig(tc.settings)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", tn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tn, err)
+			continue
+		}
+		if got := cfg.For(tc.service); got != tc.expected {
+			t.Errorf("%s: expected %+v, got %+v", tn, tc.expected, got)
+		}
+	}
+}
+
+func TestPollingStrategy_WaitForStateConfirmingTarget(t *testing.T) {
+	s := PollingStrategy{
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     1 * time.Second,
+		Multiplier:      4,
+	}
+
+	polls := 0
+	c := &resource.StateChangeConf{
+		Pending:                   []string{"RUNNING"},
+		Target:                    []string{"DONE"},
+		Timeout:                   1 * time.Minute,
+		ContinuousTargetOccurence: 10,
+		Refresh: func() (interface{}, string, error) {
+			polls++
+			if polls < 3 {
+				return "running", "RUNNING", nil
+			}
+			return "done", "DONE", nil
+		},
+	}
+	start := time.Now()
+	if _, err := s.WaitForState(c); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Backing off while confirming would wait at least 10ms+40ms+160ms+640ms
+	// and then 1s per poll.
+	if elapsed := time.Since(start); elapsed > 1*time.Second {
+		t.Errorf("expected the target to be confirmed at the initial interval, took %s", elapsed)
+	}
+	if polls != 12 {
+		t.Errorf("expected 12 polls, got %d", polls)
+	}
+}
+
+// TestPollingServices checks that PollingServices lists every service passed
+// to PollingConfig.For, so that their `polling` blocks pass validation.
+func TestPollingServices(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	re := regexp.MustCompile(`Polling\.For\("([^"]+)"\)`)
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range re.FindAllSubmatch(b, -1) {
+			if service := string(m[1]); !stringInSlice(PollingServices, service) {
+				t.Errorf("%s: service %q is missing from PollingServices", f, service)
+			}
+		}
+	}
+}
@@ -26,5 +26,5 @@ func ComposerOperationWaitTime(config *Config, op *composer.Operation, project,
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("composer"))
 }
@@ -0,0 +1,49 @@
+package google
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	batchKeyTmplComputeProjectMetadata = "projects/%s/setCommonInstanceMetadata"
+)
+
+// computeMetadataItemChange is a change to a single key of the common
+// instance metadata of a project. A nil Value removes the key.
+type computeMetadataItemChange struct {
+	Key           string
+	Value         *string
+	FailIfPresent metadataPresentBehavior
+}
+
+// BatchRequestUpdateProjectMetadataItem can be used to batch changes to
+// project metadata keys across resource nodes, i.e. to batch creation of
+// several google_compute_project_metadata_item resources into a single
+// setCommonInstanceMetadata call.
+func BatchRequestUpdateProjectMetadataItem(projectID string, change computeMetadataItemChange, userAgent string, config *Config, timeout time.Duration) error {
+	req := &BatchRequest[[]computeMetadataItemChange, struct{}]{
+		ResourceName: projectID,
+		Body:         []computeMetadataItemChange{change},
+		CombineF:     combineComputeMetadataItemChanges,
+		SendF:        sendBatchFuncUpdateProjectMetadata(config, userAgent, timeout),
+		DebugId:      fmt.Sprintf("Update Project Metadata Item %q for project %q", change.Key, projectID),
+	}
+
+	_, err := SendBatchRequestWithTimeout(
+		config.requestBatcherCompute,
+		fmt.Sprintf(batchKeyTmplComputeProjectMetadata, projectID),
+		req,
+		timeout)
+	return err
+}
+
+func combineComputeMetadataItemChanges(changes []computeMetadataItemChange, toAdd []computeMetadataItemChange) ([]computeMetadataItemChange, error) {
+	return append(changes, toAdd...), nil
+}
+
+func sendBatchFuncUpdateProjectMetadata(config *Config, userAgent string, timeout time.Duration) BatcherSendFunc[[]computeMetadataItemChange, struct{}] {
+	return func(projectID string, changes []computeMetadataItemChange) (struct{}, error) {
+		return struct{}{}, updateComputeCommonInstanceMetadata(config, projectID, userAgent, changes, timeout)
+	}
+}
@@ -89,6 +89,17 @@ func (w *ComputeOperationWaiter) OpName() string {
 	return w.Op.Name
 }
 
+func (w *ComputeOperationWaiter) Progress() string {
+	if w == nil || w.Op == nil || w.Op.Progress == 0 {
+		return ""
+	}
+
+	if w.Op.StatusMessage != "" {
+		return fmt.Sprintf("%d%% - %s", w.Op.Progress, w.Op.StatusMessage)
+	}
+	return fmt.Sprintf("%d%%", w.Op.Progress)
+}
+
 func (w *ComputeOperationWaiter) PendingStates() []string {
 	return []string{"PENDING", "RUNNING"}
 }
@@ -114,7 +125,7 @@ func ComputeOperationWaitTime(config *Config, res interface{}, project, activity
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("compute"))
 }
 
 func ComputeOrgOperationWaitTimeWithResponse(config *Config, res interface{}, response *map[string]interface{}, parent, activity, userAgent string, timeout time.Duration) error {
@@ -133,7 +144,7 @@ func ComputeOrgOperationWaitTimeWithResponse(config *Config, res interface{}, re
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("compute")); err != nil {
 		return err
 	}
 	e, err := json.Marshal(w.Op)
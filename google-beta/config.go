@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
@@ -58,8 +59,12 @@ import (
 	"google.golang.org/grpc"
 )
 
+// ProviderMeta is the provider_meta block of a module. Its fields are
+// pointers as the attributes a module doesn't set are null.
 type ProviderMeta struct {
-	ModuleName string `cty:"module_name"`
+	ModuleName                         *string  `cty:"module_name"`
+	ImpersonateServiceAccount          *string  `cty:"impersonate_service_account"`
+	ImpersonateServiceAccountDelegates []string `cty:"impersonate_service_account_delegates"`
 }
 
 type Formatter struct {
@@ -161,12 +166,23 @@ type Config struct {
 	Zone                               string
 	Scopes                             []string
 	BatchingConfig                     *batchingConfig
+	RetryPolicy                        *RetryPolicy
+	RateLimits                         []RateLimitConfig
+	AuditLog                           *AuditLogConfig
+	ExternalAccount                    *ExternalAccountConfig
 	UserProjectOverride                bool
 	RequestReason                      string
 	RequestTimeout                     time.Duration
-	// PollInterval is passed to resource.StateChangeConf in common_operation.go
-	// It controls the interval at which we poll for successful operations
-	PollInterval time.Duration
+	DefaultLabels                      map[string]string
+	// Polling controls the intervals at which we poll for successful
+	// operations in common_operation.go and resources in common_polling.go
+	Polling PollingConfig
+	// IamPolicyVerification is how IAM policy changes are confirmed, one of
+	// the IamPolicyVerification* modes in iam.go.
+	IamPolicyVerification string
+	// DisruptiveUpdatesAsErrors fails plans that include disruptive in-place
+	// updates, rather than warning about them.
+	DisruptiveUpdatesAsErrors bool
 
 	Client             *http.Client
 	context            context.Context
@@ -175,6 +191,16 @@ type Config struct {
 
 	tokenSource oauth2.TokenSource
 
+	// rateLimiters holds the token buckets shared by every client created from this Config.
+	rateLimiters *rateLimiters
+
+	// identity is the email of the identity API calls are made as, if it could be determined.
+	identity string
+
+	// impersonatedConfigs caches the copies of this Config acting as other
+	// service accounts, see ImpersonatedConfig.
+	impersonatedConfigs *impersonatedConfigCache
+
 	AccessApprovalBasePath       string
 	AccessContextManagerBasePath string
 	ActiveDirectoryBasePath      string
@@ -293,6 +319,8 @@ type Config struct {
 
 	RequestBatcherServiceUsage *RequestBatcher
 	requestBatcherIam          *RequestBatcher
+	requestBatcherDns          *RequestBatcher
+	requestBatcherCompute      *RequestBatcher
 }
 
 const AccessApprovalBasePathKey = "AccessApproval"
@@ -1171,30 +1199,85 @@ func (c *Config) LoadAndValidate(ctx context.Context) error {
 
 	c.tokenSource = tokenSource
 
-	cleanCtx := context.WithValue(ctx, oauth2.HTTPClient, cleanhttp.DefaultClient())
-
-	// 1. MTLS TRANSPORT/CLIENT - sets up proper auth headers
-	client, _, err := transport.NewHTTPClient(cleanCtx, option.WithTokenSource(tokenSource))
+	// Userinfo is fetched before request logging is enabled to reduce additional noise.
+	err = c.logGoogleIdentities()
 	if err != nil {
 		return err
 	}
 
-	// Userinfo is fetched before request logging is enabled to reduce additional noise.
-	err = c.logGoogleIdentities()
+	c.rateLimiters = newRateLimiters(c.RateLimits)
+	c.impersonatedConfigs = &impersonatedConfigCache{configs: make(map[string]*Config)}
+	client, err := c.newHTTPClient(ctx, tokenSource, c.identity)
 	if err != nil {
 		return err
 	}
 
+	c.Client = client
+	c.context = ctx
+	c.Region = GetRegionFromRegionSelfLink(c.Region)
+	c.RequestBatcherServiceUsage = NewRequestBatcher("Service Usage", ctx, c.BatchingConfig)
+	c.requestBatcherIam = NewRequestBatcher("IAM", ctx, c.BatchingConfig)
+	c.requestBatcherDns = NewRequestBatcher("DNS", ctx, c.BatchingConfig)
+	c.requestBatcherCompute = NewRequestBatcher("Compute", ctx, c.BatchingConfig)
+	if c.Polling.Default == (PollingStrategy{}) {
+		c.Polling.Default = DefaultPollingStrategy()
+	}
+
+	// gRPC Logging setup
+	logger := logrus.StandardLogger()
+
+	logrus.SetLevel(logrus.DebugLevel)
+	logrus.SetFormatter(&Formatter{
+		TimestampFormat: "2006/01/02 15:04:05",
+		LogFormat:       "%time% [%lvl%] %msg% \n",
+	})
+
+	alwaysLoggingDeciderClient := func(ctx context.Context, fullMethodName string) bool { return true }
+	grpc_logrus.ReplaceGrpcLogger(logrus.NewEntry(logger))
+
+	c.gRPCLoggingOptions = append(
+		c.gRPCLoggingOptions, option.WithGRPCDialOption(grpc.WithUnaryInterceptor(
+			grpc_logrus.PayloadUnaryClientInterceptor(logrus.NewEntry(logger), alwaysLoggingDeciderClient))),
+		option.WithGRPCDialOption(grpc.WithStreamInterceptor(
+			grpc_logrus.PayloadStreamClientInterceptor(logrus.NewEntry(logger), alwaysLoggingDeciderClient))),
+	)
+
+	return nil
+}
+
+// newHTTPClient returns a client authenticating with the given token source,
+// wrapped in the provider's transport chain. identity is recorded in the audit
+// log.
+func (c *Config) newHTTPClient(ctx context.Context, tokenSource oauth2.TokenSource, identity string) (*http.Client, error) {
+	cleanCtx := context.WithValue(ctx, oauth2.HTTPClient, cleanhttp.DefaultClient())
+
+	// 1. MTLS TRANSPORT/CLIENT - sets up proper auth headers
+	client, _, err := transport.NewHTTPClient(cleanCtx, option.WithTokenSource(tokenSource))
+	if err != nil {
+		return nil, err
+	}
+
 	// 2. Logging Transport - ensure we log HTTP requests to GCP APIs.
 	loggingTransport := logging.NewTransport("Google", client.Transport)
 
-	// 3. Retry Transport - retries common temporary errors
+	// 3. Audit Log Transport - optionally writes an audit record for each request
+	// Keep order for wrapping retries so each retried request is audited as well.
+	auditLogTransport, err := newTransportWithAuditLog(loggingTransport, c.AuditLog, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Rate Limit Transport - optionally throttles requests per API host
+	// Keep order for wrapping retries so each retried request waits for a token as well.
+	rateLimitTransport := newTransportWithRateLimits(auditLogTransport, c.rateLimiters)
+
+	// 5. Retry Transport - retries common temporary errors
 	// Keep order for wrapping logging so we log each retried request as well.
 	// This value should be used if needed to create shallow copies with additional retry predicates.
 	// See ClientWithAdditionalRetries
-	retryTransport := NewTransportWithDefaultRetries(loggingTransport)
+	retryTransport := NewTransportWithDefaultRetries(rateLimitTransport).WithPolicy(c.RetryPolicy)
 
-	// 4. Header Transport - outer wrapper to inject additional headers we want to apply
+	// 6. Header Transport - outer wrapper to inject additional headers we want to apply
 	// before making requests
 	headerTransport := newTransportWithHeaders(retryTransport)
 	if c.RequestReason != "" {
@@ -1213,33 +1296,58 @@ func (c *Config) LoadAndValidate(ctx context.Context) error {
 	// This timeout is a timeout per HTTP request, not per logical operation.
 	client.Timeout = c.synchronousTimeout()
 
-	c.Client = client
-	c.context = ctx
-	c.Region = GetRegionFromRegionSelfLink(c.Region)
-	c.RequestBatcherServiceUsage = NewRequestBatcher("Service Usage", ctx, c.BatchingConfig)
-	c.requestBatcherIam = NewRequestBatcher("IAM", ctx, c.BatchingConfig)
-	c.PollInterval = 10 * time.Second
-
-	// gRPC Logging setup
-	logger := logrus.StandardLogger()
+	return client, nil
+}
 
-	logrus.SetLevel(logrus.DebugLevel)
-	logrus.SetFormatter(&Formatter{
-		TimestampFormat: "2006/01/02 15:04:05",
-		LogFormat:       "%time% [%lvl%] %msg% \n",
-	})
+type impersonatedConfigCache struct {
+	mu      sync.Mutex
+	configs map[string]*Config
+}
 
-	alwaysLoggingDeciderClient := func(ctx context.Context, fullMethodName string) bool { return true }
-	grpc_logrus.ReplaceGrpcLogger(logrus.NewEntry(logger))
+// ImpersonatedConfig returns a copy of the Config sending requests as the
+// given service account, impersonated by the identity the provider itself
+// authenticates as. Copies are cached per service account and delegation
+// chain, so their tokens are reused across resources.
+func (c *Config) ImpersonatedConfig(serviceAccount string, delegates []string) (*Config, error) {
+	if serviceAccount == "" || (serviceAccount == c.ImpersonateServiceAccount && len(delegates) == 0) {
+		return c, nil
+	}
+	if c.impersonatedConfigs == nil {
+		return nil, fmt.Errorf("cannot impersonate %s before the provider is configured", serviceAccount)
+	}
 
-	c.gRPCLoggingOptions = append(
-		c.gRPCLoggingOptions, option.WithGRPCDialOption(grpc.WithUnaryInterceptor(
-			grpc_logrus.PayloadUnaryClientInterceptor(logrus.NewEntry(logger), alwaysLoggingDeciderClient))),
-		option.WithGRPCDialOption(grpc.WithStreamInterceptor(
-			grpc_logrus.PayloadStreamClientInterceptor(logrus.NewEntry(logger), alwaysLoggingDeciderClient))),
-	)
+	key := strings.Join(append([]string{serviceAccount}, delegates...), ",")
+	c.impersonatedConfigs.mu.Lock()
+	defer c.impersonatedConfigs.mu.Unlock()
+	if cfg, ok := c.impersonatedConfigs.configs[key]; ok {
+		return cfg, nil
+	}
 
-	return nil
+	creds, err := transport.Creds(context.TODO(), option.WithTokenSource(c.tokenSource), option.ImpersonateCredentials(serviceAccount, delegates...), option.WithScopes(c.Scopes...))
+	if err != nil {
+		return nil, fmt.Errorf("error impersonating %s: %s", serviceAccount, err)
+	}
+	client, err := c.newHTTPClient(c.context, creds.TokenSource, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	impersonated := *c
+	impersonated.ImpersonateServiceAccount = serviceAccount
+	impersonated.ImpersonateServiceAccountDelegates = delegates
+	impersonated.tokenSource = creds.TokenSource
+	impersonated.identity = serviceAccount
+	impersonated.Client = client
+	// Requests batched together are sent as a single identity, so each
+	// identity needs its own batchers.
+	impersonated.RequestBatcherServiceUsage = NewRequestBatcher("Service Usage", c.context, c.BatchingConfig)
+	impersonated.requestBatcherIam = NewRequestBatcher("IAM", c.context, c.BatchingConfig)
+	impersonated.requestBatcherDns = NewRequestBatcher("DNS", c.context, c.BatchingConfig)
+	impersonated.requestBatcherCompute = NewRequestBatcher("Compute", c.context, c.BatchingConfig)
+
+	log.Printf("[INFO] Terraform is configured with module level service account impersonation, impersonated identity: %s", serviceAccount)
+	c.impersonatedConfigs.configs[key] = &impersonated
+	return &impersonated, nil
 }
 
 func ExpandProviderBatchingConfig(v interface{}) (*batchingConfig, error) {
@@ -1269,9 +1377,182 @@ func ExpandProviderBatchingConfig(v interface{}) (*batchingConfig, error) {
 		config.EnableBatching = enable.(bool)
 	}
 
+	if maxBatchSize, ok := cfgV["max_batch_size"]; ok {
+		config.MaxBatchSize = maxBatchSize.(int)
+	}
+
+	if maxConcurrent, ok := cfgV["max_concurrent_per_key"]; ok {
+		config.MaxConcurrentPerKey = maxConcurrent.(int)
+	}
+
 	return config, nil
 }
 
+func ExpandProviderRetryPolicy(v interface{}) (*RetryPolicy, error) {
+	policy := DefaultRetryPolicy()
+
+	if v == nil {
+		return policy, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return policy, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	if maxAttempts, ok := cfgV["max_attempts"]; ok {
+		policy.MaxAttempts = maxAttempts.(int)
+	}
+
+	for key, dur := range map[string]*time.Duration{
+		"initial_backoff": &policy.InitialBackoff,
+		"max_backoff":     &policy.MaxBackoff,
+		"timeout":         &policy.Timeout,
+	} {
+		if durV, ok := cfgV[key]; ok && durV != "" {
+			parsed, err := time.ParseDuration(durV.(string))
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse duration from '%s' value %q", key, durV)
+			}
+			*dur = parsed
+		}
+	}
+
+	if strategy, ok := cfgV["backoff_strategy"]; ok && strategy != "" {
+		policy.Strategy = strategy.(string)
+	}
+
+	if jitter, ok := cfgV["jitter"]; ok {
+		policy.Jitter = jitter.(bool)
+	}
+
+	return policy, nil
+}
+
+func ExpandProviderRateLimits(v interface{}) ([]RateLimitConfig, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	var rateLimits []RateLimitConfig
+	for _, raw := range v.([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		cfgV := raw.(map[string]interface{})
+		rl := RateLimitConfig{
+			Host:              cfgV["host"].(string),
+			RequestsPerSecond: cfgV["requests_per_second"].(float64),
+		}
+		if burst, ok := cfgV["burst"]; ok {
+			rl.Burst = burst.(int)
+		}
+		if rl.Host == "" {
+			return nil, fmt.Errorf("'host' must be set in each 'rate_limit' block")
+		}
+		rateLimits = append(rateLimits, rl)
+	}
+
+	return rateLimits, nil
+}
+
+func ExpandProviderPolling(v interface{}) (PollingConfig, error) {
+	var settings []PollingSettings
+	if v != nil {
+		for _, raw := range v.([]interface{}) {
+			if raw == nil {
+				continue
+			}
+			cfgV := raw.(map[string]interface{})
+			ps := PollingSettings{}
+			if service, ok := cfgV["service"]; ok {
+				ps.Service = service.(string)
+			}
+			for key, dur := range map[string]*time.Duration{
+				"initial_interval": &ps.InitialInterval,
+				"max_interval":     &ps.MaxInterval,
+			} {
+				if durV, ok := cfgV[key]; ok && durV != "" {
+					parsed, err := time.ParseDuration(durV.(string))
+					if err != nil {
+						return PollingConfig{}, fmt.Errorf("unable to parse duration from '%s' value %q", key, durV)
+					}
+					*dur = parsed
+				}
+			}
+			if multiplier, ok := cfgV["multiplier"]; ok {
+				ps.Multiplier = multiplier.(float64)
+			}
+			settings = append(settings, ps)
+		}
+	}
+
+	return NewPollingConfig(settings)
+}
+
+func ExpandProviderExternalAccount(v interface{}) (*ExternalAccountConfig, error) {
+	if v == nil {
+		return nil, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return nil, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	cfg := &ExternalAccountConfig{}
+	for key, dest := range map[string]*string{
+		"audience":             &cfg.Audience,
+		"subject_token_type":   &cfg.SubjectTokenType,
+		"token_url":            &cfg.TokenURL,
+		"subject_token_file":   &cfg.SubjectTokenFile,
+		"subject_token_url":    &cfg.SubjectTokenURL,
+		"subject_token_format": &cfg.SubjectTokenFormat,
+		"subject_token_field":  &cfg.SubjectTokenField,
+	} {
+		if val, ok := cfgV[key]; ok {
+			*dest = val.(string)
+		}
+	}
+	if headers, ok := cfgV["subject_token_headers"]; ok && len(headers.(map[string]interface{})) > 0 {
+		cfg.SubjectTokenURLHeaders = make(map[string]string)
+		for k, v := range headers.(map[string]interface{}) {
+			cfg.SubjectTokenURLHeaders[k] = v.(string)
+		}
+	}
+	if command, ok := cfgV["subject_token_command"]; ok && len(command.([]interface{})) > 0 {
+		cfg.SubjectTokenCommand = convertStringArr(command.([]interface{}))
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func ExpandProviderAuditLog(v interface{}) (*AuditLogConfig, error) {
+	if v == nil {
+		return nil, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		return nil, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	cfg := &AuditLogConfig{
+		Path: cfgV["path"].(string),
+	}
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("'path' must be set in the 'audit_log' block")
+	}
+	if fields, ok := cfgV["redact_fields"]; ok {
+		cfg.RedactFields = convertStringArr(fields.([]interface{}))
+	}
+
+	return cfg, nil
+}
+
 func (c *Config) synchronousTimeout() time.Duration {
 	if c.RequestTimeout == 0 {
 		return 120 * time.Second
@@ -1281,6 +1562,28 @@ func (c *Config) synchronousTimeout() time.Duration {
 
 // Print Identities executing terraform API Calls.
 func (c *Config) logGoogleIdentities() error {
+	if c.ExternalAccount != nil {
+		// Federated tokens can't be used to fetch userinfo, so the federated
+		// principal is inferred from the subject token instead.
+		federated := c.ExternalAccount.identity(c.context)
+
+		tokenSource, err := c.getTokenSource(c.Scopes, false)
+		if err != nil {
+			return err
+		}
+		c.Client = oauth2.NewClient(c.context, tokenSource) // c.Client isn't initialised fully when this code is called.
+
+		if c.ImpersonateServiceAccount == "" {
+			log.Printf("[INFO] Terraform is using workload identity federation, federated identity: %s", federated)
+			c.identity = federated
+			return nil
+		}
+
+		log.Printf("[INFO] Terraform is configured with workload identity federation and service account impersonation, federated identity: %s, impersonated identity: %s", federated, c.ImpersonateServiceAccount)
+		c.identity = c.ImpersonateServiceAccount
+		return nil
+	}
+
 	if c.ImpersonateServiceAccount == "" {
 
 		tokenSource, err := c.getTokenSource(c.Scopes, true)
@@ -1295,6 +1598,7 @@ func (c *Config) logGoogleIdentities() error {
 		}
 
 		log.Printf("[INFO] Terraform is using this identity: %s", email)
+		c.identity = email
 
 		return nil
 
@@ -1314,6 +1618,7 @@ func (c *Config) logGoogleIdentities() error {
 	}
 
 	log.Printf("[INFO] Terraform is configured with service account impersonation, original identity: %s, impersonated identity: %s", email, c.ImpersonateServiceAccount)
+	c.identity = c.ImpersonateServiceAccount
 
 	// Add the Impersonated ClientOption back in to the OAuth2 TokenSource
 
@@ -1862,6 +2167,24 @@ type StaticTokenSource struct {
 // If initialCredentialsOnly is true, don't follow the impersonation settings and return the initial set of creds
 // instead.
 func (c *Config) GetCredentials(clientScopes []string, initialCredentialsOnly bool) (googleoauth.Credentials, error) {
+	if c.ExternalAccount != nil {
+		tokenSource := NewExternalAccountTokenSource(c.ExternalAccount, clientScopes)
+		if c.ImpersonateServiceAccount != "" && !initialCredentialsOnly {
+			opts := []option.ClientOption{option.WithTokenSource(tokenSource), option.ImpersonateCredentials(c.ImpersonateServiceAccount, c.ImpersonateServiceAccountDelegates...), option.WithScopes(clientScopes...)}
+			creds, err := transport.Creds(context.TODO(), opts...)
+			if err != nil {
+				return googleoauth.Credentials{}, err
+			}
+			return *creds, nil
+		}
+
+		log.Printf("[INFO] Authenticating using workload identity federation with a subject token from %s...", c.ExternalAccount.subjectTokenSource())
+		log.Printf("[INFO]   -- Scopes: %s", clientScopes)
+		return googleoauth.Credentials{
+			TokenSource: tokenSource,
+		}, nil
+	}
+
 	if c.AccessToken != "" {
 		contents, _, err := pathOrContents(c.AccessToken)
 		if err != nil {
@@ -1934,7 +2257,7 @@ func (c *Config) GetCredentials(clientScopes []string, initialCredentialsOnly bo
 
 // Remove the `/{{version}}/` from a base path if present.
 func RemoveBasePathVersion(url string) string {
-	re := regexp.MustCompile(`(?P<base>http[s]://.*)(?P<version>/[^/]+?/$)`)
+	re := regexp.MustCompile(`(?P<base>https?://.*)(?P<version>/[^/]+?/$)`)
 	return re.ReplaceAllString(url, "$1/")
 }
 
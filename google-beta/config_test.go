@@ -4,10 +4,12 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
@@ -865,6 +867,186 @@ func TestConfigLoadAndValidate_defaultBatchingConfig(t *testing.T) {
 	}
 }
 
+func TestExpandProviderRetryPolicy(t *testing.T) {
+	policy, err := ExpandProviderRetryPolicy(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(policy, DefaultRetryPolicy()) {
+		t.Fatalf("expected default retry policy, got %#v", policy)
+	}
+
+	policy, err = ExpandProviderRetryPolicy([]interface{}{
+		map[string]interface{}{
+			"max_attempts":     5,
+			"initial_backoff":  "1s",
+			"max_backoff":      "30s",
+			"backoff_strategy": RetryBackoffStrategyExponential,
+			"jitter":           true,
+			"timeout":          "",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second * 30,
+		Strategy:       RetryBackoffStrategyExponential,
+		Jitter:         true,
+		Timeout:        defaultRetryTransportTimeoutSec * time.Second,
+	}
+	if !reflect.DeepEqual(policy, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, policy)
+	}
+
+	if _, err := ExpandProviderRetryPolicy([]interface{}{
+		map[string]interface{}{
+			"initial_backoff": "soon",
+		},
+	}); err == nil {
+		t.Fatalf("expected error parsing invalid duration")
+	}
+}
+
+func TestExpandProviderRateLimits(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"host":                "compute.googleapis.com",
+			"requests_per_second": 10.0,
+			"burst":               20,
+		},
+		map[string]interface{}{
+			"host":                "https://www.googleapis.com/storage/v1/",
+			"requests_per_second": 0.5,
+			"burst":               0,
+		},
+	}
+
+	expected := []RateLimitConfig{
+		{Host: "compute.googleapis.com", RequestsPerSecond: 10, Burst: 20},
+		{Host: "https://www.googleapis.com/storage/v1/", RequestsPerSecond: 0.5},
+	}
+
+	actual, err := ExpandProviderRateLimits(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	if _, err := ExpandProviderRateLimits([]interface{}{map[string]interface{}{"host": "", "requests_per_second": 1.0}}); err == nil {
+		t.Fatalf("expected error for empty host")
+	}
+}
+
+func TestExpandProviderPolling(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"service":          "",
+			"initial_interval": "2s",
+			"max_interval":     "",
+			"multiplier":       0.0,
+		},
+		map[string]interface{}{
+			"service":          "container",
+			"initial_interval": "10s",
+			"max_interval":     "1m",
+			"multiplier":       2.0,
+		},
+		map[string]interface{}{
+			"service":          "sql",
+			"initial_interval": "",
+			"max_interval":     "",
+			"multiplier":       1.0,
+		},
+	}
+
+	expected := PollingConfig{
+		Default: PollingStrategy{InitialInterval: 2 * time.Second, MaxInterval: 30 * time.Second, Multiplier: 1.5},
+		Overrides: map[string]PollingStrategy{
+			"container": {InitialInterval: 10 * time.Second, MaxInterval: 1 * time.Minute, Multiplier: 2},
+			"sql":       {InitialInterval: 2 * time.Second, MaxInterval: 30 * time.Second, Multiplier: 1},
+		},
+	}
+
+	actual, err := ExpandProviderPolling(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	actual, err = ExpandProviderPolling([]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(actual, PollingConfig{Default: DefaultPollingStrategy()}) {
+		t.Fatalf("expected the default polling strategy, got %v", actual)
+	}
+}
+
+func TestExpandProviderExternalAccount(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"audience":              testExternalAccountAudience,
+			"subject_token_type":    "",
+			"token_url":             "",
+			"subject_token_file":    "",
+			"subject_token_url":     "http://localhost/token",
+			"subject_token_headers": map[string]interface{}{"Authorization": "Bearer abc"},
+			"subject_token_command": []interface{}{},
+			"subject_token_format":  "json",
+			"subject_token_field":   "value",
+		},
+	}
+
+	expected := &ExternalAccountConfig{
+		Audience:               testExternalAccountAudience,
+		SubjectTokenURL:        "http://localhost/token",
+		SubjectTokenURLHeaders: map[string]string{"Authorization": "Bearer abc"},
+		SubjectTokenFormat:     "json",
+		SubjectTokenField:      "value",
+	}
+	actual, err := ExpandProviderExternalAccount(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, actual)
+	}
+
+	if _, err := ExpandProviderExternalAccount([]interface{}{map[string]interface{}{"audience": testExternalAccountAudience}}); err == nil {
+		t.Fatalf("expected error without a subject token source")
+	}
+}
+
+func TestExpandProviderAuditLog(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"path":          "/tmp/audit.jsonl",
+			"redact_fields": []interface{}{"token"},
+		},
+	}
+
+	expected := &AuditLogConfig{Path: "/tmp/audit.jsonl", RedactFields: []string{"token"}}
+	actual, err := ExpandProviderAuditLog(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	if actual, err := ExpandProviderAuditLog([]interface{}{}); err != nil || actual != nil {
+		t.Fatalf("expected no audit log config, got %v, %v", actual, err)
+	}
+}
+
 func TestConfigLoadAndValidate_customBatchingConfig(t *testing.T) {
 	batchCfg, err := ExpandProviderBatchingConfig([]interface{}{
 		map[string]interface{}{
@@ -917,6 +1099,8 @@ func TestRemoveBasePathVersion(t *testing.T) {
 		{"https://staging-version.googleapis.com/", "https://staging-version.googleapis.com/"},
 		// For URLs with any parts, the last part is always removed- it's assumed to be the version.
 		{"https://runtimeconfig.googleapis.com/runtimeconfig/", "https://runtimeconfig.googleapis.com/"},
+		// Local endpoints, such as emulators, may be served over plain HTTP.
+		{"http://127.0.0.1:8080/pubsub/v1/", "http://127.0.0.1:8080/pubsub/"},
 	}
 
 	for _, c := range cases {
@@ -925,3 +1109,43 @@ func TestRemoveBasePathVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigImpersonatedConfig(t *testing.T) {
+	config := &Config{
+		Scopes:              DefaultClientScopes,
+		BatchingConfig:      &batchingConfig{SendAfter: time.Second},
+		context:             context.Background(),
+		tokenSource:         oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "provider-token"}),
+		identity:            "provider@example.com",
+		impersonatedConfigs: &impersonatedConfigCache{configs: make(map[string]*Config)},
+	}
+
+	same, err := config.ImpersonatedConfig("", nil)
+	if err != nil || same != config {
+		t.Fatalf("expected the provider Config without a service account, got %v, %v", same, err)
+	}
+
+	impersonated, err := config.ImpersonatedConfig("tenant@my-project.iam.gserviceaccount.com", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if impersonated == config || impersonated.Client == nil || impersonated.identity != "tenant@my-project.iam.gserviceaccount.com" {
+		t.Fatalf("expected a Config impersonating the service account, got %+v", impersonated)
+	}
+	if impersonated.requestBatcherIam == config.requestBatcherIam {
+		t.Errorf("expected the impersonated Config to have its own batchers")
+	}
+	if config.identity != "provider@example.com" {
+		t.Errorf("expected the provider Config to be unchanged, got identity %q", config.identity)
+	}
+
+	cached, err := config.ImpersonatedConfig("tenant@my-project.iam.gserviceaccount.com", nil)
+	if err != nil || cached != impersonated {
+		t.Errorf("expected the impersonated Config to be cached, got %v, %v", cached, err)
+	}
+
+	delegated, err := config.ImpersonatedConfig("tenant@my-project.iam.gserviceaccount.com", []string{"delegate@my-project.iam.gserviceaccount.com"})
+	if err != nil || delegated == impersonated {
+		t.Errorf("expected a separate Config for a different delegation chain, got %v, %v", delegated, err)
+	}
+}
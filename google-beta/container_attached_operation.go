@@ -44,7 +44,7 @@ func ContainerAttachedOperationWaitTimeWithResponse(config *Config, op map[strin
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("container_attached")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -60,5 +60,5 @@ func ContainerAttachedOperationWaitTime(config *Config, op map[string]interface{
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("container_attached"))
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	container "google.golang.org/api/container/v1beta1"
@@ -94,6 +96,71 @@ func (w *ContainerOperationWaiter) OpName() string {
 	return w.Op.Name
 }
 
+func (w *ContainerOperationWaiter) Progress() string {
+	if w == nil || w.Op == nil || w.Op.Progress == nil {
+		return ""
+	}
+
+	return flattenContainerOperationProgress(w.Op.Progress)
+}
+
+// flattenContainerOperationProgress describes the progress of a container
+// operation, e.g. "Creating node pool [nodes_done=3/5]", including the
+// stages it's made of.
+func flattenContainerOperationProgress(p *container.OperationProgress) string {
+	var parts []string
+	if p.Name != "" {
+		parts = append(parts, p.Name)
+	}
+	if p.Status != "" {
+		parts = append(parts, p.Status)
+	}
+
+	metrics := map[string]string{}
+	var names []string
+	for _, m := range p.Metrics {
+		var v string
+		switch {
+		case m.StringValue != "":
+			v = m.StringValue
+		case m.DoubleValue != 0:
+			v = strconv.FormatFloat(m.DoubleValue, 'f', -1, 64)
+		default:
+			v = strconv.FormatInt(m.IntValue, 10)
+		}
+		metrics[m.Name] = v
+		names = append(names, m.Name)
+	}
+	// GKE reports pairs of metrics such as NODES_DONE and NODES_TOTAL.
+	var values []string
+	for _, name := range names {
+		if strings.HasSuffix(name, "_TOTAL") {
+			continue
+		}
+		v := metrics[name]
+		if strings.HasSuffix(name, "_DONE") {
+			if total, ok := metrics[strings.TrimSuffix(name, "_DONE")+"_TOTAL"]; ok {
+				v = v + "/" + total
+			}
+		}
+		values = append(values, fmt.Sprintf("%s=%s", strings.ToLower(name), v))
+	}
+	if len(values) > 0 {
+		parts = append(parts, "["+strings.Join(values, ", ")+"]")
+	}
+
+	for _, stage := range p.Stages {
+		if stage == nil || stage.Status == "DONE" || stage.Status == "PENDING" {
+			continue
+		}
+		if s := flattenContainerOperationProgress(stage); s != "" {
+			parts = append(parts, "> "+s)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
 func (w *ContainerOperationWaiter) PendingStates() []string {
 	return []string{"PENDING", "RUNNING"}
 }
@@ -116,5 +183,5 @@ func ContainerOperationWait(config *Config, op *container.Operation, project, lo
 		return err
 	}
 
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("container"))
 }
@@ -46,5 +46,5 @@ func dataSourceGoogleCloudFunctionsFunctionRead(d *schema.ResourceData, meta int
 		return err
 	}
 
-	return nil
+	return SetDataSourceLabels(d)
 }
@@ -35,5 +35,9 @@ func dataSourceGoogleComposerEnvironmentRead(d *schema.ResourceData, meta interf
 
 	d.SetId(fmt.Sprintf("projects/%s/locations/%s/environments/%s", project, region, envName))
 
-	return resourceComposerEnvironmentRead(d, meta)
+	if err := resourceComposerEnvironmentRead(d, meta); err != nil {
+		return err
+	}
+
+	return SetDataSourceLabels(d)
 }
@@ -89,7 +89,11 @@ func dataSourceGoogleComputeInstanceRead(d *schema.ResourceData, meta interface{
 		}
 	}
 
-	if err := d.Set("labels", instance.Labels); err != nil {
+	if err := d.Set("effective_labels", instance.Labels); err != nil {
+		return err
+	}
+
+	if err := SetDataSourceLabels(d); err != nil {
 		return err
 	}
 
@@ -0,0 +1,209 @@
+package google
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+var iamPolicyDriftBindingSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"role": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"members": {
+			Type:     schema.TypeSet,
+			Required: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		},
+		"condition": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"expression": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"title": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+	},
+}
+
+// DataSourceGoogleIamPolicyDrift returns a *schema.Resource comparing the live
+// IAM policy of a resource with the bindings declared for it. The policy is
+// read through the `<resource_type>_iam_policy` resource in resources, so any
+// resource type with an IAM updater is supported. For example:
+//
+//	data "google_iam_policy_drift" "project" {
+//	  resource_type = "google_project"
+//	  parent = {
+//	    project = "my-project"
+//	  }
+//
+//	  binding {
+//	    role    = "roles/viewer"
+//	    members = ["group:viewers@example.com"]
+//	  }
+//	}
+func DataSourceGoogleIamPolicyDrift(resources map[string]*schema.Resource) *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleIamPolicyDriftRead(resources),
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: `The resource type whose IAM policy is checked, such as "google_project" or "google_storage_bucket".`,
+			},
+			"parent": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: `The fields identifying the resource, as set on its "_iam_policy" resource.`,
+			},
+			"binding": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     iamPolicyDriftBindingSchema,
+			},
+			"policy_data": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateIamPolicy,
+				Description:  `Additional declared bindings, as a google_iam_policy data source's policy_data.`,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"unmanaged_binding": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     iamPolicyDriftBindingSchema,
+			},
+			"missing_binding": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     iamPolicyDriftBindingSchema,
+			},
+		},
+	}
+}
+
+func dataSourceGoogleIamPolicyDriftRead(resources map[string]*schema.Resource) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		resourceType := d.Get("resource_type").(string)
+		policyResource, ok := resources[resourceType+"_iam_policy"]
+		if !ok || policyResource.Read == nil {
+			return fmt.Errorf("resource type %q doesn't support IAM policies", resourceType)
+		}
+
+		// Read the live policy as the _iam_policy resource would, which
+		// builds the IAM updater for the resource from the parent fields.
+		policyData := policyResource.Data(nil)
+		for k, v := range d.Get("parent").(map[string]interface{}) {
+			if _, ok := IamPolicyBaseSchema[k]; ok {
+				return fmt.Errorf("%q is not a parent field of %s", k, resourceType)
+			}
+			if _, ok := policyResource.Schema[k]; !ok {
+				return fmt.Errorf("%q is not a parent field of %s, expected one of: %s", k, resourceType, strings.Join(iamPolicyParentFields(policyResource), ", "))
+			}
+			if err := policyData.Set(k, v); err != nil {
+				return fmt.Errorf("Error setting parent field %q: %s", k, err)
+			}
+		}
+		if err := policyResource.Read(policyData, meta); err != nil {
+			return err
+		}
+		if policyData.Get("policy_data").(string) == "" {
+			return fmt.Errorf("%s with parent %v not found", resourceType, d.Get("parent"))
+		}
+
+		live, err := unmarshalIamPolicy(policyData.Get("policy_data").(string))
+		if err != nil {
+			return err
+		}
+
+		declared := expandIamPolicyDriftBindings(d.Get("binding").(*schema.Set).List())
+		if v, ok := d.GetOk("policy_data"); ok {
+			policy, err := unmarshalIamPolicy(v.(string))
+			if err != nil {
+				return fmt.Errorf("'policy_data' is not valid: %s", err)
+			}
+			declared = append(declared, policy.Bindings...)
+		}
+
+		unmanaged, missing := iamPolicyDrift(live.Bindings, declared)
+		if err := d.Set("etag", policyData.Get("etag")); err != nil {
+			return fmt.Errorf("Error setting etag: %s", err)
+		}
+		if err := d.Set("unmanaged_binding", flattenIamPolicyDriftBindings(unmanaged)); err != nil {
+			return fmt.Errorf("Error setting unmanaged_binding: %s", err)
+		}
+		if err := d.Set("missing_binding", flattenIamPolicyDriftBindings(missing)); err != nil {
+			return fmt.Errorf("Error setting missing_binding: %s", err)
+		}
+
+		d.SetId(fmt.Sprintf("%s/%d", resourceType, hashcode(fmt.Sprintf("%v", d.Get("parent")))))
+		return nil
+	}
+}
+
+// Returns the bindings of the live policy no declared binding accounts for,
+// and the declared bindings missing from the live policy. Bindings are
+// compared per role, condition and member.
+func iamPolicyDrift(live, declared []*cloudresourcemanager.Binding) (unmanaged, missing []*cloudresourcemanager.Binding) {
+	return subtractFromBindings(live, declared...), subtractFromBindings(declared, live...)
+}
+
+// Lists the fields identifying the resource of an _iam_policy resource.
+func iamPolicyParentFields(policyResource *schema.Resource) []string {
+	var fields []string
+	for k := range policyResource.Schema {
+		if _, ok := IamPolicyBaseSchema[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func expandIamPolicyDriftBindings(l []interface{}) []*cloudresourcemanager.Binding {
+	bindings := make([]*cloudresourcemanager.Binding, 0, len(l))
+	for _, v := range l {
+		binding := v.(map[string]interface{})
+		bindings = append(bindings, &cloudresourcemanager.Binding{
+			Role:      binding["role"].(string),
+			Members:   convertStringSet(binding["members"].(*schema.Set)),
+			Condition: expandIamCondition(binding["condition"]),
+		})
+	}
+	return bindings
+}
+
+func flattenIamPolicyDriftBindings(bindings []*cloudresourcemanager.Binding) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0, len(bindings))
+	for _, b := range bindings {
+		l = append(l, map[string]interface{}{
+			"role":      b.Role,
+			"members":   b.Members,
+			"condition": flattenIamCondition(b.Condition),
+		})
+	}
+	return l
+}
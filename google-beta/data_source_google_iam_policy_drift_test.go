@@ -0,0 +1,98 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestIamPolicyDrift(t *testing.T) {
+	condition := &cloudresourcemanager.Expr{
+		Title:      "expires",
+		Expression: `request.time < timestamp("2030-01-01T00:00:00Z")`,
+	}
+	live := []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:a@example.com", "user:b@example.com"}},
+		{Role: "roles/viewer", Members: []string{"user:c@example.com"}, Condition: condition},
+	}
+	declared := []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:A@example.com", "user:c@example.com"}},
+		{Role: "roles/editor", Members: []string{"user:a@example.com"}},
+	}
+
+	unmanaged, missing := iamPolicyDrift(live, declared)
+
+	expectedUnmanaged := []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:b@example.com"}},
+		{Role: "roles/viewer", Members: []string{"user:c@example.com"}, Condition: condition},
+	}
+	if !compareBindings(unmanaged, expectedUnmanaged) {
+		t.Errorf("expected unmanaged bindings %s, got %s", debugPrintBindings(expectedUnmanaged), debugPrintBindings(unmanaged))
+	}
+	expectedMissing := []*cloudresourcemanager.Binding{
+		{Role: "roles/editor", Members: []string{"user:a@example.com"}},
+		{Role: "roles/viewer", Members: []string{"user:c@example.com"}},
+	}
+	if !compareBindings(missing, expectedMissing) {
+		t.Errorf("expected missing bindings %s, got %s", debugPrintBindings(expectedMissing), debugPrintBindings(missing))
+	}
+}
+
+func TestDataSourceGoogleIamPolicyDrift_read(t *testing.T) {
+	updater := &fakeIamUpdater{policy: &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			{Role: "roles/viewer", Members: []string{"user:a@example.com", "user:b@example.com"}},
+		},
+	}}
+	var gotParent string
+	resources := map[string]*schema.Resource{
+		"google_fake_iam_policy": ResourceIamPolicy(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		}, func(d TerraformResourceData, config *Config) (ResourceIamUpdater, error) {
+			gotParent = d.Get("name").(string)
+			return updater, nil
+		}, nil),
+	}
+	drift := DataSourceGoogleIamPolicyDrift(resources)
+
+	d := drift.TestResourceData()
+	d.Set("resource_type", "google_fake")
+	d.Set("parent", map[string]interface{}{"name": "my-fake"})
+	d.Set("binding", []interface{}{
+		map[string]interface{}{
+			"role":    "roles/viewer",
+			"members": schema.NewSet(schema.HashString, []interface{}{"user:a@example.com"}),
+		},
+	})
+	if err := drift.Read(d, &Config{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if gotParent != "my-fake" {
+		t.Errorf("expected the updater to be built for %q, got %q", "my-fake", gotParent)
+	}
+	unmanaged := d.Get("unmanaged_binding").([]interface{})
+	if len(unmanaged) != 1 {
+		t.Fatalf("expected one unmanaged binding, got %v", unmanaged)
+	}
+	members := unmanaged[0].(map[string]interface{})["members"].(*schema.Set)
+	if members.Len() != 1 || !members.Contains("user:b@example.com") {
+		t.Errorf("expected user:b@example.com to be unmanaged, got %v", members.List())
+	}
+	if missing := d.Get("missing_binding").([]interface{}); len(missing) != 0 {
+		t.Errorf("expected no missing bindings, got %v", missing)
+	}
+
+	d.Set("parent", map[string]interface{}{"project": "my-project"})
+	if err := drift.Read(d, &Config{}); err == nil {
+		t.Errorf("expected an error for an unknown parent field")
+	}
+	d.Set("resource_type", "google_unknown")
+	if err := drift.Read(d, &Config{}); err == nil {
+		t.Errorf("expected an error for a resource type without IAM policies")
+	}
+}
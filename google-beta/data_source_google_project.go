@@ -43,5 +43,5 @@ func datasourceGoogleProjectRead(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("%s not found or not in ACTIVE state", id)
 	}
 
-	return nil
+	return SetDataSourceLabels(d)
 }
@@ -34,5 +34,9 @@ func dataSourceGoogleStorageBucketRead(d *schema.ResourceData, meta interface{})
 	}
 	log.Printf("[DEBUG] Read bucket %v at location %v\n\n", res.Name, res.SelfLink)
 
-	return setStorageBucket(d, config, res, bucket, userAgent)
+	if err := setStorageBucket(d, config, res, bucket, userAgent); err != nil {
+		return err
+	}
+
+	return SetDataSourceLabels(d)
 }
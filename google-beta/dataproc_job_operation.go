@@ -73,7 +73,7 @@ func dataprocJobOperationWait(config *Config, region, projectId, jobId, activity
 		ProjectId: projectId,
 		JobId:     jobId,
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("dataproc"))
 }
 
 type DataprocDeleteJobOperationWaiter struct {
@@ -113,5 +113,5 @@ func dataprocDeleteOperationWait(config *Config, region, projectId, jobId, activ
 			JobId:     jobId,
 		},
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("dataproc"))
 }
@@ -55,7 +55,7 @@ func DeploymentManagerOperationWaitTime(config *Config, resp interface{}, projec
 		return err
 	}
 
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("deployment_manager"))
 }
 
 func (w *DeploymentManagerOperationWaiter) Error() error {
@@ -41,7 +41,7 @@ func DialogflowCXOperationWaitTimeWithResponse(config *Config, op map[string]int
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("dialogflow_cx")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -57,5 +57,5 @@ func DialogflowCXOperationWaitTime(config *Config, op map[string]interface{}, ac
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("dialogflow_cx"))
 }
@@ -0,0 +1,265 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The kinds of disruption caused by updates that Terraform plans in place.
+const (
+	// DisruptionRestart updates restart the resource, such as a VM that is
+	// stopped to be updated.
+	DisruptionRestart = "restart"
+	// DisruptionRecreateNodes updates replace the nodes of a GKE cluster or
+	// node pool, evicting their workloads.
+	DisruptionRecreateNodes = "recreate-nodes"
+	// DisruptionDowntime updates make the resource unavailable while they're
+	// applied.
+	DisruptionDowntime = "downtime"
+)
+
+// disruptiveUpdate is a change to field that is applied in place but
+// disrupts the resource.
+type disruptiveUpdate struct {
+	Field  string
+	Kind   string
+	Detail string
+}
+
+// customizeDiffDisruptiveUpdates returns a CustomizeDiffFunc that reports the
+// disruptive updates found by classify as plan warnings, or as an error when
+// the provider's disruptive_updates_as_errors is set.
+func customizeDiffDisruptiveUpdates(classify func(d TerraformResourceDiff) []disruptiveUpdate) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		// Only updates can be disruptive, creating a resource isn't.
+		if d.Id() == "" {
+			return nil
+		}
+
+		updates := classify(d)
+		if len(updates) == 0 {
+			return nil
+		}
+
+		if config, ok := meta.(*Config); ok && config.DisruptiveUpdatesAsErrors {
+			var msgs []string
+			for _, u := range updates {
+				msgs = append(msgs, fmt.Sprintf("%s (%s): %s", u.Field, u.Kind, u.Detail))
+			}
+			return fmt.Errorf("disruptive updates are planned and disruptive_updates_as_errors is set:\n%s", strings.Join(msgs, "\n"))
+		}
+
+		for _, u := range updates {
+			addPlanWarning(ctx, u)
+		}
+		return nil
+	}
+}
+
+type planWarningsKey struct{}
+
+// planWarnings collects the warnings of planning a single resource.
+type planWarnings struct {
+	mu    sync.Mutex
+	diags []*tfprotov5.Diagnostic
+}
+
+// addPlanWarning adds a warning about u to the plan being made with ctx. The
+// warning is only logged when ctx doesn't belong to a plan, such as when the
+// provider is called directly in unit tests.
+func addPlanWarning(ctx context.Context, u disruptiveUpdate) {
+	log.Printf("[WARN] Disruptive update of %s (%s): %s", u.Field, u.Kind, u.Detail)
+
+	w, ok := ctx.Value(planWarningsKey{}).(*planWarnings)
+	if !ok {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.diags = append(w.diags, &tfprotov5.Diagnostic{
+		Severity:  tfprotov5.DiagnosticSeverityWarning,
+		Summary:   fmt.Sprintf("Disruptive update: %s", u.Kind),
+		Detail:    u.Detail,
+		Attribute: attributePathFromFieldPath(u.Field),
+	})
+}
+
+// attributePathFromFieldPath converts a ResourceData field path, such as
+// settings.0.tier, to an attribute path.
+func attributePathFromFieldPath(field string) *tftypes.AttributePath {
+	path := tftypes.NewAttributePath()
+	for _, part := range strings.Split(field, ".") {
+		if i, err := strconv.Atoi(part); err == nil {
+			path = path.WithElementKeyInt(i)
+		} else {
+			path = path.WithAttributeName(part)
+		}
+	}
+	return path
+}
+
+// WithPlanWarnings wraps the server of the SDK provider so that the warnings
+// added by CustomizeDiff functions while planning a resource are returned
+// with its plan. The SDK only returns the errors of CustomizeDiff functions.
+func WithPlanWarnings(server func() tfprotov5.ProviderServer) func() tfprotov5.ProviderServer {
+	return func() tfprotov5.ProviderServer {
+		return &planWarningsProviderServer{ProviderServer: server()}
+	}
+}
+
+type planWarningsProviderServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s *planWarningsProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	w := &planWarnings{}
+	resp, err := s.ProviderServer.PlanResourceChange(context.WithValue(ctx, planWarningsKey{}, w), req)
+	if resp != nil {
+		resp.Diagnostics = append(resp.Diagnostics, w.diags...)
+	}
+	return resp, err
+}
+
+func computeInstanceDisruptiveUpdates(d TerraformResourceDiff) []disruptiveUpdate {
+	// Stopped instances aren't disrupted any further.
+	if d.Get("current_status").(string) != "RUNNING" || d.Get("desired_status").(string) == "TERMINATED" {
+		return nil
+	}
+
+	var updates []disruptiveUpdate
+	for _, field := range []string{"machine_type", "min_cpu_platform", "service_account", "enable_display", "shielded_instance_config", "advanced_machine_features"} {
+		if d.HasChange(field) {
+			updates = append(updates, disruptiveUpdate{
+				Field:  field,
+				Kind:   DisruptionRestart,
+				Detail: fmt.Sprintf("Changing %s stops the instance, which is then started again. This requires allow_stopping_for_update to be set.", field),
+			})
+		}
+	}
+	return updates
+}
+
+func containerNodePoolDisruptiveUpdates(d TerraformResourceDiff) []disruptiveUpdate {
+	return nodePoolDisruptiveUpdates(d, "")
+}
+
+// nodePoolDisruptiveUpdates returns the disruptive updates of the node pool
+// whose fields start with prefix, which is either a google_container_node_pool
+// or a node_pool block of a google_container_cluster.
+func nodePoolDisruptiveUpdates(d TerraformResourceDiff, prefix string) []disruptiveUpdate {
+	var updates []disruptiveUpdate
+	for _, field := range []string{"node_config.0.image_type", "node_config.0.workload_metadata_config", "node_config.0.kubelet_config", "node_config.0.linux_node_config"} {
+		if d.HasChange(prefix + field) {
+			updates = append(updates, disruptiveUpdate{
+				Field:  prefix + field,
+				Kind:   DisruptionRecreateNodes,
+				Detail: fmt.Sprintf("Changing %s recreates every node of the node pool, following its upgrade settings.", prefix+field),
+			})
+		}
+	}
+
+	if d.HasChange(prefix+"version") && d.Get(prefix+"version").(string) != "" {
+		updates = append(updates, disruptiveUpdate{
+			Field:  prefix + "version",
+			Kind:   DisruptionRecreateNodes,
+			Detail: "Upgrading the node pool recreates every node of the node pool, following its upgrade settings.",
+		})
+	}
+
+	if d.HasChange(prefix + "node_locations") {
+		updates = append(updates, disruptiveUpdate{
+			Field:  prefix + "node_locations",
+			Kind:   DisruptionRecreateNodes,
+			Detail: "Changing the node locations of the node pool creates nodes in the added zones and deletes the nodes of the removed zones.",
+		})
+	}
+	return updates
+}
+
+func containerClusterDisruptiveUpdates(d TerraformResourceDiff) []disruptiveUpdate {
+	var updates []disruptiveUpdate
+	if d.HasChange("min_master_version") && isZone(d.Get("location").(string)) {
+		updates = append(updates, disruptiveUpdate{
+			Field:  "min_master_version",
+			Kind:   DisruptionDowntime,
+			Detail: "Upgrading the control plane of a zonal cluster makes the Kubernetes API unavailable until the upgrade completes.",
+		})
+	}
+
+	if d.HasChange("node_version") {
+		updates = append(updates, disruptiveUpdate{
+			Field:  "node_version",
+			Kind:   DisruptionRecreateNodes,
+			Detail: "Changing node_version recreates every node of the default node pool.",
+		})
+	}
+
+	if d.HasChange("node_config.0.image_type") {
+		updates = append(updates, disruptiveUpdate{
+			Field:  "node_config.0.image_type",
+			Kind:   DisruptionRecreateNodes,
+			Detail: "Changing node_config.0.image_type recreates every node of the default node pool.",
+		})
+	}
+
+	if d.HasChange("node_locations") {
+		updates = append(updates, disruptiveUpdate{
+			Field:  "node_locations",
+			Kind:   DisruptionRecreateNodes,
+			Detail: "Changing the node locations of the cluster creates nodes in the added zones and deletes the nodes of the removed zones.",
+		})
+	}
+
+	if n, ok := d.GetOk("node_pool.#"); ok {
+		for i := 0; i < n.(int); i++ {
+			updates = append(updates, nodePoolDisruptiveUpdates(d, fmt.Sprintf("node_pool.%d.", i))...)
+		}
+	}
+	return updates
+}
+
+func sqlDatabaseInstanceDisruptiveUpdates(d TerraformResourceDiff) []disruptiveUpdate {
+	var updates []disruptiveUpdate
+	for _, field := range []string{"settings.0.tier", "settings.0.availability_type", "settings.0.database_flags"} {
+		if d.HasChange(field) {
+			updates = append(updates, disruptiveUpdate{
+				Field:  field,
+				Kind:   DisruptionRestart,
+				Detail: fmt.Sprintf("Changing %s may restart the instance, interrupting its connections.", field),
+			})
+		}
+	}
+
+	if d.HasChange("settings.0.activation_policy") && d.Get("settings.0.activation_policy").(string) == "NEVER" {
+		updates = append(updates, disruptiveUpdate{
+			Field:  "settings.0.activation_policy",
+			Kind:   DisruptionDowntime,
+			Detail: "Setting activation_policy to NEVER stops the instance until it's changed again.",
+		})
+	}
+
+	if d.HasChange("database_version") {
+		updates = append(updates, disruptiveUpdate{
+			Field:  "database_version",
+			Kind:   DisruptionDowntime,
+			Detail: "Upgrading the database version makes the instance unavailable until the upgrade completes.",
+		})
+	}
+
+	if isSqlDatabaseInstanceRestoreRequested(d) {
+		updates = append(updates, disruptiveUpdate{
+			Field:  "restore.0.restore_trigger",
+			Kind:   DisruptionDowntime,
+			Detail: "Restoring a backup overwrites the data of the instance, which is unavailable until the restore completes.",
+		})
+	}
+	return updates
+}
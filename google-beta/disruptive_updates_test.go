@@ -0,0 +1,181 @@
+package google
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func disruptiveUpdateFields(updates []disruptiveUpdate) []string {
+	var fields []string
+	for _, u := range updates {
+		fields = append(fields, u.Field+":"+u.Kind)
+	}
+	return fields
+}
+
+func TestComputeInstanceDisruptiveUpdates(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		before, after map[string]interface{}
+		expected      []string
+	}{
+		"machine type of running instance": {
+			before:   map[string]interface{}{"current_status": "RUNNING", "desired_status": "", "machine_type": "e2-small"},
+			after:    map[string]interface{}{"current_status": "RUNNING", "desired_status": "", "machine_type": "e2-medium"},
+			expected: []string{"machine_type:restart"},
+		},
+		"machine type of stopped instance": {
+			before: map[string]interface{}{"current_status": "TERMINATED", "desired_status": "TERMINATED", "machine_type": "e2-small"},
+			after:  map[string]interface{}{"current_status": "TERMINATED", "desired_status": "TERMINATED", "machine_type": "e2-medium"},
+		},
+		"machine type of instance being stopped": {
+			before: map[string]interface{}{"current_status": "RUNNING", "desired_status": "RUNNING", "machine_type": "e2-small"},
+			after:  map[string]interface{}{"current_status": "RUNNING", "desired_status": "TERMINATED", "machine_type": "e2-medium"},
+		},
+		"description": {
+			before: map[string]interface{}{"current_status": "RUNNING", "desired_status": "", "description": "a"},
+			after:  map[string]interface{}{"current_status": "RUNNING", "desired_status": "", "description": "b"},
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{Before: tc.before, After: tc.after}
+		if got := disruptiveUpdateFields(computeInstanceDisruptiveUpdates(d)); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("bad: %s, expected %v, got %v", tn, tc.expected, got)
+		}
+	}
+}
+
+func TestContainerClusterDisruptiveUpdates(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		before, after map[string]interface{}
+		expected      []string
+	}{
+		"zonal master upgrade": {
+			before:   map[string]interface{}{"location": "us-central1-a", "min_master_version": "1.24"},
+			after:    map[string]interface{}{"location": "us-central1-a", "min_master_version": "1.25"},
+			expected: []string{"min_master_version:downtime"},
+		},
+		"regional master upgrade": {
+			before: map[string]interface{}{"location": "us-central1", "min_master_version": "1.24"},
+			after:  map[string]interface{}{"location": "us-central1", "min_master_version": "1.25"},
+		},
+		"node pools": {
+			before: map[string]interface{}{"location": "us-central1", "node_pool.#": 2, "node_pool.1.version": "1.24", "node_pool.1.node_config.0.image_type": "COS"},
+			after:  map[string]interface{}{"location": "us-central1", "node_pool.#": 2, "node_pool.1.version": "1.25", "node_pool.1.node_config.0.image_type": "COS_CONTAINERD"},
+			expected: []string{
+				"node_pool.1.node_config.0.image_type:recreate-nodes",
+				"node_pool.1.version:recreate-nodes",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		d := &ResourceDiffMock{Before: tc.before, After: tc.after}
+		if got := disruptiveUpdateFields(containerClusterDisruptiveUpdates(d)); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("bad: %s, expected %v, got %v", tn, tc.expected, got)
+		}
+	}
+}
+
+func TestSqlDatabaseInstanceDisruptiveUpdates(t *testing.T) {
+	t.Parallel()
+
+	d := &ResourceDiffMock{
+		Before: map[string]interface{}{"settings.0.tier": "db-f1-micro", "settings.0.activation_policy": "ALWAYS", "database_version": "POSTGRES_13", "restore.0.restore_trigger": "1"},
+		After:  map[string]interface{}{"settings.0.tier": "db-g1-small", "settings.0.activation_policy": "NEVER", "database_version": "POSTGRES_14", "restore.0.restore_trigger": "2"},
+	}
+	expected := []string{"settings.0.tier:restart", "settings.0.activation_policy:downtime", "database_version:downtime", "restore.0.restore_trigger:downtime"}
+	if got := disruptiveUpdateFields(sqlDatabaseInstanceDisruptiveUpdates(d)); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// Adding a restore block doesn't restore the instance.
+	d = &ResourceDiffMock{
+		Before: map[string]interface{}{},
+		After:  map[string]interface{}{"restore.0.restore_trigger": "1"},
+	}
+	if got := disruptiveUpdateFields(sqlDatabaseInstanceDisruptiveUpdates(d)); len(got) != 0 {
+		t.Errorf("expected adding a restore block not to be disruptive, got %v", got)
+	}
+}
+
+func TestCustomizeDiffDisruptiveUpdates(t *testing.T) {
+	t.Parallel()
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tier": {Type: schema.TypeString, Optional: true},
+		},
+		CustomizeDiff: customizeDiffDisruptiveUpdates(func(d TerraformResourceDiff) []disruptiveUpdate {
+			if !d.HasChange("tier") {
+				return nil
+			}
+			return []disruptiveUpdate{{Field: "tier", Kind: DisruptionRestart, Detail: "restarts"}}
+		}),
+	}
+	state := &terraform.InstanceState{ID: "instance", Attributes: map[string]string{"id": "instance", "tier": "small"}}
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{"tier": "large"})
+
+	w := &planWarnings{}
+	ctx := context.WithValue(context.Background(), planWarningsKey{}, w)
+	if _, err := r.Diff(ctx, state, cfg, &Config{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(w.diags) != 1 || w.diags[0].Severity != tfprotov5.DiagnosticSeverityWarning || w.diags[0].Summary != "Disruptive update: restart" {
+		t.Errorf("expected a restart warning, got %#v", w.diags)
+	}
+
+	// Creating the resource isn't disruptive.
+	w.diags = nil
+	if _, err := r.Diff(ctx, nil, cfg, &Config{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(w.diags) != 0 {
+		t.Errorf("expected no warnings on create, got %#v", w.diags)
+	}
+
+	_, err := r.Diff(ctx, state, cfg, &Config{DisruptiveUpdatesAsErrors: true})
+	if err == nil || !strings.Contains(err.Error(), "tier (restart): restarts") {
+		t.Errorf("expected a disruptive update error, got %v", err)
+	}
+}
+
+type fakePlanProviderServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s *fakePlanProviderServer) PlanResourceChange(ctx context.Context, _ *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	addPlanWarning(ctx, disruptiveUpdate{Field: "settings.0.tier", Kind: DisruptionRestart, Detail: "restarts"})
+	return &tfprotov5.PlanResourceChangeResponse{}, nil
+}
+
+func TestWithPlanWarnings(t *testing.T) {
+	t.Parallel()
+
+	server := WithPlanWarnings(func() tfprotov5.ProviderServer { return &fakePlanProviderServer{} })()
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []*tfprotov5.Diagnostic{{
+		Severity:  tfprotov5.DiagnosticSeverityWarning,
+		Summary:   "Disruptive update: restart",
+		Detail:    "restarts",
+		Attribute: tftypes.NewAttributePath().WithAttributeName("settings").WithElementKeyInt(0).WithAttributeName("tier"),
+	}}
+	if !reflect.DeepEqual(resp.Diagnostics, expected) {
+		t.Errorf("expected %#v, got %#v", expected, resp.Diagnostics)
+	}
+}
@@ -0,0 +1,84 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/api/dns/v1"
+)
+
+const (
+	batchKeyTmplDnsChanges = "projects/%s/managedZones/%s/changes"
+)
+
+// BatchRequestDnsChange can be used to batch record set changes to the same
+// managed zone across resource nodes, i.e. to send the changes of several
+// google_dns_record_set resources as a single dns.Change.
+func BatchRequestDnsChange(project, zone string, chg *dns.Change, userAgent string, config *Config, timeout time.Duration) error {
+	req := &BatchRequest[*dns.Change, struct{}]{
+		ResourceName: fmt.Sprintf("%s/%s", project, zone),
+		Body:         chg,
+		CombineF:     combineDnsChanges,
+		SendF:        sendBatchFuncDnsChange(config, userAgent),
+		DebugId:      fmt.Sprintf("DNS change %s for managed zone %q in project %q", describeDnsChange(chg), zone, project),
+	}
+
+	_, err := SendBatchRequestWithTimeout(
+		config.requestBatcherDns,
+		fmt.Sprintf(batchKeyTmplDnsChanges, project, zone),
+		req,
+		timeout)
+	return err
+}
+
+// combineDnsChanges returns a new change with the additions and deletions of
+// both changes. The given changes are left untouched, as they may still be
+// sent on their own if the combined change fails.
+func combineDnsChanges(chg *dns.Change, toAdd *dns.Change) (*dns.Change, error) {
+	combined := &dns.Change{}
+	for _, c := range []*dns.Change{chg, toAdd} {
+		combined.Additions = append(combined.Additions, c.Additions...)
+		combined.Deletions = append(combined.Deletions, c.Deletions...)
+	}
+	return combined, nil
+}
+
+func sendBatchFuncDnsChange(config *Config, userAgent string) BatcherSendFunc[*dns.Change, struct{}] {
+	return func(resourceName string, chg *dns.Change) (struct{}, error) {
+		parts := strings.SplitN(resourceName, "/", 2)
+		if len(parts) != 2 {
+			return struct{}{}, fmt.Errorf("provider error: expected DNS batch resource name in the format project/zone, got %q", resourceName)
+		}
+		project, zone := parts[0], parts[1]
+
+		log.Printf("[DEBUG] DNS change request: %#v", chg)
+		chg, err := config.NewDnsClient(userAgent).Changes.Create(project, zone, chg).Do()
+		if err != nil {
+			return struct{}{}, err
+		}
+
+		w := &DnsChangeWaiter{
+			Service:     config.NewDnsClient(userAgent),
+			Change:      chg,
+			Project:     project,
+			ManagedZone: zone,
+		}
+		if _, err := w.Conf().WaitForState(); err != nil {
+			return struct{}{}, fmt.Errorf("Error waiting for Google DNS change: %s", err)
+		}
+		return struct{}{}, nil
+	}
+}
+
+func describeDnsChange(chg *dns.Change) string {
+	var parts []string
+	for _, r := range chg.Deletions {
+		parts = append(parts, fmt.Sprintf("-%s/%s", r.Name, r.Type))
+	}
+	for _, r := range chg.Additions {
+		parts = append(parts, fmt.Sprintf("+%s/%s", r.Name, r.Type))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,367 @@
+// Workload Identity Federation support: an oauth2.TokenSource exchanging a
+// subject token issued by an external identity provider, such as a CI system's
+// OIDC token, for a federated Google access token through the Security Token
+// Service.
+
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	defaultExternalAccountTokenURL         = "https://sts.googleapis.com/v1/token"
+	defaultExternalAccountSubjectTokenType = "urn:ietf:params:oauth:token-type:jwt"
+
+	ExternalAccountSubjectTokenFormatText = "text"
+	ExternalAccountSubjectTokenFormatJSON = "json"
+
+	// externalAccountCommandTimeout bounds how long a subject token command
+	// may run.
+	externalAccountCommandTimeout = 30 * time.Second
+	// externalAccountHTTPTimeout bounds the requests reading a subject token
+	// from a URL and exchanging it with the Security Token Service.
+	externalAccountHTTPTimeout = 30 * time.Second
+	// externalAccountSubjectTokenExpiryMargin is how long before it expires a
+	// cached subject token is considered expired, so it's still valid when
+	// the Security Token Service checks it.
+	externalAccountSubjectTokenExpiryMargin = 1 * time.Minute
+)
+
+var externalAccountHTTPClient = &http.Client{Timeout: externalAccountHTTPTimeout}
+
+// cachedSubjectToken is a subject token printed by a command, and when it
+// expires.
+type cachedSubjectToken struct {
+	token  string
+	expiry time.Time
+}
+
+// externalAccountCommandTokens caches the subject tokens printed by commands,
+// keyed by command, so a command isn't rerun on every token refresh while its
+// last token is valid. Tokens without a known expiry aren't cached.
+var (
+	externalAccountCommandTokensMu sync.Mutex
+	externalAccountCommandTokens   = map[string]cachedSubjectToken{}
+)
+
+// ExternalAccountConfig configures authenticating as a workload identity pool
+// principal. Exactly one of SubjectTokenFile, SubjectTokenURL and
+// SubjectTokenCommand must be set.
+type ExternalAccountConfig struct {
+	// Audience is the full resource name of the workload identity pool
+	// provider, e.g. "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider".
+	Audience string
+	// SubjectTokenType defaults to a JWT.
+	SubjectTokenType string
+	// TokenURL is the Security Token Service endpoint.
+	TokenURL string
+
+	SubjectTokenFile       string
+	SubjectTokenURL        string
+	SubjectTokenURLHeaders map[string]string
+	// SubjectTokenCommand is the program, and its arguments, printing the
+	// subject token to stdout.
+	SubjectTokenCommand []string
+
+	// SubjectTokenFormat is one of ExternalAccountSubjectTokenFormatText or
+	// ExternalAccountSubjectTokenFormatJSON. For JSON, the token is read from
+	// the SubjectTokenField field.
+	SubjectTokenFormat string
+	SubjectTokenField  string
+}
+
+func (c *ExternalAccountConfig) validate() error {
+	if c.Audience == "" {
+		return fmt.Errorf("'audience' must be set in the 'external_account' block")
+	}
+
+	sources := 0
+	for _, set := range []bool{c.SubjectTokenFile != "", c.SubjectTokenURL != "", len(c.SubjectTokenCommand) > 0} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of 'subject_token_file', 'subject_token_url' or 'subject_token_command' must be set in the 'external_account' block")
+	}
+
+	switch c.SubjectTokenFormat {
+	case "", ExternalAccountSubjectTokenFormatText:
+	case ExternalAccountSubjectTokenFormatJSON:
+		if c.SubjectTokenField == "" {
+			return fmt.Errorf("'subject_token_field' must be set when 'subject_token_format' is %q", ExternalAccountSubjectTokenFormatJSON)
+		}
+	default:
+		return fmt.Errorf("unknown 'subject_token_format' %q", c.SubjectTokenFormat)
+	}
+	return nil
+}
+
+// subjectTokenSource describes where the subject token is read from, for logs.
+func (c *ExternalAccountConfig) subjectTokenSource() string {
+	switch {
+	case c.SubjectTokenFile != "":
+		return fmt.Sprintf("file %s", c.SubjectTokenFile)
+	case c.SubjectTokenURL != "":
+		return fmt.Sprintf("URL %s", c.SubjectTokenURL)
+	default:
+		return fmt.Sprintf("command %s", c.SubjectTokenCommand[0])
+	}
+}
+
+// subjectToken reads the current subject token from the configured source.
+// The token printed by a command is reused until it expires.
+func (c *ExternalAccountConfig) subjectToken(ctx context.Context, client *http.Client) (string, error) {
+	var commandKey string
+	if len(c.SubjectTokenCommand) > 0 {
+		commandKey = strings.Join(c.SubjectTokenCommand, "\x00")
+		externalAccountCommandTokensMu.Lock()
+		cached, ok := externalAccountCommandTokens[commandKey]
+		externalAccountCommandTokensMu.Unlock()
+		if ok && time.Now().Add(externalAccountSubjectTokenExpiryMargin).Before(cached.expiry) {
+			return cached.token, nil
+		}
+	}
+
+	var raw []byte
+	var err error
+	switch {
+	case c.SubjectTokenFile != "":
+		raw, err = ioutil.ReadFile(c.SubjectTokenFile)
+	case c.SubjectTokenURL != "":
+		raw, err = c.readSubjectTokenURL(ctx, client)
+	default:
+		raw, err = c.runSubjectTokenCommand(ctx)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading subject token from %s: %s", c.subjectTokenSource(), err)
+	}
+
+	token, expiry, err := c.parseSubjectToken(raw)
+	if err != nil {
+		return "", err
+	}
+	if commandKey != "" && !expiry.IsZero() {
+		externalAccountCommandTokensMu.Lock()
+		externalAccountCommandTokens[commandKey] = cachedSubjectToken{token: token, expiry: expiry}
+		externalAccountCommandTokensMu.Unlock()
+	}
+	return token, nil
+}
+
+// parseSubjectToken returns the subject token in the output raw of its source,
+// and when it expires: the "expiration_time" field of a JSON output, in
+// seconds since the epoch, or else the "exp" claim of a JWT. The expiry is
+// zero if unknown.
+func (c *ExternalAccountConfig) parseSubjectToken(raw []byte) (string, time.Time, error) {
+	if c.SubjectTokenFormat != ExternalAccountSubjectTokenFormatJSON {
+		token := strings.TrimSpace(string(raw))
+		if token == "" {
+			return "", time.Time{}, fmt.Errorf("empty subject token read from %s", c.subjectTokenSource())
+		}
+		_, exp := subjectTokenClaims(token)
+		return token, exp, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "", time.Time{}, fmt.Errorf("error parsing subject token from %s as JSON: %s", c.subjectTokenSource(), err)
+	}
+	token, ok := fields[c.SubjectTokenField].(string)
+	if !ok || token == "" {
+		return "", time.Time{}, fmt.Errorf("field %q not found in subject token JSON read from %s", c.SubjectTokenField, c.subjectTokenSource())
+	}
+	if exp, ok := fields["expiration_time"].(float64); ok {
+		return token, time.Unix(int64(exp), 0), nil
+	}
+	_, exp := subjectTokenClaims(token)
+	return token, exp, nil
+}
+
+// subjectTokenClaims returns the "sub" and "exp" claims of a JWT subject
+// token, or zero values if the token isn't a JWT or lacks them.
+func subjectTokenClaims(token string) (string, time.Time) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", time.Time{}
+	}
+	var claims struct {
+		Sub string `json:"sub"`
+		Exp int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", time.Time{}
+	}
+	var exp time.Time
+	if claims.Exp > 0 {
+		exp = time.Unix(claims.Exp, 0)
+	}
+	return claims.Sub, exp
+}
+
+func (c *ExternalAccountConfig) readSubjectTokenURL(ctx context.Context, client *http.Client) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.SubjectTokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.SubjectTokenURLHeaders {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return body, nil
+}
+
+func (c *ExternalAccountConfig) runSubjectTokenCommand(ctx context.Context) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, externalAccountCommandTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.SubjectTokenCommand[0], c.SubjectTokenCommand[1:]...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// identity returns the principal the subject token federates to, assuming
+// the default `google.subject = assertion.sub` attribute mapping, or the
+// audience if the subject token isn't a JWT.
+func (c *ExternalAccountConfig) identity(ctx context.Context) string {
+	pool := c.Audience
+	if i := strings.Index(pool, "/providers/"); i >= 0 {
+		pool = pool[:i]
+	}
+	pool = strings.TrimPrefix(pool, "//")
+
+	token, err := c.subjectToken(ctx, externalAccountHTTPClient)
+	if err != nil {
+		return c.Audience
+	}
+	sub, _ := subjectTokenClaims(token)
+	if sub == "" {
+		return c.Audience
+	}
+	return fmt.Sprintf("principal://%s/subject/%s", pool, sub)
+}
+
+// NewExternalAccountTokenSource returns a token source exchanging the
+// configured subject token for a federated access token with the given
+// scopes. Tokens are cached until they expire.
+func NewExternalAccountTokenSource(cfg *ExternalAccountConfig, scopes []string) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, &externalAccountTokenSource{
+		cfg:    cfg,
+		scopes: scopes,
+		client: externalAccountHTTPClient,
+	})
+}
+
+type externalAccountTokenSource struct {
+	cfg    *ExternalAccountConfig
+	scopes []string
+	client *http.Client
+}
+
+// stsTokenResponse is the response of the Security Token Service token
+// exchange, see https://cloud.google.com/iam/docs/reference/sts/rest/v1/TopLevel/token.
+type stsTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Token implements the oauth2.TokenSource interface method.
+// It reads the subject token and exchanges it for an access token.
+func (ts *externalAccountTokenSource) Token() (*oauth2.Token, error) {
+	ctx := context.Background()
+	subjectToken, err := ts.cfg.subjectToken(ctx, ts.client)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenURL := ts.cfg.TokenURL
+	if tokenURL == "" {
+		tokenURL = defaultExternalAccountTokenURL
+	}
+	subjectTokenType := ts.cfg.SubjectTokenType
+	if subjectTokenType == "" {
+		subjectTokenType = defaultExternalAccountSubjectTokenType
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:token-exchange")
+	form.Set("audience", ts.cfg.Audience)
+	form.Set("scope", strings.Join(ts.scopes, " "))
+	form.Set("requested_token_type", "urn:ietf:params:oauth:token-type:access_token")
+	form.Set("subject_token", subjectToken)
+	form.Set("subject_token_type", subjectTokenType)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging subject token: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading token exchange response: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error exchanging subject token: %s: %s", resp.Status, body)
+	}
+
+	var stsResp stsTokenResponse
+	if err := json.Unmarshal(body, &stsResp); err != nil {
+		return nil, fmt.Errorf("error parsing token exchange response: %s", err)
+	}
+	if stsResp.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange response has no access token")
+	}
+
+	token := &oauth2.Token{
+		AccessToken: stsResp.AccessToken,
+		TokenType:   stsResp.TokenType,
+	}
+	if stsResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(stsResp.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
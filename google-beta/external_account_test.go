@@ -0,0 +1,213 @@
+package google
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testExternalAccountAudience = "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/my-pool/providers/my-provider"
+
+func TestExternalAccountConfig_validate(t *testing.T) {
+	cases := map[string]struct {
+		cfg     ExternalAccountConfig
+		wantErr bool
+	}{
+		"file": {
+			cfg: ExternalAccountConfig{Audience: testExternalAccountAudience, SubjectTokenFile: "/var/run/token"},
+		},
+		"no audience": {
+			cfg:     ExternalAccountConfig{SubjectTokenFile: "/var/run/token"},
+			wantErr: true,
+		},
+		"no source": {
+			cfg:     ExternalAccountConfig{Audience: testExternalAccountAudience},
+			wantErr: true,
+		},
+		"several sources": {
+			cfg:     ExternalAccountConfig{Audience: testExternalAccountAudience, SubjectTokenFile: "/var/run/token", SubjectTokenURL: "http://localhost/token"},
+			wantErr: true,
+		},
+		"json without field": {
+			cfg:     ExternalAccountConfig{Audience: testExternalAccountAudience, SubjectTokenURL: "http://localhost/token", SubjectTokenFormat: ExternalAccountSubjectTokenFormatJSON},
+			wantErr: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		err := tc.cfg.validate()
+		if tc.wantErr != (err != nil) {
+			t.Errorf("%s: expected error: %v, got %v", tn, tc.wantErr, err)
+		}
+	}
+}
+
+func TestExternalAccountTokenSource_subjectTokenSources(t *testing.T) {
+	urlSource := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"value": "url-token"}`)
+	}))
+	defer urlSource.Close()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		cfg      ExternalAccountConfig
+		expected string
+	}{
+		"file": {
+			cfg:      ExternalAccountConfig{SubjectTokenFile: tokenFile},
+			expected: "file-token",
+		},
+		"url": {
+			cfg: ExternalAccountConfig{
+				SubjectTokenURL:        urlSource.URL,
+				SubjectTokenURLHeaders: map[string]string{"Metadata": "true"},
+				SubjectTokenFormat:     ExternalAccountSubjectTokenFormatJSON,
+				SubjectTokenField:      "value",
+			},
+			expected: "url-token",
+		},
+		"command": {
+			cfg:      ExternalAccountConfig{SubjectTokenCommand: []string{"echo", "command-token"}},
+			expected: "command-token",
+		},
+	}
+
+	for tn, tc := range cases {
+		var gotSubjectToken string
+		sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("%s: error parsing token exchange request: %s", tn, err)
+			}
+			gotSubjectToken = r.PostForm.Get("subject_token")
+			if got := r.PostForm.Get("audience"); got != testExternalAccountAudience {
+				t.Errorf("%s: expected audience %q, got %q", tn, testExternalAccountAudience, got)
+			}
+			if got := r.PostForm.Get("scope"); got != "https://www.googleapis.com/auth/cloud-platform" {
+				t.Errorf("%s: unexpected scope %q", tn, got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token": "federated-token", "token_type": "Bearer", "expires_in": 3600}`)
+		}))
+
+		cfg := tc.cfg
+		cfg.Audience = testExternalAccountAudience
+		cfg.TokenURL = sts.URL
+		token, err := NewExternalAccountTokenSource(&cfg, []string{"https://www.googleapis.com/auth/cloud-platform"}).Token()
+		sts.Close()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tn, err)
+			continue
+		}
+		if gotSubjectToken != tc.expected {
+			t.Errorf("%s: expected subject token %q, got %q", tn, tc.expected, gotSubjectToken)
+		}
+		if token.AccessToken != "federated-token" || token.Expiry.IsZero() {
+			t.Errorf("%s: unexpected token %+v", tn, token)
+		}
+	}
+}
+
+func TestExternalAccountTokenSource_exchangeError(t *testing.T) {
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": "invalid_grant"}`)
+	}))
+	defer sts.Close()
+
+	cfg := &ExternalAccountConfig{
+		Audience:            testExternalAccountAudience,
+		TokenURL:            sts.URL,
+		SubjectTokenCommand: []string{"echo", "token"},
+	}
+	if _, err := NewExternalAccountTokenSource(cfg, nil).Token(); err == nil {
+		t.Fatalf("expected an error when the token exchange fails")
+	}
+}
+
+func TestExternalAccountConfig_identity(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub": "repo:org/repo:ref:refs/heads/main"}`))
+	jwt := "eyJhbGciOiJSUzI1NiJ9." + payload + ".signature"
+
+	cfg := &ExternalAccountConfig{
+		Audience:            testExternalAccountAudience,
+		SubjectTokenCommand: []string{"echo", jwt},
+	}
+	expected := "principal://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/my-pool/subject/repo:org/repo:ref:refs/heads/main"
+	if got := cfg.identity(context.Background()); got != expected {
+		t.Errorf("expected identity %q, got %q", expected, got)
+	}
+
+	cfg.SubjectTokenCommand = []string{"echo", "not-a-jwt"}
+	if got := cfg.identity(context.Background()); got != testExternalAccountAudience {
+		t.Errorf("expected identity %q, got %q", testExternalAccountAudience, got)
+	}
+}
+
+func TestExternalAccountConfig_subjectTokenCommandCache(t *testing.T) {
+	jwt := func(exp time.Time) string {
+		payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub": "me", "exp": %d}`, exp.Unix())))
+		return "eyJhbGciOiJSUzI1NiJ9." + payload + ".signature"
+	}
+
+	cases := map[string]struct {
+		output       string
+		format       string
+		expectedRuns int
+	}{
+		"valid jwt": {
+			output:       jwt(time.Now().Add(1 * time.Hour)),
+			expectedRuns: 1,
+		},
+		"jwt expiring within the margin": {
+			output:       jwt(time.Now().Add(30 * time.Second)),
+			expectedRuns: 2,
+		},
+		"token without expiry": {
+			output:       "opaque-token",
+			expectedRuns: 2,
+		},
+		"json expiration_time": {
+			output:       fmt.Sprintf(`{"id_token": "opaque-token", "expiration_time": %d}`, time.Now().Add(1*time.Hour).Unix()),
+			format:       ExternalAccountSubjectTokenFormatJSON,
+			expectedRuns: 1,
+		},
+	}
+
+	for tn, tc := range cases {
+		runs := filepath.Join(t.TempDir(), "runs")
+		cfg := &ExternalAccountConfig{
+			Audience:            testExternalAccountAudience,
+			SubjectTokenCommand: []string{"sh", "-c", fmt.Sprintf("echo run >> %s; echo '%s'", runs, tc.output)},
+			SubjectTokenFormat:  tc.format,
+			SubjectTokenField:   "id_token",
+		}
+		for i := 0; i < 2; i++ {
+			if _, err := cfg.subjectToken(context.Background(), externalAccountHTTPClient); err != nil {
+				t.Fatalf("%s: unexpected error: %s", tn, err)
+			}
+		}
+		b, err := ioutil.ReadFile(runs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Count(string(b), "run"); got != tc.expectedRuns {
+			t.Errorf("%s: expected the command to run %d times, got %d", tn, tc.expectedRuns, got)
+		}
+	}
+}
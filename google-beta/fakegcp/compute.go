@@ -0,0 +1,346 @@
+package fakegcp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// computeCollection describes a Compute resource collection: its kind, the
+// scope of its resources, and how new resources are validated and defaulted.
+type computeCollection struct {
+	kind     string
+	regional bool
+	// insert validates a new resource and sets its server-side defaults,
+	// returning an error message if it's invalid.
+	insert func(s *Server, version, project string, obj map[string]interface{}) string
+}
+
+var computeCollections = map[string]computeCollection{
+	"networks": {
+		kind:   "compute#network",
+		insert: insertComputeNetwork,
+	},
+	"subnetworks": {
+		kind:     "compute#subnetwork",
+		regional: true,
+		insert:   insertComputeSubnetwork,
+	},
+	"firewalls": {
+		kind:   "compute#firewall",
+		insert: insertComputeFirewall,
+	},
+}
+
+var computeNameRegex = regexp.MustCompile(`^[a-z](?:[-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// serveCompute serves the paths under /compute/{version}/.
+func (s *Server) serveCompute(w http.ResponseWriter, r *http.Request, version string, segments []string) {
+	if len(segments) < 2 || segments[0] != "projects" {
+		writeUnimplemented(w, r)
+		return
+	}
+	project := s.projectID(segments[1])
+	rest := segments[2:]
+
+	if len(rest) == 0 {
+		if r.Method != http.MethodGet {
+			writeUnimplemented(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"kind":     "compute#project",
+			"id":       strconv.FormatInt(s.projectNumber(project), 10),
+			"name":     project,
+			"selfLink": s.computeLink(version, project, ""),
+		})
+		return
+	}
+
+	var scope string
+	switch {
+	case rest[0] == "global" && len(rest) >= 2:
+		scope, rest = "global", rest[1:]
+	case rest[0] == "regions" && len(rest) >= 3:
+		scope, rest = "regions/"+rest[1], rest[2:]
+	default:
+		writeUnimplemented(w, r)
+		return
+	}
+
+	if rest[0] == "operations" && len(rest) >= 2 {
+		s.serveComputeOperation(w, r, version, project, scope, rest[1:])
+		return
+	}
+
+	c, ok := computeCollections[rest[0]]
+	if !ok || c.regional != (scope != "global") {
+		writeUnimplemented(w, r)
+		return
+	}
+	collectionPath := fmt.Sprintf("projects/%s/%s/%s", project, scope, rest[0])
+	key := "compute/" + collectionPath
+
+	switch {
+	case len(rest) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"kind":     c.kind + "List",
+			"items":    s.list(key + "/"),
+			"selfLink": s.computeLink(version, project, scope+"/"+rest[0]),
+		})
+	case len(rest) == 1 && r.Method == http.MethodPost:
+		obj, err := readJSON(r)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		name, _ := obj["name"].(string)
+		if !computeNameRegex.MatchString(name) {
+			writeBadRequest(w, fmt.Sprintf("Invalid value for field 'resource.name': '%s'. Must be a match of regex '(?:[a-z](?:[-a-z0-9]{0,61}[a-z0-9])?)'", name))
+			return
+		}
+		if _, ok := s.resources[key+"/"+name]; ok {
+			writeAlreadyExists(w, collectionPath+"/"+name)
+			return
+		}
+		if msg := c.insert(s, version, project, obj); msg != "" {
+			writeBadRequest(w, msg)
+			return
+		}
+		selfLink := s.computeLink(version, project, scope+"/"+rest[0]+"/"+name)
+		obj["kind"] = c.kind
+		obj["id"] = strconv.FormatInt(s.newID(), 10)
+		obj["selfLink"] = selfLink
+		obj["creationTimestamp"] = timestamp()
+		if c.regional {
+			obj["region"] = s.computeLink(version, project, scope)
+		}
+		s.resources[key+"/"+name] = obj
+		s.writeComputeOperation(w, version, project, scope, "insert", selfLink)
+	case len(rest) == 2:
+		path := key + "/" + rest[1]
+		obj, ok := s.resources[path]
+		if !ok {
+			writeNotFound(w, collectionPath+"/"+rest[1])
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, obj)
+		case http.MethodPatch, http.MethodPut:
+			update, err := readJSON(r)
+			if err != nil {
+				writeBadRequest(w, err.Error())
+				return
+			}
+			if fp, ok := update["fingerprint"].(string); ok && fp != "" && obj["fingerprint"] != nil && fp != obj["fingerprint"] {
+				writeError(w, http.StatusPreconditionFailed, "FAILED_PRECONDITION", "conditionNotMet", "Supplied fingerprint does not match current resource fingerprint.")
+				return
+			}
+			for _, k := range []string{"kind", "id", "selfLink", "creationTimestamp", "region", "name"} {
+				delete(update, k)
+			}
+			if r.Method == http.MethodPut {
+				for k := range obj {
+					if _, ok := update[k]; !ok && !computeOutputField(k) {
+						delete(obj, k)
+					}
+				}
+			}
+			mergeFields(obj, update)
+			if _, ok := obj["fingerprint"]; ok {
+				obj["fingerprint"] = s.etag()
+			}
+			s.writeComputeOperation(w, version, project, scope, "patch", obj["selfLink"].(string))
+		case http.MethodDelete:
+			if users := s.computeNetworkUsers(obj["selfLink"].(string)); rest[0] == "networks" && len(users) > 0 {
+				writeError(w, http.StatusBadRequest, "FAILED_PRECONDITION", "resourceInUseByAnotherResource", fmt.Sprintf("The network resource '%s' is already being used by '%s'", collectionPath+"/"+rest[1], users[0]))
+				return
+			}
+			delete(s.resources, path)
+			s.writeComputeOperation(w, version, project, scope, "delete", obj["selfLink"].(string))
+		default:
+			writeUnimplemented(w, r)
+		}
+	case len(rest) == 3 && r.Method == http.MethodPost:
+		obj, ok := s.resources[key+"/"+rest[1]]
+		if !ok {
+			writeNotFound(w, collectionPath+"/"+rest[1])
+			return
+		}
+		body, err := readJSON(r)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		switch rest[0] + "/" + rest[2] {
+		case "subnetworks/setPrivateIpGoogleAccess":
+			obj["privateIpGoogleAccess"] = body["privateIpGoogleAccess"] == true
+		case "subnetworks/expandIpCidrRange":
+			obj["ipCidrRange"] = body["ipCidrRange"]
+		default:
+			writeUnimplemented(w, r)
+			return
+		}
+		s.writeComputeOperation(w, version, project, scope, rest[2], obj["selfLink"].(string))
+	default:
+		writeUnimplemented(w, r)
+	}
+}
+
+func computeOutputField(k string) bool {
+	switch k {
+	case "kind", "id", "selfLink", "creationTimestamp", "region", "name", "fingerprint", "gatewayAddress":
+		return true
+	}
+	return false
+}
+
+func (s *Server) computeLink(version, project, path string) string {
+	link := fmt.Sprintf("%s/compute/%s/projects/%s", s.URL, version, project)
+	if path != "" {
+		link += "/" + path
+	}
+	return link
+}
+
+// computeNetworkLink returns the self link of the network a resource refers
+// to, by URL, relative path or name, or "" if the network doesn't exist.
+func (s *Server) computeNetworkLink(version, project string, network interface{}) string {
+	n, _ := network.(string)
+	if n == "" {
+		return ""
+	}
+	name := n[strings.LastIndex(n, "/")+1:]
+	if i := strings.Index(n, "projects/"); i >= 0 {
+		project = strings.Split(n[i:], "/")[1]
+	}
+	if _, ok := s.resources[fmt.Sprintf("compute/projects/%s/global/networks/%s", project, name)]; !ok {
+		return ""
+	}
+	return s.computeLink(version, project, "global/networks/"+name)
+}
+
+// computeNetworkUsers returns the self links of the resources using a network.
+func (s *Server) computeNetworkUsers(networkLink string) []string {
+	var users []string
+	networkPath := networkLink[strings.Index(networkLink, "/projects/"):]
+	for _, path := range s.paths("compute/") {
+		if n, ok := s.resources[path]["network"].(string); ok && strings.HasSuffix(n, networkPath) {
+			users = append(users, strings.TrimPrefix(path, "compute/"))
+		}
+	}
+	return users
+}
+
+func insertComputeNetwork(s *Server, version, project string, obj map[string]interface{}) string {
+	if _, ok := obj["routingConfig"]; !ok {
+		obj["routingConfig"] = map[string]interface{}{"routingMode": "REGIONAL"}
+	}
+	if _, ok := obj["mtu"]; !ok {
+		obj["mtu"] = 1460
+	}
+	if _, ok := obj["networkFirewallPolicyEnforcementOrder"]; !ok {
+		obj["networkFirewallPolicyEnforcementOrder"] = "AFTER_CLASSIC_FIREWALL"
+	}
+	return ""
+}
+
+func insertComputeSubnetwork(s *Server, version, project string, obj map[string]interface{}) string {
+	network := s.computeNetworkLink(version, project, obj["network"])
+	if network == "" {
+		return fmt.Sprintf("The resource '%v' of type 'network' was not found", obj["network"])
+	}
+	obj["network"] = network
+
+	cidr, _ := obj["ipCidrRange"].(string)
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || ipNet.IP.To4() == nil {
+		return fmt.Sprintf("Invalid value for field 'resource.ipCidrRange': '%s'. Invalid IPCidrRange: %s", cidr, cidr)
+	}
+	gateway := make(net.IP, 4)
+	binary.BigEndian.PutUint32(gateway, binary.BigEndian.Uint32(ipNet.IP.To4())+1)
+	obj["gatewayAddress"] = gateway.String()
+
+	if _, ok := obj["privateIpGoogleAccess"]; !ok {
+		obj["privateIpGoogleAccess"] = false
+	}
+	if _, ok := obj["purpose"]; !ok {
+		obj["purpose"] = "PRIVATE"
+	}
+	if _, ok := obj["stackType"]; !ok {
+		obj["stackType"] = "IPV4_ONLY"
+	}
+	obj["fingerprint"] = s.etag()
+	return ""
+}
+
+func insertComputeFirewall(s *Server, version, project string, obj map[string]interface{}) string {
+	network := s.computeNetworkLink(version, project, obj["network"])
+	if network == "" {
+		return fmt.Sprintf("The resource '%v' of type 'network' was not found", obj["network"])
+	}
+	obj["network"] = network
+
+	_, allowed := obj["allowed"]
+	_, denied := obj["denied"]
+	if allowed == denied {
+		return "Exactly one of 'allowed' or 'denied' must be specified"
+	}
+	if _, ok := obj["direction"]; !ok {
+		obj["direction"] = "INGRESS"
+	}
+	if _, ok := obj["priority"]; !ok {
+		obj["priority"] = 1000
+	}
+	if _, ok := obj["disabled"]; !ok {
+		obj["disabled"] = false
+	}
+	return ""
+}
+
+// writeComputeOperation records a completed operation and writes it as still
+// running, so that callers go through polling it.
+func (s *Server) writeComputeOperation(w http.ResponseWriter, version, project, scope, operationType, targetLink string) {
+	name := fmt.Sprintf("operation-%d", s.newID())
+	op := map[string]interface{}{
+		"kind":          "compute#operation",
+		"id":            strconv.FormatInt(s.newID(), 10),
+		"name":          name,
+		"operationType": operationType,
+		"targetLink":    targetLink,
+		"status":        "DONE",
+		"progress":      100,
+		"insertTime":    timestamp(),
+		"selfLink":      s.computeLink(version, project, scope+"/operations/"+name),
+	}
+	if scope != "global" {
+		op["region"] = s.computeLink(version, project, scope)
+	}
+	s.resources[fmt.Sprintf("compute/projects/%s/%s/operations/%s", project, scope, name)] = op
+
+	running := copyJSON(op)
+	running["status"] = "RUNNING"
+	running["progress"] = 0
+	writeJSON(w, http.StatusOK, running)
+}
+
+func (s *Server) serveComputeOperation(w http.ResponseWriter, r *http.Request, version, project, scope string, rest []string) {
+	relative := fmt.Sprintf("projects/%s/%s/operations/%s", project, scope, rest[0])
+	op, ok := s.resources["compute/"+relative]
+	if !ok {
+		writeNotFound(w, relative)
+		return
+	}
+	switch {
+	case len(rest) == 1 && r.Method == http.MethodGet,
+		len(rest) == 2 && rest[1] == "wait" && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, op)
+	default:
+		writeUnimplemented(w, r)
+	}
+}
@@ -0,0 +1,118 @@
+package fakegcp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// servePubsub serves the paths under /pubsub/v1/.
+func (s *Server) servePubsub(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) < 3 || segments[0] != "projects" || (segments[2] != "topics" && segments[2] != "subscriptions") {
+		writeUnimplemented(w, r)
+		return
+	}
+	project := s.projectID(segments[1])
+	collection := segments[2]
+	prefix := fmt.Sprintf("projects/%s/%s/", project, collection)
+
+	if len(segments) == 3 && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, map[string]interface{}{collection: s.list("pubsub/" + prefix)})
+		return
+	}
+	if len(segments) != 4 {
+		writeUnimplemented(w, r)
+		return
+	}
+
+	name := prefix + segments[3]
+	key := "pubsub/" + name
+	obj, exists := s.resources[key]
+	switch r.Method {
+	case http.MethodPut:
+		if exists {
+			writeError(w, http.StatusConflict, "ALREADY_EXISTS", "alreadyExists", "Resource already exists in the project (resource="+segments[3]+").")
+			return
+		}
+		obj, err := readJSON(r)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		obj["name"] = name
+		if collection == "subscriptions" {
+			if msg := s.insertPubsubSubscription(obj); msg != "" {
+				writeError(w, http.StatusNotFound, "NOT_FOUND", "notFound", msg)
+				return
+			}
+		}
+		s.resources[key] = obj
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodGet:
+		if !exists {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "notFound", "Resource not found (resource="+segments[3]+").")
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPatch:
+		if !exists {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "notFound", "Resource not found (resource="+segments[3]+").")
+			return
+		}
+		body, err := readJSON(r)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		update, _ := body[strings.TrimSuffix(collection, "s")].(map[string]interface{})
+		mask, _ := body["updateMask"].(string)
+		if mask == "" {
+			mask = r.URL.Query().Get("updateMask")
+		}
+		if update == nil || mask == "" {
+			writeBadRequest(w, "The update_mask in the Update request must be set.")
+			return
+		}
+		applyUpdateMask(obj, update, mask)
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		if !exists {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "notFound", "Resource not found (resource="+segments[3]+").")
+			return
+		}
+		delete(s.resources, key)
+		if collection == "topics" {
+			// Subscriptions outlive their topic, which is then reported as
+			// deleted.
+			for _, sub := range s.list(fmt.Sprintf("pubsub/projects/%s/subscriptions/", project)) {
+				if sub := sub.(map[string]interface{}); sub["topic"] == name {
+					sub["topic"] = "_deleted-topic_"
+				}
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeUnimplemented(w, r)
+	}
+}
+
+// insertPubsubSubscription checks that the topic of a new subscription
+// exists and sets the subscription's defaults, returning an error message if
+// the topic is missing.
+func (s *Server) insertPubsubSubscription(obj map[string]interface{}) string {
+	topic, _ := obj["topic"].(string)
+	if _, ok := s.resources["pubsub/"+topic]; !ok {
+		return "Resource not found (resource=" + topic[strings.LastIndex(topic, "/")+1:] + ")."
+	}
+	if _, ok := obj["ackDeadlineSeconds"]; !ok {
+		obj["ackDeadlineSeconds"] = 10
+	}
+	if _, ok := obj["messageRetentionDuration"]; !ok {
+		obj["messageRetentionDuration"] = "604800s"
+	}
+	if _, ok := obj["expirationPolicy"]; !ok {
+		obj["expirationPolicy"] = map[string]interface{}{"ttl": "2678400s"}
+	}
+	obj["state"] = "ACTIVE"
+	return ""
+}
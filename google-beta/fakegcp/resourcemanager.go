@@ -0,0 +1,89 @@
+package fakegcp
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// serveResourceManager serves the paths under /cloudresourcemanager/v1/.
+// Any project ID is accepted, and projects are created on first use with a
+// policy granting roles/owner to a fake user.
+func (s *Server) serveResourceManager(w http.ResponseWriter, r *http.Request, segments []string) {
+	segments, verb := splitVerb(segments)
+	if len(segments) != 2 || segments[0] != "projects" {
+		writeUnimplemented(w, r)
+		return
+	}
+	project := s.projectID(segments[1])
+	number := s.projectNumber(project)
+	key := "cloudresourcemanager/projects/" + project
+	if _, ok := s.resources[key]; !ok {
+		s.resources[key] = map[string]interface{}{
+			"projectId":      project,
+			"projectNumber":  strconv.FormatInt(number, 10),
+			"name":           project,
+			"lifecycleState": "ACTIVE",
+			"createTime":     timestamp(),
+		}
+		s.resources[key+"/iamPolicy"] = map[string]interface{}{
+			"version": 1,
+			"etag":    s.etag(),
+			"bindings": []interface{}{
+				map[string]interface{}{
+					"role":    "roles/owner",
+					"members": []interface{}{"user:owner@example.com"},
+				},
+			},
+		}
+	}
+	policy := s.resources[key+"/iamPolicy"]
+
+	switch {
+	case verb == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.resources[key])
+	case verb == "getIamPolicy" && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, policy)
+	case verb == "setIamPolicy" && r.Method == http.MethodPost:
+		body, err := readJSON(r)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		update, ok := body["policy"].(map[string]interface{})
+		if !ok {
+			writeBadRequest(w, "policy must be specified.")
+			return
+		}
+		if etag, _ := update["etag"].(string); etag != "" && etag != policy["etag"] {
+			writeError(w, http.StatusConflict, "ABORTED", "aborted", "There were concurrent policy changes. Please retry the whole read-modify-write with exponential backoff.")
+			return
+		}
+		// Without an update mask, only the bindings are replaced
+		fields := []string{"bindings", "version"}
+		if mask, _ := body["updateMask"].(string); mask != "" {
+			fields = []string{"version"}
+			for _, f := range strings.Split(mask, ",") {
+				if f := camelCase(strings.TrimSpace(f)); f != "etag" && f != "version" {
+					fields = append(fields, f)
+				}
+			}
+		}
+		applyUpdateMask(policy, update, strings.Join(fields, ","))
+		if policy["version"] == nil {
+			policy["version"] = 1
+		}
+		policy["etag"] = s.etag()
+		writeJSON(w, http.StatusOK, policy)
+	case verb == "testIamPermissions" && r.Method == http.MethodPost:
+		body, err := readJSON(r)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"permissions": body["permissions"]})
+	default:
+		writeError(w, http.StatusNotImplemented, "UNIMPLEMENTED", "notImplemented", fmt.Sprintf("fakegcp doesn't implement %s projects/%s:%s", r.Method, project, verb))
+	}
+}
@@ -0,0 +1,180 @@
+package fakegcp
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+)
+
+// serveSecretManager serves the paths under /secretmanager/v1/. Secrets and
+// versions are named with the number of their project, as the real API does.
+func (s *Server) serveSecretManager(w http.ResponseWriter, r *http.Request, segments []string) {
+	segments, verb := splitVerb(segments)
+	if len(segments) < 3 || segments[0] != "projects" || segments[2] != "secrets" {
+		writeUnimplemented(w, r)
+		return
+	}
+	project := s.projectID(segments[1])
+	number := strconv.FormatInt(s.projectNumber(project), 10)
+
+	if len(segments) == 3 {
+		switch {
+		case r.Method == http.MethodGet && verb == "":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"secrets": s.list(fmt.Sprintf("secretmanager/projects/%s/secrets/", project))})
+		case r.Method == http.MethodPost && verb == "":
+			s.insertSecret(w, r, project, number)
+		default:
+			writeUnimplemented(w, r)
+		}
+		return
+	}
+
+	secretPath := fmt.Sprintf("projects/%s/secrets/%s", project, segments[3])
+	secret, ok := s.resources["secretmanager/"+secretPath]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "notFound", fmt.Sprintf("Secret [projects/%s/secrets/%s] not found.", number, segments[3]))
+		return
+	}
+
+	switch {
+	case len(segments) == 4 && verb == "":
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, secret)
+		case http.MethodPatch:
+			update, err := readJSON(r)
+			if err != nil {
+				writeBadRequest(w, err.Error())
+				return
+			}
+			if r.URL.Query().Get("updateMask") == "" {
+				writeBadRequest(w, "update_mask must be specified.")
+				return
+			}
+			applyUpdateMask(secret, update, r.URL.Query().Get("updateMask"))
+			secret["etag"] = strconv.Quote(s.etag())
+			writeJSON(w, http.StatusOK, secret)
+		case http.MethodDelete:
+			for _, path := range s.paths("secretmanager/" + secretPath + "/") {
+				delete(s.resources, path)
+			}
+			delete(s.resources, "secretmanager/"+secretPath)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		default:
+			writeUnimplemented(w, r)
+		}
+	case len(segments) == 4 && verb == "addVersion" && r.Method == http.MethodPost:
+		s.addSecretVersion(w, r, secretPath, secret)
+	case len(segments) == 5 && segments[4] == "versions" && verb == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"versions": s.list("secretmanager/" + secretPath + "/versions/")})
+	case len(segments) == 6 && segments[4] == "versions":
+		s.serveSecretVersion(w, r, secretPath, segments[5], verb)
+	default:
+		writeUnimplemented(w, r)
+	}
+}
+
+func (s *Server) insertSecret(w http.ResponseWriter, r *http.Request, project, number string) {
+	secretID := r.URL.Query().Get("secretId")
+	if secretID == "" {
+		writeBadRequest(w, "secret_id must be specified.")
+		return
+	}
+	key := fmt.Sprintf("secretmanager/projects/%s/secrets/%s", project, secretID)
+	name := fmt.Sprintf("projects/%s/secrets/%s", number, secretID)
+	if _, ok := s.resources[key]; ok {
+		writeError(w, http.StatusConflict, "ALREADY_EXISTS", "alreadyExists", fmt.Sprintf("Secret [%s] already exists.", name))
+		return
+	}
+	obj, err := readJSON(r)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	if _, ok := obj["replication"]; !ok {
+		writeBadRequest(w, "replication must be specified.")
+		return
+	}
+	obj["name"] = name
+	obj["createTime"] = timestamp()
+	obj["etag"] = strconv.Quote(s.etag())
+	s.resources[key] = obj
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (s *Server) addSecretVersion(w http.ResponseWriter, r *http.Request, secretPath string, secret map[string]interface{}) {
+	body, err := readJSON(r)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	payload, _ := body["payload"].(map[string]interface{})
+	data, _ := payload["data"].(string)
+	if _, err := base64.StdEncoding.DecodeString(data); err != nil || data == "" {
+		writeBadRequest(w, "payload.data must be set to base64 encoded bytes.")
+		return
+	}
+
+	n := len(s.list("secretmanager/"+secretPath+"/versions/")) + 1
+	version := map[string]interface{}{
+		"name":       fmt.Sprintf("%s/versions/%d", secret["name"], n),
+		"createTime": timestamp(),
+		"state":      "ENABLED",
+		"etag":       strconv.Quote(s.etag()),
+	}
+	key := fmt.Sprintf("secretmanager/%s/versions/%d", secretPath, n)
+	s.resources[key] = version
+	s.objectData[key] = []byte(data)
+	writeJSON(w, http.StatusOK, version)
+}
+
+func (s *Server) serveSecretVersion(w http.ResponseWriter, r *http.Request, secretPath, version, verb string) {
+	if version == "latest" {
+		version = strconv.Itoa(len(s.list("secretmanager/" + secretPath + "/versions/")))
+	}
+	key := fmt.Sprintf("secretmanager/%s/versions/%s", secretPath, version)
+	obj, ok := s.resources[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "notFound", fmt.Sprintf("Secret Version [%s/versions/%s] not found.", secretPath, version))
+		return
+	}
+
+	switch {
+	case verb == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, obj)
+	case verb == "access" && r.Method == http.MethodGet:
+		if obj["state"] != "ENABLED" {
+			writeError(w, http.StatusBadRequest, "FAILED_PRECONDITION", "failedPrecondition", fmt.Sprintf("Secret Version [%s] is in %s state.", obj["name"], obj["state"]))
+			return
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(string(s.objectData[key]))
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"name": obj["name"],
+			"payload": map[string]interface{}{
+				"data":       string(s.objectData[key]),
+				"dataCrc32c": strconv.FormatUint(uint64(crc32.Checksum(decoded, crc32cTable)), 10),
+			},
+		})
+	case r.Method == http.MethodPost && (verb == "enable" || verb == "disable" || verb == "destroy"):
+		if obj["state"] == "DESTROYED" {
+			writeError(w, http.StatusBadRequest, "FAILED_PRECONDITION", "failedPrecondition", fmt.Sprintf("Secret Version [%s] is in DESTROYED state.", obj["name"]))
+			return
+		}
+		switch verb {
+		case "enable":
+			obj["state"] = "ENABLED"
+		case "disable":
+			obj["state"] = "DISABLED"
+		case "destroy":
+			obj["state"] = "DESTROYED"
+			obj["destroyTime"] = timestamp()
+			delete(s.objectData, key)
+		}
+		obj["etag"] = strconv.Quote(s.etag())
+		writeJSON(w, http.StatusOK, obj)
+	default:
+		writeUnimplemented(w, r)
+	}
+}
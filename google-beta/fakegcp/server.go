@@ -0,0 +1,371 @@
+// Package fakegcp implements an in-process fake of a core set of Google Cloud
+// APIs, so that provider tests can run without network access or credentials.
+//
+// The fake keeps the resources it is sent in memory, and implements the
+// semantics the provider relies on: resources are created, read, updated and
+// deleted as they are by the real APIs, missing and conflicting resources are
+// reported with the errors the real APIs return, and Compute mutations return
+// long-running operations to poll. The APIs implemented are:
+//
+//   - Compute Engine networks, subnetworks and firewalls, with their global
+//     and regional operations.
+//   - Cloud Storage buckets and objects, including resumable uploads and
+//     composition.
+//   - Pub/Sub topics and subscriptions.
+//   - Secret Manager secrets and secret versions.
+//   - Resource Manager projects and their IAM policies.
+//
+// Point a provider at the fake with the base paths returned by BasePaths, which
+// are keyed by the provider's `*_custom_endpoint` settings. The provider's
+// TestFakeGcp* tests do so through resource.UnitTest, and need a Terraform CLI
+// on the PATH or in TF_ACC_TERRAFORM_PATH.
+package fakegcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is a fake Google Cloud API server listening on a local address.
+type Server struct {
+	// URL is the base URL of the server, of the form http://127.0.0.1:1234.
+	URL string
+
+	server *httptest.Server
+
+	mu sync.Mutex
+	// resources are keyed by the path of the resource relative to the API
+	// root, prefixed by the API name, e.g. "compute/projects/p/global/networks/n".
+	resources map[string]map[string]interface{}
+	// objectData holds the contents of Storage objects, keyed as resources.
+	objectData map[string][]byte
+	// uploads are the resumable Storage uploads in progress, keyed by ID.
+	uploads map[string]*resumableUpload
+	// failUploadChunks is the number of upcoming resumable upload chunks to
+	// fail, and uploadedChunks the number of chunks stored.
+	failUploadChunks int
+	uploadedChunks   int
+	// projects maps the IDs of the projects seen by the server to their
+	// numbers, which are assigned on first use.
+	projects map[string]int64
+	nextID   int64
+}
+
+// NewServer starts a fake server. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		resources:  map[string]map[string]interface{}{},
+		objectData: map[string][]byte{},
+		uploads:    map[string]*resumableUpload{},
+		projects:   map[string]int64{},
+		nextID:     1000,
+	}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// BasePaths returns the base paths of the APIs implemented by the server,
+// keyed by the name of the provider setting overriding them.
+func (s *Server) BasePaths() map[string]string {
+	return map[string]string{
+		"compute_custom_endpoint":          s.URL + "/compute/beta/",
+		"pubsub_custom_endpoint":           s.URL + "/pubsub/v1/",
+		"resource_manager_custom_endpoint": s.URL + "/cloudresourcemanager/v1/",
+		"secret_manager_custom_endpoint":   s.URL + "/secretmanager/v1/",
+		"storage_custom_endpoint":          s.URL + "/storage/v1/",
+	}
+}
+
+// Resource returns a copy of the resource stored at path, a path relative to
+// the API root prefixed by the API name, such as
+// "compute/projects/p/global/networks/n" or "storage/b/bucket".
+func (s *Server) Resource(path string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.resources[path]
+	if !ok {
+		return nil, false
+	}
+	return copyJSON(r), true
+}
+
+// Resources returns the paths of the resources stored with the given prefix,
+// sorted.
+func (s *Server) Resources(prefix string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paths(prefix)
+}
+
+func (s *Server) paths(prefix string) []string {
+	var paths []string
+	for path := range s.resources {
+		if strings.HasPrefix(path, prefix) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments, err := pathSegments(r.URL)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "invalid", err.Error())
+		return
+	}
+
+	switch {
+	case hasPrefix(segments, "compute", "v1"), hasPrefix(segments, "compute", "beta"):
+		s.serveCompute(w, r, segments[1], segments[2:])
+	case hasPrefix(segments, "storage", "v1"):
+		s.serveStorage(w, r, segments[2:])
+	case hasPrefix(segments, "upload", "storage", "v1"):
+		s.serveStorageUpload(w, r, segments[3:])
+	case hasPrefix(segments, "download", "storage", "v1"):
+		s.serveStorage(w, r, segments[3:])
+	case hasPrefix(segments, "pubsub", "v1"):
+		s.servePubsub(w, r, segments[2:])
+	case hasPrefix(segments, "secretmanager", "v1"):
+		s.serveSecretManager(w, r, segments[2:])
+	case hasPrefix(segments, "cloudresourcemanager", "v1"):
+		s.serveResourceManager(w, r, segments[2:])
+	default:
+		writeUnimplemented(w, r)
+	}
+}
+
+// pathSegments splits the path of u, unescaping each segment separately so
+// that names containing escaped slashes, like Storage object names, are kept
+// whole.
+func pathSegments(u *url.URL) ([]string, error) {
+	var segments []string
+	for _, escaped := range strings.Split(strings.Trim(u.EscapedPath(), "/"), "/") {
+		segment, err := url.PathUnescape(escaped)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+func hasPrefix(segments []string, prefix ...string) bool {
+	if len(segments) < len(prefix) {
+		return false
+	}
+	for i, p := range prefix {
+		if segments[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+// splitVerb splits the custom method off the last segment of a path, as in
+// "projects/p:getIamPolicy".
+func splitVerb(segments []string) ([]string, string) {
+	if len(segments) == 0 {
+		return segments, ""
+	}
+	last := segments[len(segments)-1]
+	i := strings.LastIndex(last, ":")
+	if i < 0 {
+		return segments, ""
+	}
+	return append(append([]string{}, segments[:len(segments)-1]...), last[:i]), last[i+1:]
+}
+
+// projectNumber returns the number of a project, assigning one on first use.
+func (s *Server) projectNumber(project string) int64 {
+	if n, err := strconv.ParseInt(project, 10, 64); err == nil {
+		return n
+	}
+	n, ok := s.projects[project]
+	if !ok {
+		n = 100000000000 + int64(len(s.projects)) + 1
+		s.projects[project] = n
+	}
+	return n
+}
+
+// projectID returns the ID of a project given its ID or number.
+func (s *Server) projectID(project string) string {
+	n, err := strconv.ParseInt(project, 10, 64)
+	if err != nil {
+		return project
+	}
+	for id, number := range s.projects {
+		if number == n {
+			return id
+		}
+	}
+	return project
+}
+
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) etag() string {
+	return fmt.Sprintf("BwX%d", s.newID())
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func readJSON(r *http.Request) (map[string]interface{}, error) {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if len(strings.TrimSpace(string(b))) == 0 {
+		return obj, nil
+	}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, fmt.Errorf("invalid JSON payload received: %s", err)
+	}
+	return obj, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the format of the Google APIs, which
+// googleapi.CheckResponse parses.
+func writeError(w http.ResponseWriter, code int, status, reason, message string) {
+	writeJSON(w, code, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+			"status":  status,
+			"errors": []interface{}{
+				map[string]interface{}{
+					"message": message,
+					"reason":  reason,
+					"domain":  "global",
+				},
+			},
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, resource string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "notFound", fmt.Sprintf("The resource '%s' was not found", resource))
+}
+
+func writeAlreadyExists(w http.ResponseWriter, resource string) {
+	writeError(w, http.StatusConflict, "ALREADY_EXISTS", "alreadyExists", fmt.Sprintf("The resource '%s' already exists", resource))
+}
+
+func writeBadRequest(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "invalid", message)
+}
+
+func writeUnimplemented(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotImplemented, "UNIMPLEMENTED", "notImplemented", fmt.Sprintf("fakegcp doesn't implement %s %s", r.Method, r.URL.Path))
+}
+
+func copyJSON(v map[string]interface{}) map[string]interface{} {
+	b, _ := json.Marshal(v)
+	c := map[string]interface{}{}
+	json.Unmarshal(b, &c)
+	return c
+}
+
+// mergeFields sets the fields of src on dst. Null fields are removed.
+func mergeFields(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if v == nil {
+			delete(dst, k)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// applyUpdateMask sets the fields of src listed in the comma-separated
+// updateMask on dst, removing those src doesn't set. Fields are camelCase or
+// snake_case, and nested fields are dot-separated. An empty mask sets all the
+// fields of src.
+func applyUpdateMask(dst, src map[string]interface{}, updateMask string) {
+	if updateMask == "" {
+		mergeFields(dst, src)
+		return
+	}
+	for _, path := range strings.Split(updateMask, ",") {
+		parts := strings.Split(strings.TrimSpace(path), ".")
+		for i, p := range parts {
+			parts[i] = camelCase(p)
+		}
+		d, sv := dst, interface{}(src)
+		for i, p := range parts {
+			m, _ := sv.(map[string]interface{})
+			sv = m[p]
+			if i == len(parts)-1 {
+				if sv == nil {
+					delete(d, p)
+				} else {
+					d[p] = sv
+				}
+				break
+			}
+			next, ok := d[p].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				d[p] = next
+			}
+			d = next
+		}
+	}
+}
+
+func camelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// list returns the resources stored directly under prefix, sorted by path.
+func (s *Server) list(prefix string) []interface{} {
+	var paths []string
+	for path := range s.resources {
+		if strings.HasPrefix(path, prefix) && !strings.Contains(strings.TrimPrefix(path, prefix), "/") {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	items := make([]interface{}, 0, len(paths))
+	for _, path := range paths {
+		items = append(items, s.resources[path])
+	}
+	return items
+}
@@ -0,0 +1,501 @@
+package fakegcp
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// serveStorage serves the paths under /storage/v1/.
+func (s *Server) serveStorage(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) == 0 || segments[0] != "b" {
+		writeUnimplemented(w, r)
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		project := s.projectID(r.URL.Query().Get("project"))
+		var items []interface{}
+		for _, b := range s.list("storage/b/") {
+			if b.(map[string]interface{})["projectNumber"] == strconv.FormatInt(s.projectNumber(project), 10) {
+				items = append(items, b)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"kind": "storage#buckets", "items": items})
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		s.insertStorageBucket(w, r)
+	case len(segments) == 2:
+		s.serveStorageBucket(w, r, segments[1])
+	case len(segments) >= 3 && segments[2] == "o":
+		bucket := segments[1]
+		if _, ok := s.resources["storage/b/"+bucket]; !ok {
+			writeNotFound(w, "b/"+bucket)
+			return
+		}
+		if len(segments) == 3 && r.Method == http.MethodGet {
+			s.listStorageObjects(w, r, bucket)
+			return
+		}
+		if len(segments) == 4 {
+			s.serveStorageObject(w, r, bucket, segments[3])
+			return
+		}
+		if len(segments) == 5 && segments[4] == "compose" && r.Method == http.MethodPost {
+			s.composeStorageObject(w, r, bucket, segments[3])
+			return
+		}
+		writeUnimplemented(w, r)
+	default:
+		writeUnimplemented(w, r)
+	}
+}
+
+func (s *Server) insertStorageBucket(w http.ResponseWriter, r *http.Request) {
+	project := r.URL.Query().Get("project")
+	if project == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "required", "Required parameter: project")
+		return
+	}
+	obj, err := readJSON(r)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	name, _ := obj["name"].(string)
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "required", "Required: name")
+		return
+	}
+	if _, ok := s.resources["storage/b/"+name]; ok {
+		writeError(w, http.StatusConflict, "ALREADY_EXISTS", "conflict", "Your previous request to create the named bucket succeeded and you already own it.")
+		return
+	}
+
+	location, _ := obj["location"].(string)
+	if location == "" {
+		location = "US"
+	}
+	location = strings.ToUpper(location)
+	locationType := "region"
+	switch location {
+	case "US", "EU", "ASIA":
+		locationType = "multi-region"
+	case "NAM4", "EUR4", "ASIA1":
+		locationType = "dual-region"
+	}
+	if _, ok := obj["storageClass"]; !ok {
+		obj["storageClass"] = "STANDARD"
+	}
+	if _, ok := obj["iamConfiguration"]; !ok {
+		obj["iamConfiguration"] = map[string]interface{}{
+			"bucketPolicyOnly":         map[string]interface{}{"enabled": false},
+			"uniformBucketLevelAccess": map[string]interface{}{"enabled": false},
+			"publicAccessPrevention":   "inherited",
+		}
+	}
+
+	now := timestamp()
+	obj["kind"] = "storage#bucket"
+	obj["id"] = name
+	obj["selfLink"] = s.URL + "/storage/v1/b/" + name
+	obj["projectNumber"] = strconv.FormatInt(s.projectNumber(s.projectID(project)), 10)
+	obj["location"] = location
+	obj["locationType"] = locationType
+	obj["metageneration"] = "1"
+	obj["etag"] = s.etag()
+	obj["timeCreated"] = now
+	obj["updated"] = now
+	s.resources["storage/b/"+name] = obj
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (s *Server) serveStorageBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	key := "storage/b/" + bucket
+	obj, ok := s.resources[key]
+	if !ok {
+		writeNotFound(w, "b/"+bucket)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPatch, http.MethodPut:
+		update, err := readJSON(r)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		for _, k := range []string{"kind", "id", "name", "selfLink", "projectNumber", "location", "locationType", "metageneration", "etag", "timeCreated", "updated"} {
+			delete(update, k)
+		}
+		mergeFields(obj, update)
+		metageneration, _ := strconv.ParseInt(obj["metageneration"].(string), 10, 64)
+		obj["metageneration"] = strconv.FormatInt(metageneration+1, 10)
+		obj["etag"] = s.etag()
+		obj["updated"] = timestamp()
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		if len(s.list(key+"/o/")) > 0 {
+			writeError(w, http.StatusConflict, "FAILED_PRECONDITION", "conflict", "The bucket you tried to delete is not empty.")
+			return
+		}
+		delete(s.resources, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeUnimplemented(w, r)
+	}
+}
+
+func (s *Server) listStorageObjects(w http.ResponseWriter, r *http.Request, bucket string) {
+	prefix := r.URL.Query().Get("prefix")
+	var items []interface{}
+	for _, o := range s.list("storage/b/" + bucket + "/o/") {
+		if strings.HasPrefix(o.(map[string]interface{})["name"].(string), prefix) {
+			items = append(items, o)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"kind": "storage#objects", "items": items})
+}
+
+func (s *Server) serveStorageObject(w http.ResponseWriter, r *http.Request, bucket, name string) {
+	key := storageObjectKey(bucket, name)
+	obj, ok := s.resources[key]
+	if !ok {
+		writeNotFound(w, "b/"+bucket+"/o/"+name)
+		return
+	}
+	if g := r.URL.Query().Get("generation"); g != "" && g != obj["generation"] {
+		writeNotFound(w, fmt.Sprintf("b/%s/o/%s#%s", bucket, name, g))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if r.URL.Query().Get("alt") == "media" {
+			w.Header().Set("Content-Type", obj["contentType"].(string))
+			w.Write(s.objectData[key])
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPatch, http.MethodPut:
+		update, err := readJSON(r)
+		if err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+		for _, k := range []string{"kind", "id", "name", "bucket", "selfLink", "mediaLink", "generation", "metageneration", "size", "md5Hash", "crc32c", "etag", "timeCreated", "updated"} {
+			delete(update, k)
+		}
+		mergeFields(obj, update)
+		metageneration, _ := strconv.ParseInt(obj["metageneration"].(string), 10, 64)
+		obj["metageneration"] = strconv.FormatInt(metageneration+1, 10)
+		obj["updated"] = timestamp()
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		delete(s.resources, key)
+		delete(s.objectData, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeUnimplemented(w, r)
+	}
+}
+
+// storageObjectKey escapes the object name, which may contain slashes, so
+// that objects are listed as direct children of their bucket.
+func storageObjectKey(bucket, name string) string {
+	return "storage/b/" + bucket + "/o/" + url.PathEscape(name)
+}
+
+// serveStorageUpload serves the paths under /upload/storage/v1/, uploading
+// objects with the media, multipart or resumable upload types.
+func (s *Server) serveStorageUpload(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 3 || segments[0] != "b" || segments[2] != "o" {
+		writeUnimplemented(w, r)
+		return
+	}
+	bucket := segments[1]
+	if _, ok := s.resources["storage/b/"+bucket]; !ok {
+		writeNotFound(w, "b/"+bucket)
+		return
+	}
+
+	// The client libraries send chunks with POST, as well as PUT.
+	if id := r.URL.Query().Get("upload_id"); id != "" && (r.Method == http.MethodPut || r.Method == http.MethodPost) {
+		s.uploadStorageChunk(w, r, id)
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeUnimplemented(w, r)
+		return
+	}
+
+	obj := map[string]interface{}{}
+	var data []byte
+	var err error
+	switch uploadType := r.URL.Query().Get("uploadType"); uploadType {
+	case "media":
+		obj["contentType"] = r.Header.Get("Content-Type")
+		data, err = ioutil.ReadAll(r.Body)
+	case "multipart":
+		obj, data, err = readMultipartUpload(r)
+	case "resumable":
+		s.startResumableUpload(w, r, bucket)
+		return
+	default:
+		writeBadRequest(w, fmt.Sprintf("fakegcp doesn't implement the %q upload type", uploadType))
+		return
+	}
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	if name := r.URL.Query().Get("name"); name != "" {
+		obj["name"] = name
+	}
+	s.insertStorageObject(w, bucket, obj, data)
+}
+
+// resumableUpload is a resumable upload session, holding the data received
+// so far.
+type resumableUpload struct {
+	bucket string
+	obj    map[string]interface{}
+	data   []byte
+}
+
+// FailUploadChunks makes the server fail the next n chunks of resumable
+// uploads with a 503 error, as flaky connections do.
+func (s *Server) FailUploadChunks(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failUploadChunks = n
+}
+
+// UploadedChunks returns the number of chunks of resumable uploads the server
+// has stored.
+func (s *Server) UploadedChunks() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.uploadedChunks
+}
+
+func (s *Server) startResumableUpload(w http.ResponseWriter, r *http.Request, bucket string) {
+	obj := map[string]interface{}{}
+	if r.ContentLength != 0 {
+		var err error
+		if obj, err = readJSON(r); err != nil {
+			writeBadRequest(w, err.Error())
+			return
+		}
+	}
+	if name := r.URL.Query().Get("name"); name != "" {
+		obj["name"] = name
+	}
+	if ct, _ := obj["contentType"].(string); ct == "" {
+		obj["contentType"] = r.Header.Get("X-Upload-Content-Type")
+	}
+
+	id := strconv.FormatInt(s.newID(), 10)
+	s.uploads[id] = &resumableUpload{bucket: bucket, obj: obj}
+	w.Header().Set("Location", fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=resumable&upload_id=%s", s.URL, url.PathEscape(bucket), id))
+	w.WriteHeader(http.StatusOK)
+}
+
+// uploadStorageChunk stores a chunk of a resumable upload, whose range is
+// given by the Content-Range header, such as "bytes 0-99/*" for a chunk of
+// an upload of unknown size or "bytes 100-149/150" for the last chunk.
+func (s *Server) uploadStorageChunk(w http.ResponseWriter, r *http.Request, id string) {
+	upload, ok := s.uploads[id]
+	if !ok {
+		writeNotFound(w, "upload "+id)
+		return
+	}
+	chunk, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	if s.failUploadChunks > 0 {
+		s.failUploadChunks--
+		writeError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "backendError", "Backend Error")
+		return
+	}
+
+	var start, end int64
+	var total string
+	contentRange := r.Header.Get("Content-Range")
+	if strings.HasPrefix(contentRange, "bytes */") {
+		// A query of the upload status, or a final empty chunk.
+		start, end, total = int64(len(upload.data)), int64(len(upload.data))-1, strings.TrimPrefix(contentRange, "bytes */")
+	} else if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%s", &start, &end, &total); err != nil {
+		writeBadRequest(w, fmt.Sprintf("invalid Content-Range %q", contentRange))
+		return
+	}
+	if start != int64(len(upload.data)) || end-start+1 != int64(len(chunk)) {
+		writeBadRequest(w, fmt.Sprintf("Content-Range %q doesn't follow the %d bytes received", contentRange, len(upload.data)))
+		return
+	}
+	upload.data = append(upload.data, chunk...)
+	if len(chunk) > 0 {
+		s.uploadedChunks++
+	}
+
+	if total == "*" || total != strconv.Itoa(len(upload.data)) {
+		if len(upload.data) > 0 {
+			w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(upload.data)-1))
+		}
+		// Clients sending X-GUploader-No-308 get a 200 with the status in a
+		// header instead, as a 308 is otherwise handled as a redirect.
+		if r.Header.Get("X-GUploader-No-308") == "yes" {
+			w.Header().Set("X-Http-Status-Code-Override", "308")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusPermanentRedirect)
+		return
+	}
+
+	delete(s.uploads, id)
+	s.insertStorageObject(w, upload.bucket, upload.obj, upload.data)
+}
+
+// composeStorageObject concatenates the source objects of a compose request
+// into the object name.
+func (s *Server) composeStorageObject(w http.ResponseWriter, r *http.Request, bucket, name string) {
+	req, err := readJSON(r)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+	sources, _ := req["sourceObjects"].([]interface{})
+	if len(sources) == 0 || len(sources) > 32 {
+		writeBadRequest(w, "The number of source components provided must be between 1 and 32")
+		return
+	}
+
+	var data []byte
+	for _, source := range sources {
+		sourceName, _ := source.(map[string]interface{})["name"].(string)
+		key := storageObjectKey(bucket, sourceName)
+		if _, ok := s.resources[key]; !ok {
+			writeNotFound(w, "b/"+bucket+"/o/"+sourceName)
+			return
+		}
+		data = append(data, s.objectData[key]...)
+	}
+
+	obj, _ := req["destination"].(map[string]interface{})
+	if obj == nil {
+		obj = map[string]interface{}{}
+	}
+	obj["name"] = name
+	obj["componentCount"] = len(sources)
+	s.insertStorageObject(w, bucket, obj, data)
+}
+
+// insertStorageObject stores the object described by obj with the contents
+// data, checking them against the checksums in obj if any.
+func (s *Server) insertStorageObject(w http.ResponseWriter, bucket string, obj map[string]interface{}, data []byte) {
+	b := s.resources["storage/b/"+bucket]
+	name, _ := obj["name"].(string)
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "required", "Required: name")
+		return
+	}
+	if ct, _ := obj["contentType"].(string); ct == "" {
+		obj["contentType"] = "application/octet-stream"
+	}
+	if _, ok := obj["storageClass"]; !ok {
+		obj["storageClass"] = b["storageClass"]
+	}
+
+	md5Sum := md5.Sum(data)
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.Checksum(data, crc32cTable))
+	md5Hash := base64.StdEncoding.EncodeToString(md5Sum[:])
+	crc32c := base64.StdEncoding.EncodeToString(crc)
+	if v, ok := obj["crc32c"].(string); ok && v != crc32c {
+		writeBadRequest(w, fmt.Sprintf("Provided CRC32C %q doesn't match calculated CRC32C %q.", v, crc32c))
+		return
+	}
+	if v, ok := obj["md5Hash"].(string); ok && v != md5Hash {
+		writeBadRequest(w, fmt.Sprintf("Provided MD5 hash %q doesn't match calculated MD5 hash %q.", v, md5Hash))
+		return
+	}
+
+	generation := strconv.FormatInt(s.newID(), 10)
+	now := timestamp()
+	escaped := url.PathEscape(name)
+	obj["kind"] = "storage#object"
+	obj["id"] = fmt.Sprintf("%s/%s/%s", bucket, name, generation)
+	obj["bucket"] = bucket
+	obj["selfLink"] = fmt.Sprintf("%s/storage/v1/b/%s/o/%s", s.URL, bucket, escaped)
+	obj["mediaLink"] = fmt.Sprintf("%s/download/storage/v1/b/%s/o/%s?generation=%s&alt=media", s.URL, bucket, escaped, generation)
+	obj["generation"] = generation
+	obj["metageneration"] = "1"
+	obj["size"] = strconv.Itoa(len(data))
+	// Composite objects have no MD5 hash.
+	if _, ok := obj["componentCount"]; ok {
+		delete(obj, "md5Hash")
+	} else {
+		obj["md5Hash"] = md5Hash
+	}
+	obj["crc32c"] = crc32c
+	obj["etag"] = s.etag()
+	obj["timeCreated"] = now
+	obj["updated"] = now
+
+	key := storageObjectKey(bucket, name)
+	s.resources[key] = obj
+	s.objectData[key] = data
+	writeJSON(w, http.StatusOK, obj)
+}
+
+// readMultipartUpload reads the metadata and the contents of a multipart
+// upload.
+func readMultipartUpload(r *http.Request) (map[string]interface{}, []byte, error) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, nil, err
+	}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+
+	part, err := mr.NextPart()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading the metadata part: %s", err)
+	}
+	obj, err := readJSON(&http.Request{Body: part})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	part, err = mr.NextPart()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading the media part: %s", err)
+	}
+	data, err := ioutil.ReadAll(part)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ct, _ := obj["contentType"].(string); ct == "" {
+		obj["contentType"] = part.Header.Get("Content-Type")
+	}
+	if _, err := mr.NextPart(); err != io.EOF {
+		return nil, nil, fmt.Errorf("expected 2 parts in a multipart upload")
+	}
+	return obj, data, nil
+}
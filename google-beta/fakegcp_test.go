@@ -0,0 +1,152 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/fakegcp"
+)
+
+const fakeGcpProject = "fake-project"
+
+// newFakeGcpServer returns a new fake server, closed when the test ends.
+func newFakeGcpServer(t *testing.T) *fakegcp.Server {
+	srv := fakegcp.NewServer()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newFakeGcpProvider returns a provider configured to send the requests of the
+// APIs fakegcp implements to a new fake server, through their custom
+// endpoints, for tests calling the provider's code directly.
+func newFakeGcpProvider(t *testing.T) (*schema.Provider, *fakegcp.Server) {
+	t.Helper()
+	srv := newFakeGcpServer(t)
+
+	raw := map[string]interface{}{
+		"project":      fakeGcpProject,
+		"region":       "us-central1",
+		"access_token": "fake-token",
+		"polling": []interface{}{
+			map[string]interface{}{
+				"initial_interval": "10ms",
+				"max_interval":     "10ms",
+			},
+		},
+		"batching": []interface{}{
+			map[string]interface{}{
+				"enable_batching": false,
+			},
+		},
+		"iam_policy_verification": IamPolicyVerificationOff,
+	}
+	for k, v := range srv.BasePaths() {
+		raw[k] = v
+	}
+
+	p := Provider()
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Fatalf("error configuring the provider: %v", diags)
+	}
+	return p, srv
+}
+
+// fakeGcpTest runs a test case with the Terraform CLI against srv, prefixing
+// the configuration of each step with a provider block sending the requests of
+// the APIs fakegcp implements to srv, through their custom endpoints. Unlike
+// acceptance tests it doesn't need TF_ACC or credentials, but it's skipped
+// when no Terraform CLI can be found.
+func fakeGcpTest(t *testing.T, srv *fakegcp.Server, c resource.TestCase) {
+	t.Helper()
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" && os.Getenv("TF_ACC_TERRAFORM_VERSION") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("Terraform CLI not found, set TF_ACC_TERRAFORM_PATH to run fake GCP tests")
+		}
+	}
+
+	c.ProtoV5ProviderFactories = ProtoV5ProviderFactories(t)
+	for i := range c.Steps {
+		if c.Steps[i].Config != "" {
+			c.Steps[i].Config = fakeGcpProviderConfig(srv) + c.Steps[i].Config
+		}
+	}
+	resource.UnitTest(t, c)
+}
+
+// fakeGcpProviderConfig returns the provider block used by fakeGcpTest.
+func fakeGcpProviderConfig(srv *fakegcp.Server) string {
+	var endpoints []string
+	for k, v := range srv.BasePaths() {
+		endpoints = append(endpoints, fmt.Sprintf("  %s = %q\n", k, v))
+	}
+	sort.Strings(endpoints)
+
+	return fmt.Sprintf(`
+provider "google" {
+  project                 = %q
+  region                  = "us-central1"
+  access_token            = "fake-token"
+  iam_policy_verification = %q
+
+%s
+  polling {
+    initial_interval = "10ms"
+    max_interval     = "10ms"
+  }
+
+  batching {
+    enable_batching = false
+  }
+}
+`, fakeGcpProject, IamPolicyVerificationOff, strings.Join(endpoints, ""))
+}
+
+// fakeGcpCheckResource checks the resource stored by srv at path, a path as
+// accepted by fakegcp.Server.Resource.
+func fakeGcpCheckResource(srv *fakegcp.Server, path string, check func(map[string]interface{}) error) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		r, ok := srv.Resource(path)
+		if !ok {
+			return fmt.Errorf("%s not found", path)
+		}
+		return check(r)
+	}
+}
+
+// fakeGcpCheckNoResources checks that srv stores no resources under prefix,
+// as accepted by fakegcp.Server.Resources.
+func fakeGcpCheckNoResources(srv *fakegcp.Server, prefix string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if left := srv.Resources(prefix); len(left) != 0 {
+			return fmt.Errorf("expected no resources under %s, got %v", prefix, left)
+		}
+		return nil
+	}
+}
+
+// fakeGcpCheckContent checks the content served by srv at path, relative to
+// its URL.
+func fakeGcpCheckContent(srv *fakegcp.Server, path string, check func([]byte) error) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		res, err := http.Get(srv.URL + path)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+		return check(b)
+	}
+}
@@ -165,7 +165,7 @@ func (p *frameworkProvider) LoadAndValidateFramework(ctx context.Context, data P
 	p.context = ctx
 	p.region = data.Region
 	p.zone = data.Zone
-	p.pollInterval = 10 * time.Second
+	p.polling = GetPollingConfig(ctx, data.Polling, diags)
 	p.project = data.Project
 	p.requestBatcherServiceUsage = NewRequestBatcher("Service Usage", ctx, batchingConfig)
 	p.requestBatcherIam = NewRequestBatcher("IAM", ctx, batchingConfig)
@@ -1302,13 +1302,38 @@ func (p *frameworkProvider) SetupClient(ctx context.Context, data ProviderModel,
 	// 2. Logging Transport - ensure we log HTTP requests to GCP APIs.
 	loggingTransport := logging.NewTransport("Google", client.Transport)
 
-	// 3. Retry Transport - retries common temporary errors
+	// 3. Audit Log Transport - optionally writes an audit record for each request
+	// Keep order for wrapping retries so each retried request is audited as well.
+	auditLog := GetAuditLogConfig(ctx, data.AuditLog, diags)
+	if diags.HasError() {
+		return
+	}
+	auditLogTransport, err := newTransportWithAuditLog(loggingTransport, auditLog, p.identity)
+	if err != nil {
+		diags.AddError("error setting up audit log", err.Error())
+		return
+	}
+
+	// 4. Rate Limit Transport - optionally throttles requests per API host
+	// Keep order for wrapping retries so each retried request waits for a token as well.
+	rateLimits := GetRateLimits(ctx, data.RateLimit, diags)
+	if diags.HasError() {
+		return
+	}
+	p.rateLimiters = newRateLimiters(rateLimits)
+	rateLimitTransport := newTransportWithRateLimits(auditLogTransport, p.rateLimiters)
+
+	// 5. Retry Transport - retries common temporary errors
 	// Keep order for wrapping logging so we log each retried request as well.
 	// This value should be used if needed to create shallow copies with additional retry predicates.
 	// See ClientWithAdditionalRetries
-	retryTransport := NewTransportWithDefaultRetries(loggingTransport)
+	retryPolicy := GetRetryPolicy(ctx, data.Retry, diags)
+	if diags.HasError() {
+		return
+	}
+	retryTransport := NewTransportWithDefaultRetries(rateLimitTransport).WithPolicy(retryPolicy)
 
-	// 4. Header Transport - outer wrapper to inject additional headers we want to apply
+	// 6. Header Transport - outer wrapper to inject additional headers we want to apply
 	// before making requests
 	headerTransport := newTransportWithHeaders(retryTransport)
 	if !data.RequestReason.IsNull() {
@@ -1360,6 +1385,31 @@ func (p *frameworkProvider) logGoogleIdentities(ctx context.Context, data Provid
 	// a separate diagnostics here
 	var d diag.Diagnostics
 
+	if externalAccount := GetExternalAccountConfig(ctx, data.ExternalAccount, diags); externalAccount != nil {
+		// Federated tokens can't be used to fetch userinfo, so the federated
+		// principal is inferred from the subject token instead.
+		federated := externalAccount.identity(ctx)
+
+		tokenSource := GetTokenSource(ctx, data, false, diags)
+		if diags.HasError() {
+			return
+		}
+		p.client = oauth2.NewClient(ctx, tokenSource) // p.client isn't initialised fully when this code is called.
+
+		if data.ImpersonateServiceAccount.IsNull() {
+			tflog.Info(ctx, fmt.Sprintf("Terraform is using workload identity federation, federated identity: %s", federated))
+			p.identity = federated
+			return
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Terraform is configured with workload identity federation and service account impersonation, federated identity: %s, impersonated identity: %s", federated, data.ImpersonateServiceAccount.ValueString()))
+		p.identity = data.ImpersonateServiceAccount.ValueString()
+		return
+	}
+	if diags.HasError() {
+		return
+	}
+
 	if data.ImpersonateServiceAccount.IsNull() {
 
 		tokenSource := GetTokenSource(ctx, data, true, diags)
@@ -1375,6 +1425,7 @@ func (p *frameworkProvider) logGoogleIdentities(ctx context.Context, data Provid
 		}
 
 		tflog.Info(ctx, fmt.Sprintf("Terraform is using this identity: %s", email))
+		p.identity = email
 		return
 	}
 
@@ -1391,6 +1442,7 @@ func (p *frameworkProvider) logGoogleIdentities(ctx context.Context, data Provid
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Terraform is configured with service account impersonation, original identity: %s, impersonated identity: %s", email, data.ImpersonateServiceAccount.ValueString()))
+	p.identity = data.ImpersonateServiceAccount.ValueString()
 
 	// Add the Impersonated ClientOption back in to the OAuth2 TokenSource
 	tokenSource = GetTokenSource(ctx, data, false, diags)
@@ -1432,6 +1484,28 @@ func GetCredentials(ctx context.Context, data ProviderModel, initialCredentialsO
 		return googleoauth.Credentials{}
 	}
 
+	if externalAccount := GetExternalAccountConfig(ctx, data.ExternalAccount, diags); externalAccount != nil {
+		tokenSource := NewExternalAccountTokenSource(externalAccount, clientScopes)
+		if !data.ImpersonateServiceAccount.IsNull() && !initialCredentialsOnly {
+			opts := []option.ClientOption{option.WithTokenSource(tokenSource), option.ImpersonateCredentials(data.ImpersonateServiceAccount.ValueString(), delegates...), option.WithScopes(clientScopes...)}
+			creds, err := transport.Creds(context.TODO(), opts...)
+			if err != nil {
+				diags.AddError("error impersonating credentials", err.Error())
+				return googleoauth.Credentials{}
+			}
+			return *creds
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Authenticating using workload identity federation with a subject token from %s...", externalAccount.subjectTokenSource()))
+		tflog.Info(ctx, fmt.Sprintf("  -- Scopes: %s", clientScopes))
+		return googleoauth.Credentials{
+			TokenSource: tokenSource,
+		}
+	}
+	if diags.HasError() {
+		return googleoauth.Credentials{}
+	}
+
 	if !data.AccessToken.IsNull() {
 		contents, _, err := pathOrContents(data.AccessToken.ValueString())
 		if err != nil {
@@ -1510,6 +1584,49 @@ func GetCredentials(ctx context.Context, data ProviderModel, initialCredentialsO
 	}
 }
 
+// GetExternalAccountConfig returns the workload identity federation
+// configuration given the provider configuration set for external_account
+func GetExternalAccountConfig(ctx context.Context, data types.List, diags *diag.Diagnostics) *ExternalAccountConfig {
+	if data.IsNull() {
+		return nil
+	}
+
+	var eaConfigs []ProviderExternalAccount
+	d := data.ElementsAs(ctx, &eaConfigs, true)
+	diags.Append(d...)
+	if diags.HasError() || len(eaConfigs) == 0 {
+		return nil
+	}
+	ea := eaConfigs[0]
+
+	cfg := &ExternalAccountConfig{
+		Audience:           ea.Audience.ValueString(),
+		SubjectTokenType:   ea.SubjectTokenType.ValueString(),
+		TokenURL:           ea.TokenURL.ValueString(),
+		SubjectTokenFile:   ea.SubjectTokenFile.ValueString(),
+		SubjectTokenURL:    ea.SubjectTokenURL.ValueString(),
+		SubjectTokenFormat: ea.SubjectTokenFormat.ValueString(),
+		SubjectTokenField:  ea.SubjectTokenField.ValueString(),
+	}
+	if !ea.SubjectTokenHeaders.IsNull() {
+		d = ea.SubjectTokenHeaders.ElementsAs(ctx, &cfg.SubjectTokenURLHeaders, false)
+		diags.Append(d...)
+	}
+	if !ea.SubjectTokenCommand.IsNull() {
+		d = ea.SubjectTokenCommand.ElementsAs(ctx, &cfg.SubjectTokenCommand, false)
+		diags.Append(d...)
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	if err := cfg.validate(); err != nil {
+		diags.AddError("invalid external_account configuration", err.Error())
+		return nil
+	}
+	return cfg
+}
+
 // GetBatchingConfig returns the batching config object given the
 // provider configuration set for batching
 func GetBatchingConfig(ctx context.Context, data types.List, diags *diag.Diagnostics) *batchingConfig {
@@ -1541,5 +1658,162 @@ func GetBatchingConfig(ctx context.Context, data types.List, diags *diag.Diagnos
 		bc.EnableBatching = pbConfigs[0].EnableBatching.ValueBool()
 	}
 
+	if !pbConfigs[0].MaxBatchSize.IsNull() {
+		bc.MaxBatchSize = int(pbConfigs[0].MaxBatchSize.ValueInt64())
+	}
+
+	if !pbConfigs[0].MaxConcurrentPerKey.IsNull() {
+		bc.MaxConcurrentPerKey = int(pbConfigs[0].MaxConcurrentPerKey.ValueInt64())
+	}
+
 	return bc
 }
+
+// GetRetryPolicy returns the retry policy for the retry transport given the
+// provider configuration set for retry
+func GetRetryPolicy(ctx context.Context, data types.List, diags *diag.Diagnostics) *RetryPolicy {
+	policy := DefaultRetryPolicy()
+
+	if data.IsNull() {
+		return policy
+	}
+
+	var prConfigs []ProviderRetry
+	d := data.ElementsAs(ctx, &prConfigs, true)
+	diags.Append(d...)
+	if diags.HasError() || len(prConfigs) == 0 {
+		return policy
+	}
+	pr := prConfigs[0]
+
+	if !pr.MaxAttempts.IsNull() {
+		policy.MaxAttempts = int(pr.MaxAttempts.ValueInt64())
+	}
+
+	for _, v := range []struct {
+		name  string
+		value types.String
+		dest  *time.Duration
+	}{
+		{"initial_backoff", pr.InitialBackoff, &policy.InitialBackoff},
+		{"max_backoff", pr.MaxBackoff, &policy.MaxBackoff},
+		{"timeout", pr.Timeout, &policy.Timeout},
+	} {
+		if v.value.IsNull() {
+			continue
+		}
+		dur, err := time.ParseDuration(v.value.ValueString())
+		if err != nil {
+			diags.AddError(fmt.Sprintf("error parsing %s time duration", v.name), err.Error())
+			return policy
+		}
+		*v.dest = dur
+	}
+
+	if !pr.BackoffStrategy.IsNull() {
+		policy.Strategy = pr.BackoffStrategy.ValueString()
+	}
+
+	if !pr.Jitter.IsNull() {
+		policy.Jitter = pr.Jitter.ValueBool()
+	}
+
+	return policy
+}
+
+// GetRateLimits returns the per host rate limits given the provider
+// configuration set for rate_limit
+func GetRateLimits(ctx context.Context, data types.List, diags *diag.Diagnostics) []RateLimitConfig {
+	if data.IsNull() {
+		return nil
+	}
+
+	var prConfigs []ProviderRateLimit
+	d := data.ElementsAs(ctx, &prConfigs, true)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil
+	}
+
+	var rateLimits []RateLimitConfig
+	for _, pr := range prConfigs {
+		rateLimits = append(rateLimits, RateLimitConfig{
+			Host:              pr.Host.ValueString(),
+			RequestsPerSecond: pr.RequestsPerSecond.ValueFloat64(),
+			Burst:             int(pr.Burst.ValueInt64()),
+		})
+	}
+
+	return rateLimits
+}
+
+// GetPollingConfig returns the polling strategies given the provider
+// configuration set for polling
+func GetPollingConfig(ctx context.Context, data types.List, diags *diag.Diagnostics) PollingConfig {
+	var settings []PollingSettings
+	if !data.IsNull() {
+		var ppConfigs []ProviderPolling
+		d := data.ElementsAs(ctx, &ppConfigs, true)
+		diags.Append(d...)
+		if diags.HasError() {
+			return PollingConfig{Default: DefaultPollingStrategy()}
+		}
+
+		for _, pp := range ppConfigs {
+			ps := PollingSettings{
+				Service:    pp.Service.ValueString(),
+				Multiplier: pp.Multiplier.ValueFloat64(),
+			}
+			for _, v := range []struct {
+				name  string
+				value types.String
+				dest  *time.Duration
+			}{
+				{"initial_interval", pp.InitialInterval, &ps.InitialInterval},
+				{"max_interval", pp.MaxInterval, &ps.MaxInterval},
+			} {
+				if v.value.IsNull() {
+					continue
+				}
+				dur, err := time.ParseDuration(v.value.ValueString())
+				if err != nil {
+					diags.AddError(fmt.Sprintf("error parsing %s time duration", v.name), err.Error())
+					return PollingConfig{Default: DefaultPollingStrategy()}
+				}
+				*v.dest = dur
+			}
+			settings = append(settings, ps)
+		}
+	}
+
+	cfg, err := NewPollingConfig(settings)
+	if err != nil {
+		diags.AddError("error setting up polling", err.Error())
+	}
+	return cfg
+}
+
+// GetAuditLogConfig returns the audit log configuration given the provider
+// configuration set for audit_log
+func GetAuditLogConfig(ctx context.Context, data types.List, diags *diag.Diagnostics) *AuditLogConfig {
+	if data.IsNull() {
+		return nil
+	}
+
+	var alConfigs []ProviderAuditLog
+	d := data.ElementsAs(ctx, &alConfigs, true)
+	diags.Append(d...)
+	if diags.HasError() || len(alConfigs) == 0 {
+		return nil
+	}
+
+	cfg := &AuditLogConfig{
+		Path: alConfigs[0].Path.ValueString(),
+	}
+	if !alConfigs[0].RedactFields.IsNull() {
+		d = alConfigs[0].RedactFields.ElementsAs(ctx, &cfg.RedactFields, false)
+		diags.Append(d...)
+	}
+
+	return cfg
+}
@@ -3,8 +3,9 @@ package google
 import (
 	"context"
 	"net/http"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -42,7 +43,9 @@ type frameworkProvider struct {
 	client                     *http.Client
 	context                    context.Context
 	gRPCLoggingOptions         []option.ClientOption
-	pollInterval               time.Duration
+	identity                   string
+	polling                    PollingConfig
+	rateLimiters               *rateLimiters
 	project                    types.String
 	region                     types.String
 	zone                       types.String
@@ -169,6 +172,13 @@ func (p *frameworkProvider) MetaSchema(_ context.Context, _ provider.MetaSchemaR
 			"module_name": metaschema.StringAttribute{
 				Optional: true,
 			},
+			"impersonate_service_account": metaschema.StringAttribute{
+				Optional: true,
+			},
+			"impersonate_service_account_delegates": metaschema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -182,6 +192,7 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.Expressions{
 						path.MatchRoot("access_token"),
+						path.MatchRoot("external_account"),
 					}...),
 					CredentialsValidator(),
 				},
@@ -191,6 +202,7 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.Expressions{
 						path.MatchRoot("credentials"),
+						path.MatchRoot("external_account"),
 					}...),
 				},
 			},
@@ -226,6 +238,24 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			"request_reason": schema.StringAttribute{
 				Optional: true,
 			},
+			"iam_policy_verification": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						IamPolicyVerificationDelay,
+						IamPolicyVerificationOff,
+						IamPolicyVerificationEventual,
+						IamPolicyVerificationStrict,
+					),
+				},
+			},
+			"disruptive_updates_as_errors": schema.BoolAttribute{
+				Optional: true,
+			},
+			"default_labels": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 
 			// Generated Products
 			"access_approval_custom_endpoint": &schema.StringAttribute{
@@ -900,6 +930,44 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"external_account": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"audience": schema.StringAttribute{
+							Required: true,
+						},
+						"subject_token_type": schema.StringAttribute{
+							Optional: true,
+						},
+						"token_url": schema.StringAttribute{
+							Optional: true,
+						},
+						"subject_token_file": schema.StringAttribute{
+							Optional: true,
+						},
+						"subject_token_url": schema.StringAttribute{
+							Optional: true,
+						},
+						"subject_token_headers": schema.MapAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"subject_token_command": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"subject_token_format": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(ExternalAccountSubjectTokenFormatText, ExternalAccountSubjectTokenFormatJSON),
+							},
+						},
+						"subject_token_field": schema.StringAttribute{
+							Optional: true,
+						},
+					},
+				},
+			},
 			"batching": schema.ListNestedBlock{
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
@@ -912,6 +980,121 @@ func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 						"enable_batching": schema.BoolAttribute{
 							Optional: true,
 						},
+						"max_batch_size": schema.Int64Attribute{
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"max_concurrent_per_key": schema.Int64Attribute{
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+			"audit_log": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Required: true,
+						},
+						"redact_fields": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"rate_limit": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"host": schema.StringAttribute{
+							Required: true,
+						},
+						"requests_per_second": schema.Float64Attribute{
+							Required: true,
+							Validators: []validator.Float64{
+								float64validator.AtLeast(0),
+							},
+						},
+						"burst": schema.Int64Attribute{
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+			"polling": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"service": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(PollingServices...),
+							},
+						},
+						"initial_interval": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								NonNegativeDurationValidator(),
+							},
+						},
+						"max_interval": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								NonNegativeDurationValidator(),
+							},
+						},
+						"multiplier": schema.Float64Attribute{
+							Optional: true,
+							Validators: []validator.Float64{
+								float64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"retry": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"max_attempts": schema.Int64Attribute{
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"initial_backoff": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								DurationAtLeastValidator(minRetryInitialBackoff),
+							},
+						},
+						"max_backoff": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								NonNegativeDurationValidator(),
+							},
+						},
+						"backoff_strategy": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(RetryBackoffStrategyFibonacci, RetryBackoffStrategyExponential),
+							},
+						},
+						"jitter": schema.BoolAttribute{
+							Optional: true,
+						},
+						"timeout": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								DurationAtLeastValidator(minRetryTimeout),
+							},
+						},
 					},
 				},
 			},
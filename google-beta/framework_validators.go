@@ -91,3 +91,40 @@ func (v nonnegativedurationValidator) ValidateString(ctx context.Context, reques
 func NonNegativeDurationValidator() validator.String {
 	return nonnegativedurationValidator{}
 }
+
+// Duration At Least Validator
+type durationAtLeastValidator struct {
+	min time.Duration
+}
+
+// Description describes the validation in plain text formatting.
+func (v durationAtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value expected to be a string representing a duration of at least %v", v.min)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v durationAtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v durationAtLeastValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	dur, err := time.ParseDuration(value)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("expected %s to be a duration", value), err.Error())
+		return
+	}
+
+	if dur < v.min {
+		response.Diagnostics.AddError(fmt.Sprintf("duration must be at least %v", v.min), fmt.Sprintf("duration provided: %v", dur))
+	}
+}
+
+func DurationAtLeastValidator(min time.Duration) validator.String {
+	return durationAtLeastValidator{min: min}
+}
@@ -1,8 +1,16 @@
 package google
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"os"
+	"strconv"
 	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 // List of prefixes used for test resource names
@@ -20,6 +28,52 @@ var testResourcePrefixes = []string{
 	"k8s-fw-",             // firewall rules are getting created and not cleaned up by k8 resources using this prefix
 }
 
+// Environment variables configuring the sweepers.
+const (
+	// SWEEP_DRY_RUN, when true, makes sweepers log the resources they would
+	// delete instead of deleting them.
+	sweepDryRunEnvVar = "SWEEP_DRY_RUN"
+	// SWEEP_MIN_AGE is the age, as a duration such as "3h", that a resource
+	// must reach before it is swept. Resources younger than this may belong to
+	// a test that is still running.
+	sweepMinAgeEnvVar = "SWEEP_MIN_AGE"
+	// SWEEP_LABELS is a comma-separated list of labels, as key=value or key,
+	// marking resources to sweep whatever their name.
+	sweepLabelsEnvVar = "SWEEP_LABELS"
+)
+
+const defaultSweepMinAge = 3 * time.Hour
+
+// Fields holding the creation time of resources, in the formats used across
+// the APIs.
+var sweepCreationTimeFields = []string{"creationTimestamp", "createTime", "timeCreated"}
+
+// sweeperDependencies lists, by sweeper name, the sweepers that must run first
+// because the resources they delete hold references to the sweeper's
+// resources. A disk can't be deleted while an instance uses it, for instance.
+// Only hand-written sweepers read it: the sweepers generated by MMv1 register
+// themselves without dependencies.
+var sweeperDependencies = map[string][]string{
+	"ComputeDisk": {
+		"ComputeInstance",
+	},
+	"ComputeInstance": {
+		"ComputeInstanceGroupManager",
+		"ComputeRegionInstanceGroupManager",
+	},
+	"ComputeInstanceTemplate": {
+		"ComputeInstanceGroupManager",
+		"ComputeRegionInstanceGroupManager",
+	},
+}
+
+// How often, and for how long, a sweeper that others depend on polls for its
+// deletions to finish.
+var (
+	sweeperPollInterval  = 10 * time.Second
+	sweeperDeleteTimeout = 10 * time.Minute
+)
+
 // SharedConfigForRegion returns a common config setup needed for the sweeper
 // functions for a given region
 func SharedConfigForRegion(region string) (*Config, error) {
@@ -43,7 +97,16 @@ func SharedConfigForRegion(region string) (*Config, error) {
 	return conf, nil
 }
 
+// IsSweepableTestResource returns whether the resource named resourceName was
+// created by a test, going by its name. In a dry run it logs the resource and
+// returns false, so that the sweepers generated by MMv1, which delete whatever
+// it accepts, only report what they would delete. Those sweepers don't apply
+// SWEEP_MIN_AGE or SWEEP_LABELS, which need the listed object.
 func IsSweepableTestResource(resourceName string) bool {
+	return hasTestResourcePrefix(resourceName) && !skipSweepInDryRun("test", resourceName)
+}
+
+func hasTestResourcePrefix(resourceName string) bool {
 	for _, p := range testResourcePrefixes {
 		if strings.HasPrefix(resourceName, p) {
 			return true
@@ -51,3 +114,353 @@ func IsSweepableTestResource(resourceName string) bool {
 	}
 	return false
 }
+
+// IsSweepableTestObject returns whether the resource obj, named name, was
+// created by a test, either because of its name or its labels, and is old
+// enough to sweep. Resources without a creation time are considered old
+// enough. Unlike IsSweepableTestResource, it doesn't check for a dry run.
+func IsSweepableTestObject(name string, obj map[string]interface{}) bool {
+	if !hasTestResourcePrefix(name) && !hasSweepLabels(obj) {
+		return false
+	}
+	created, ok := sweepCreationTime(obj)
+	return !ok || time.Since(created) >= sweepMinAge()
+}
+
+// IsSweepableTestResourceCreatedAt is IsSweepableTestObject for a resource
+// whose API isn't read as JSON, created at the RFC 3339 time createTime, or ""
+// if the API doesn't report it, and carrying labels.
+func IsSweepableTestResourceCreatedAt(name, createTime string, labels map[string]string) bool {
+	obj := map[string]interface{}{}
+	if createTime != "" {
+		obj["createTime"] = createTime
+	}
+	if len(labels) > 0 {
+		l := make(map[string]interface{}, len(labels))
+		for k, v := range labels {
+			l[k] = v
+		}
+		obj["labels"] = l
+	}
+	return IsSweepableTestObject(name, obj)
+}
+
+// isSweepDryRun returns whether sweepers should only report the resources
+// they would delete.
+func isSweepDryRun() bool {
+	v, _ := strconv.ParseBool(os.Getenv(sweepDryRunEnvVar))
+	return v
+}
+
+// skipSweepInDryRun returns whether the deletion of the resource name by the
+// sweeper named sweeper must be skipped because this is a dry run, in which
+// case the resource is logged.
+func skipSweepInDryRun(sweeper, name string) bool {
+	if !isSweepDryRun() {
+		return false
+	}
+	log.Printf("[INFO][SWEEPER_LOG] Dry run: would delete %s resource: %s", sweeper, name)
+	return true
+}
+
+func sweepMinAge() time.Duration {
+	v := os.Getenv(sweepMinAgeEnvVar)
+	if v == "" {
+		return defaultSweepMinAge
+	}
+	age, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("[WARN][SWEEPER_LOG] Invalid %s %q, using %s: %s", sweepMinAgeEnvVar, v, defaultSweepMinAge, err)
+		return defaultSweepMinAge
+	}
+	return age
+}
+
+// hasSweepLabels returns whether obj carries all the labels set in
+// SWEEP_LABELS. It is always false when no labels are set.
+func hasSweepLabels(obj map[string]interface{}) bool {
+	v := os.Getenv(sweepLabelsEnvVar)
+	if v == "" {
+		return false
+	}
+	labels, _ := obj["labels"].(map[string]interface{})
+	for _, label := range strings.Split(v, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(label), "=")
+		actual, ok := labels[key]
+		if !ok || (hasValue && actual != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// sweepCreationTime returns the time obj was created, if the API reports it.
+func sweepCreationTime(obj map[string]interface{}) (time.Time, bool) {
+	for _, field := range sweepCreationTimeFields {
+		v, ok := obj[field].(string)
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			log.Printf("[WARN][SWEEPER_LOG] Unable to parse %s %q: %s", field, v, err)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// ResourceSweeper describes a sweeper that lists the resources of a type from
+// a collection URL and deletes each one left behind by tests. Sweepers of
+// generated resources are derived from their list URL and name fields.
+type ResourceSweeper struct {
+	// Name is the name the sweeper is registered as, such as "ComputeNetwork".
+	Name string
+	// ListURL is the template of the URL listing the resources. It is expanded
+	// with ReplaceVars against the project, region, location, zone ("-") and
+	// billing_account.
+	ListURL string
+	// ListField is the field of the list response holding the resources.
+	ListField string
+	// Aggregated is set when ListURL is a Compute aggregated list, whose
+	// ListField maps each zone to a list of its resources.
+	Aggregated bool
+	// NameFields are the fields of a resource tried in order for its name,
+	// "name" if unset. Names are shortened to their last path segment.
+	NameFields []string
+	// DeleteURL is the template of the URL deleting a resource. The name of
+	// the resource is appended to the expansion of the template, and {{zone}}
+	// is taken from the resource for aggregated lists.
+	DeleteURL string
+}
+
+// AddTestResourceSweeper registers s, to run after the sweepers it depends on
+// in sweeperDependencies.
+func AddTestResourceSweeper(s ResourceSweeper) {
+	resource.AddTestSweepers(s.Name, &resource.Sweeper{
+		Name:         s.Name,
+		Dependencies: sweeperDependencies[s.Name],
+		F:            s.sweep,
+	})
+}
+
+// At the time of writing, the CI only passes us-central1 as the region
+func (s ResourceSweeper) sweep(region string) error {
+	log.Printf("[INFO][SWEEPER_LOG] Starting sweeper for %s", s.Name)
+
+	config, err := SharedConfigForRegion(region)
+	if err != nil {
+		log.Printf("[INFO][SWEEPER_LOG] error getting shared config for region: %s", err)
+		return err
+	}
+
+	err = config.LoadAndValidate(context.Background())
+	if err != nil {
+		log.Printf("[INFO][SWEEPER_LOG] error loading: %s", err)
+		return err
+	}
+
+	t := &testing.T{}
+	billingId := GetTestBillingAccountFromEnv(t)
+
+	s.sweepResources(config, region, billingId)
+	return nil
+}
+
+// sweepResources deletes the resources that can be swept, or only logs them
+// in a dry run, and returns their names. Errors are logged rather than
+// returned so that one failing sweeper doesn't stop the others.
+func (s ResourceSweeper) sweepResources(config *Config, region, billingId string) []string {
+	// Setup variables to replace in list template
+	d := &ResourceDataMock{
+		FieldsInSchema: map[string]interface{}{
+			"project":         config.Project,
+			"region":          region,
+			"location":        region,
+			"zone":            "-",
+			"billing_account": billingId,
+		},
+	}
+
+	rl, err := s.list(config, d)
+	if err != nil {
+		log.Printf("[INFO][SWEEPER_LOG] %s", err)
+		return nil
+	}
+	log.Printf("[INFO][SWEEPER_LOG] Found %d items in %s list response.", len(rl), s.Name)
+
+	var swept []string
+	// Keep count of items that aren't sweepable for logging.
+	skipped := 0
+	for _, obj := range rl {
+		name := s.resourceName(obj)
+		if name == "" {
+			log.Printf("[INFO][SWEEPER_LOG] %s resource name was nil", s.Name)
+			continue
+		}
+		// Skip resources that shouldn't be sweeped
+		if !IsSweepableTestObject(name, obj) {
+			skipped++
+			continue
+		}
+
+		deleteUrl, err := s.deleteURL(config, d, obj, name)
+		if err != nil {
+			log.Printf("[INFO][SWEEPER_LOG] error preparing delete url: %s", err)
+			continue
+		}
+
+		if skipSweepInDryRun(s.Name, name) {
+			swept = append(swept, name)
+			continue
+		}
+
+		// Don't wait on operations as we may have a lot to delete
+		_, err = SendRequest(config, "DELETE", config.Project, deleteUrl, config.UserAgent, nil)
+		if err != nil {
+			log.Printf("[INFO][SWEEPER_LOG] Error deleting for url %s : %s", deleteUrl, err)
+		} else {
+			log.Printf("[INFO][SWEEPER_LOG] Sent delete request for %s resource: %s", s.Name, name)
+			swept = append(swept, name)
+		}
+	}
+
+	if skipped > 0 {
+		log.Printf("[INFO][SWEEPER_LOG] %d items were non-sweepable or too recent and skipped.", skipped)
+	}
+
+	if !isSweepDryRun() && len(swept) > 0 && isSweeperDependency(s.Name) {
+		s.waitForDeletion(config, d, swept)
+	}
+
+	return swept
+}
+
+// list returns the resources listed by the sweeper, following the pages of
+// the list response.
+func (s ResourceSweeper) list(config *Config, d TerraformResourceData) ([]map[string]interface{}, error) {
+	listUrl, err := ReplaceVars(d, config, s.ListURL)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing sweeper list url: %s", err)
+	}
+
+	var rl []map[string]interface{}
+	pageToken := ""
+	for {
+		url := listUrl
+		if pageToken != "" {
+			url, err = AddQueryParams(listUrl, map[string]string{"pageToken": pageToken})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := SendRequest(config, "GET", config.Project, url, config.UserAgent, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Error in response from request %s: %s", url, err)
+		}
+
+		if s.Aggregated {
+			zones, _ := res[s.ListField].(map[string]interface{})
+			// Loop through every zone in the list response
+			for _, zonesValue := range zones {
+				zone, _ := zonesValue.(map[string]interface{})
+				for k, v := range zone {
+					// Zone map either has resources or a warning stating there were no resources found in the zone
+					if k != "warning" {
+						rl = appendSweepObjects(rl, v)
+					}
+				}
+			}
+		} else {
+			rl = appendSweepObjects(rl, res[s.ListField])
+		}
+
+		pageToken, _ = res["nextPageToken"].(string)
+		if pageToken == "" {
+			return rl, nil
+		}
+	}
+}
+
+func appendSweepObjects(rl []map[string]interface{}, v interface{}) []map[string]interface{} {
+	items, _ := v.([]interface{})
+	for _, item := range items {
+		if obj, ok := item.(map[string]interface{}); ok {
+			rl = append(rl, obj)
+		}
+	}
+	return rl
+}
+
+func (s ResourceSweeper) resourceName(obj map[string]interface{}) string {
+	fields := s.NameFields
+	if len(fields) == 0 {
+		fields = []string{"name"}
+	}
+	for _, field := range fields {
+		if v, ok := obj[field].(string); ok && v != "" {
+			return GetResourceNameFromSelfLink(v)
+		}
+	}
+	return ""
+}
+
+func (s ResourceSweeper) deleteURL(config *Config, d TerraformResourceData, obj map[string]interface{}, name string) (string, error) {
+	deleteTemplate := s.DeleteURL
+	if s.Aggregated {
+		zone, ok := obj["zone"].(string)
+		if !ok {
+			return "", fmt.Errorf("%s resource %s zone was nil", s.Name, name)
+		}
+		deleteTemplate = strings.Replace(deleteTemplate, "{{zone}}", GetResourceNameFromSelfLink(zone), -1)
+	}
+
+	deleteUrl, err := ReplaceVars(d, config, deleteTemplate)
+	if err != nil {
+		return "", err
+	}
+	return deleteUrl + name, nil
+}
+
+// waitForDeletion polls the list of resources until none of names remain, so
+// that the sweepers depending on s find their resources unused.
+func (s ResourceSweeper) waitForDeletion(config *Config, d TerraformResourceData, names []string) {
+	deadline := time.Now().Add(sweeperDeleteTimeout)
+	for {
+		rl, err := s.list(config, d)
+		if err != nil {
+			log.Printf("[INFO][SWEEPER_LOG] %s", err)
+			return
+		}
+		remaining := 0
+		for _, obj := range rl {
+			for _, name := range names {
+				if s.resourceName(obj) == name {
+					remaining++
+				}
+			}
+		}
+		if remaining == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			log.Printf("[INFO][SWEEPER_LOG] %d %s resources were still being deleted after %s", remaining, s.Name, sweeperDeleteTimeout)
+			return
+		}
+		time.Sleep(sweeperPollInterval)
+	}
+}
+
+// isSweeperDependency returns whether other sweepers must run after name.
+func isSweeperDependency(name string) bool {
+	for _, deps := range sweeperDependencies {
+		for _, dep := range deps {
+			if dep == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,7 +1,13 @@
 package google
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -9,3 +15,194 @@ import (
 func TestMain(m *testing.M) {
 	resource.TestMain(m)
 }
+
+func TestIsSweepableTestObject(t *testing.T) {
+	old := time.Now().Add(-4 * time.Hour).Format(time.RFC3339)
+	recent := time.Now().Add(-time.Minute).Format(time.RFC3339)
+
+	cases := map[string]struct {
+		Name     string
+		Obj      map[string]interface{}
+		Labels   string
+		MinAge   string
+		Expected bool
+	}{
+		"old test resource": {
+			Name:     "tf-test-abc",
+			Obj:      map[string]interface{}{"creationTimestamp": old},
+			Expected: true,
+		},
+		"recent test resource": {
+			Name:     "tf-test-abc",
+			Obj:      map[string]interface{}{"createTime": recent},
+			Expected: false,
+		},
+		"recent test resource with no minimum age": {
+			Name:     "tf-test-abc",
+			Obj:      map[string]interface{}{"timeCreated": recent},
+			MinAge:   "0",
+			Expected: true,
+		},
+		"test resource without creation time": {
+			Name:     "tf-test-abc",
+			Obj:      map[string]interface{}{},
+			Expected: true,
+		},
+		"other resource": {
+			Name:     "prod-abc",
+			Obj:      map[string]interface{}{"creationTimestamp": old},
+			Expected: false,
+		},
+		"labelled resource": {
+			Name: "prod-abc",
+			Obj: map[string]interface{}{
+				"creationTimestamp": old,
+				"labels":            map[string]interface{}{"purpose": "tf-test", "owner": "ci"},
+			},
+			Labels:   "purpose=tf-test,owner",
+			Expected: true,
+		},
+		"resource with other labels": {
+			Name: "prod-abc",
+			Obj: map[string]interface{}{
+				"creationTimestamp": old,
+				"labels":            map[string]interface{}{"purpose": "prod"},
+			},
+			Labels:   "purpose=tf-test",
+			Expected: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			t.Setenv(sweepLabelsEnvVar, tc.Labels)
+			t.Setenv(sweepMinAgeEnvVar, tc.MinAge)
+			if got := IsSweepableTestObject(tc.Name, tc.Obj); got != tc.Expected {
+				t.Errorf("expected %t, got %t", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestIsSweepableTestResource(t *testing.T) {
+	t.Setenv(sweepDryRunEnvVar, "")
+	if !IsSweepableTestResource("tf-test-abc") {
+		t.Errorf("expected a test resource to be swept")
+	}
+	if IsSweepableTestResource("prod-abc") {
+		t.Errorf("expected a resource not created by tests not to be swept")
+	}
+
+	t.Setenv(sweepDryRunEnvVar, "true")
+	if IsSweepableTestResource("tf-test-abc") {
+		t.Errorf("expected nothing to be swept in a dry run")
+	}
+}
+
+func TestIsSweepableTestResourceCreatedAt(t *testing.T) {
+	old := time.Now().Add(-4 * time.Hour).Format(time.RFC3339)
+	t.Setenv(sweepMinAgeEnvVar, "")
+	t.Setenv(sweepLabelsEnvVar, "purpose=tf-test")
+
+	if !IsSweepableTestResourceCreatedAt("prod-abc", old, map[string]string{"purpose": "tf-test"}) {
+		t.Errorf("expected a labelled resource to be swept")
+	}
+	if IsSweepableTestResourceCreatedAt("prod-abc", old, nil) {
+		t.Errorf("expected a resource without labels not to be swept")
+	}
+	if IsSweepableTestResourceCreatedAt("tf-test-abc", time.Now().Format(time.RFC3339), nil) {
+		t.Errorf("expected a recent test resource not to be swept")
+	}
+}
+
+func TestResourceSweeper_sweepResources(t *testing.T) {
+	p, srv := newFakeGcpProvider(t)
+	config := p.Meta().(*Config)
+	for _, name := range []string{"tf-test-network", "prod-network"} {
+		url := config.ComputeBasePath + "projects/" + fakeGcpProject + "/global/networks"
+		if _, err := SendRequest(config, "POST", fakeGcpProject, url, config.UserAgent, map[string]interface{}{"name": name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := ResourceSweeper{
+		Name:      "ComputeNetwork",
+		ListURL:   "{{ComputeBasePath}}projects/{{project}}/global/networks",
+		ListField: "items",
+		DeleteURL: "{{ComputeBasePath}}projects/{{project}}/global/networks/{{name}}",
+	}
+	networks := "compute/projects/" + fakeGcpProject + "/global/networks/"
+
+	t.Setenv(sweepMinAgeEnvVar, "")
+	if swept := s.sweepResources(config, "us-central1", ""); len(swept) != 0 {
+		t.Errorf("expected recent networks not to be swept, got %v", swept)
+	}
+
+	t.Setenv(sweepMinAgeEnvVar, "0")
+	t.Setenv(sweepDryRunEnvVar, "true")
+	if swept := s.sweepResources(config, "us-central1", ""); !reflect.DeepEqual(swept, []string{"tf-test-network"}) {
+		t.Errorf("expected a dry run to report tf-test-network, got %v", swept)
+	}
+	if got := srv.Resources(networks); len(got) != 2 {
+		t.Errorf("expected a dry run not to delete networks, got %v", got)
+	}
+
+	t.Setenv(sweepDryRunEnvVar, "")
+	if swept := s.sweepResources(config, "us-central1", ""); !reflect.DeepEqual(swept, []string{"tf-test-network"}) {
+		t.Errorf("expected tf-test-network to be swept, got %v", swept)
+	}
+	if got := srv.Resources(networks); !reflect.DeepEqual(got, []string{networks + "prod-network"}) {
+		t.Errorf("expected only prod-network to remain, got %v", got)
+	}
+}
+
+func TestResourceSweeper_aggregatedPages(t *testing.T) {
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "DELETE":
+			deleted = append(deleted, r.URL.Path)
+			fmt.Fprint(w, `{}`)
+		case r.URL.Query().Get("pageToken") == "":
+			fmt.Fprint(w, `{"items": {
+				"zones/us-central1-a": {"instances": [{"name": "tf-test-a", "zone": "https://x/zones/us-central1-a"}]},
+				"zones/us-east1-b": {"warning": {"code": "NO_RESULTS_ON_PAGE"}}
+			}, "nextPageToken": "page-2"}`)
+		default:
+			fmt.Fprint(w, `{"items": {
+				"zones/us-west1-a": {"instances": [{"name": "tf-test-b", "zone": "https://x/zones/us-west1-a"}, {"name": "prod"}]}
+			}}`)
+		}
+	}))
+	defer srv.Close()
+
+	p, _ := newFakeGcpProvider(t)
+	t.Setenv(sweepMinAgeEnvVar, "")
+	t.Setenv(sweepDryRunEnvVar, "")
+	s := ResourceSweeper{
+		Name:       "ComputeInstance",
+		ListURL:    srv.URL + "/projects/{{project}}/aggregated/instances",
+		ListField:  "items",
+		Aggregated: true,
+		DeleteURL:  srv.URL + "/projects/{{project}}/zones/{{zone}}/instances/{{name}}",
+	}
+	// The server doesn't remove deleted instances from its lists, so the
+	// sweeper waits for them until it times out.
+	sweeperPollInterval, sweeperDeleteTimeout = time.Millisecond, 10*time.Millisecond
+	defer func() { sweeperPollInterval, sweeperDeleteTimeout = 10*time.Second, 10*time.Minute }()
+
+	swept := s.sweepResources(p.Meta().(*Config), "us-central1", "")
+	sort.Strings(swept)
+	if !reflect.DeepEqual(swept, []string{"tf-test-a", "tf-test-b"}) {
+		t.Errorf("expected the test instances of both pages to be swept, got %v", swept)
+	}
+	sort.Strings(deleted)
+	expected := []string{
+		"/projects/" + fakeGcpProject + "/zones/us-central1-a/instances/tf-test-a",
+		"/projects/" + fakeGcpProject + "/zones/us-west1-a/instances/tf-test-b",
+	}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("expected deletions %v, got %v", expected, deleted)
+	}
+}
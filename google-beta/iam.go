@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math/rand"
 	"reflect"
 	"sort"
 	"strings"
@@ -19,6 +20,26 @@ import (
 const maxBackoffSeconds = 30
 const IamPolicyVersion = 3
 
+// How many times a write rejected because the policy changed concurrently is
+// re-applied to the current policy before giving up.
+const maxIamPolicyConflicts = 10
+
+// Modes for confirming IAM policy changes, set by the provider's
+// `iam_policy_verification` field. Each confirmation costs a policy read, and
+// IAM read quota is limited.
+const (
+	// Read the policy back three times without checking that the change is
+	// in it, which gives it time to propagate. This is the default, and how
+	// earlier versions confirmed changes.
+	IamPolicyVerificationDelay = "delay"
+	// Trust a successful write.
+	IamPolicyVerificationOff = "off"
+	// Read the policy back until the change is seen once.
+	IamPolicyVerificationEventual = "eventual"
+	// Read the policy back until the change is seen three times in a row.
+	IamPolicyVerificationStrict = "strict"
+)
+
 // These types are implemented per GCP resource type and specify how to do per-resource IAM operations.
 // They are used in the generic Terraform IAM resource definitions
 // (e.g. _member/_binding/_policy/_audit_config)
@@ -75,12 +96,20 @@ func iamPolicyReadWithRetry(updater ResourceIamUpdater) (*cloudresourcemanager.P
 }
 
 // Locking wrapper around read-modify-write cycle for IAM policy.
-func iamPolicyReadModifyWrite(updater ResourceIamUpdater, modify iamPolicyModifyFunc) error {
+//
+// The mutex only serialises changes made by this process. Changes made
+// concurrently elsewhere are detected through the policy etag: when the API
+// rejects a write with a conflict, the policy is read again and `modify` is
+// re-applied to it. Once written, the change is confirmed according to
+// `verification`, one of the IamPolicyVerification* modes.
+func iamPolicyReadModifyWrite(updater ResourceIamUpdater, modify iamPolicyModifyFunc, verification string) error {
 	mutexKey := updater.GetMutexKey()
 	mutexKV.Lock(mutexKey)
 	defer mutexKV.Unlock(mutexKey)
 
 	backoff := time.Second
+	conflicts := 0
+	lostEtag := ""
 	for {
 		log.Printf("[DEBUG]: Retrieving policy for %s\n", updater.DescribeResource())
 		p, err := updater.GetResourceIamPolicy()
@@ -92,6 +121,9 @@ func iamPolicyReadModifyWrite(updater ResourceIamUpdater, modify iamPolicyModify
 			return err
 		}
 		log.Printf("[DEBUG]: Retrieved policy for %s: %+v\n", updater.DescribeResource(), p)
+		if conflicts > 0 {
+			log.Printf("[WARN] Concurrent change to the IAM policy of %s: our etag %q lost to etag %q, re-applying changes (conflict %d)", updater.DescribeResource(), lostEtag, p.Etag, conflicts)
+		}
 
 		err = modify(p)
 		if err != nil {
@@ -101,52 +133,25 @@ func iamPolicyReadModifyWrite(updater ResourceIamUpdater, modify iamPolicyModify
 		log.Printf("[DEBUG]: Setting policy for %s to %+v\n", updater.DescribeResource(), p)
 		err = updater.SetResourceIamPolicy(p)
 		if err == nil {
-			fetchBackoff := 1 * time.Second
-			for successfulFetches := 0; successfulFetches < 3; {
-				if fetchBackoff > maxBackoffSeconds*time.Second {
-					return fmt.Errorf("Error applying IAM policy to %s: Waited too long for propagation.\n", updater.DescribeResource())
-				}
-				time.Sleep(fetchBackoff)
-				log.Printf("[DEBUG]: Retrieving policy for %s\n", updater.DescribeResource())
-				new_p, err := updater.GetResourceIamPolicy()
-				if err != nil {
-					// Quota for Read is pretty limited, so watch out for running out of quota.
-					if IsGoogleApiErrorWithCode(err, 429) {
-						fetchBackoff = fetchBackoff * 2
-					} else {
-						return err
-					}
-				}
-				log.Printf("[DEBUG]: Retrieved policy for %s: %+v\n", updater.DescribeResource(), p)
-				if new_p == nil {
-					// https://github.com/hashicorp/terraform-provider-google/issues/2625
-					fetchBackoff = fetchBackoff * 2
-					continue
-				}
-				modified_p := new_p
-				// This relies on the fact that `modify` is idempotent: since other changes might have
-				// happened between the call to set the policy and now, we just need to make sure that
-				// our change has been made.  'modify(p) == p' is our check for whether this has been
-				// correctly applied.
-				err = modify(modified_p)
-				if err != nil {
-					return err
-				}
-				if modified_p == new_p {
-					successfulFetches += 1
-				} else {
-					fetchBackoff = fetchBackoff * 2
-				}
+			if err := iamPolicyVerifyChange(updater, modify, verification); err != nil {
+				return err
 			}
 			break
 		}
 		if isConflictError(err) {
-			log.Printf("[DEBUG]: Concurrent policy changes, restarting read-modify-write after %s\n", backoff)
-			time.Sleep(backoff)
-			backoff = backoff * 2
-			if backoff > 30*time.Second {
+			conflicts++
+			lostEtag = p.Etag
+			if conflicts > maxIamPolicyConflicts {
 				return errwrap.Wrapf(fmt.Sprintf("Error applying IAM policy to %s: Too many conflicts.  Latest error: {{err}}", updater.DescribeResource()), err)
 			}
+			// Jitter the wait so that writers in different processes that
+			// conflicted with each other don't retry in lockstep.
+			wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff)))
+			log.Printf("[DEBUG]: Concurrent policy changes, restarting read-modify-write after %s\n", wait)
+			time.Sleep(wait)
+			if backoff < maxBackoffSeconds*time.Second {
+				backoff = backoff * 2
+			}
 			continue
 		}
 
@@ -180,6 +185,86 @@ func iamPolicyReadModifyWrite(updater ResourceIamUpdater, modify iamPolicyModify
 	return nil
 }
 
+// Confirms a change written by iamPolicyReadModifyWrite is visible, by reading
+// the policy until it has been seen in place in as many consecutive reads as
+// `verification` requires. In the delay mode, the reads aren't checked.
+func iamPolicyVerifyChange(updater ResourceIamUpdater, modify iamPolicyModifyFunc, verification string) error {
+	if verification == "" {
+		verification = IamPolicyVerificationDelay
+	}
+	reads := iamPolicyVerificationReads(verification)
+	checked := verification != IamPolicyVerificationDelay
+	fetchBackoff := 1 * time.Second
+	for successfulFetches := 0; successfulFetches < reads; {
+		if fetchBackoff > maxBackoffSeconds*time.Second {
+			return fmt.Errorf("Error applying IAM policy to %s: Waited too long for propagation.\n", updater.DescribeResource())
+		}
+		time.Sleep(fetchBackoff)
+		log.Printf("[DEBUG]: Retrieving policy for %s\n", updater.DescribeResource())
+		p, err := updater.GetResourceIamPolicy()
+		if err != nil {
+			// Quota for Read is pretty limited, so watch out for running out of quota.
+			if !IsGoogleApiErrorWithCode(err, 429) {
+				return err
+			}
+			fetchBackoff = fetchBackoff * 2
+			continue
+		}
+		log.Printf("[DEBUG]: Retrieved policy for %s: %+v\n", updater.DescribeResource(), p)
+		if p == nil {
+			// https://github.com/hashicorp/terraform-provider-google/issues/2625
+			fetchBackoff = fetchBackoff * 2
+			continue
+		}
+		applied := true
+		if checked {
+			applied, err = iamPolicyHasChange(p, modify)
+			if err != nil {
+				return err
+			}
+		}
+		if applied {
+			successfulFetches += 1
+		} else {
+			successfulFetches = 0
+			fetchBackoff = fetchBackoff * 2
+		}
+	}
+	return nil
+}
+
+// Reports whether `modify` is a no-op on p, meaning the change it makes is
+// already in place. This relies on `modify` being idempotent: since other
+// changes might have happened since the policy was set, we only check that
+// ours is there.
+func iamPolicyHasChange(p *cloudresourcemanager.Policy, modify iamPolicyModifyFunc) (bool, error) {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return false, err
+	}
+	modified := &cloudresourcemanager.Policy{}
+	if err := json.Unmarshal(raw, modified); err != nil {
+		return false, err
+	}
+	if err := modify(modified); err != nil {
+		return false, err
+	}
+	return compareBindings(p.Bindings, modified.Bindings) && compareAuditConfigs(p.AuditConfigs, modified.AuditConfigs), nil
+}
+
+// Returns how many consecutive reads must show a change before it's
+// considered applied.
+func iamPolicyVerificationReads(verification string) int {
+	switch verification {
+	case IamPolicyVerificationOff:
+		return 0
+	case IamPolicyVerificationEventual:
+		return 1
+	default:
+		return 3
+	}
+}
+
 // Flattens a list of Bindings so each role+condition has a single Binding with combined members
 func MergeBindings(bindings []*cloudresourcemanager.Binding) []*cloudresourcemanager.Binding {
 	bm := createIamBindingsMap(bindings)
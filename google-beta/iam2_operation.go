@@ -56,5 +56,5 @@ func IAM2OperationWaitTime(config *Config, op map[string]interface{}, activity,
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("iam2"))
 }
@@ -16,45 +16,31 @@ const (
 func BatchRequestModifyIamPolicy(updater ResourceIamUpdater, modify iamPolicyModifyFunc, config *Config, reqDesc string) error {
 	batchKey := fmt.Sprintf(batchKeyTmplModifyIamPolicy, updater.GetMutexKey())
 
-	request := &BatchRequest{
+	request := &BatchRequest[[]iamPolicyModifyFunc, struct{}]{
 		ResourceName: updater.GetResourceId(),
 		Body:         []iamPolicyModifyFunc{modify},
 		CombineF:     combineBatchIamPolicyModifiers,
-		SendF:        sendBatchModifyIamPolicy(updater),
+		SendF:        sendBatchModifyIamPolicy(updater, config.IamPolicyVerification),
 		DebugId:      reqDesc,
 	}
 
-	_, err := config.requestBatcherIam.SendRequestWithTimeout(batchKey, request, time.Minute*30)
+	_, err := SendBatchRequestWithTimeout(config.requestBatcherIam, batchKey, request, time.Minute*30)
 	return err
 }
 
-func combineBatchIamPolicyModifiers(currV interface{}, toAddV interface{}) (interface{}, error) {
-	currModifiers, ok := currV.([]iamPolicyModifyFunc)
-	if !ok {
-		return nil, fmt.Errorf("provider error in batch combiner: expected data to be type []iamPolicyModifyFunc, got %v with type %T", currV, currV)
-	}
-
-	newModifiers, ok := toAddV.([]iamPolicyModifyFunc)
-	if !ok {
-		return nil, fmt.Errorf("provider error in batch combiner: expected data to be type []iamPolicyModifyFunc, got %v with type %T", currV, currV)
-	}
-
+func combineBatchIamPolicyModifiers(currModifiers []iamPolicyModifyFunc, newModifiers []iamPolicyModifyFunc) ([]iamPolicyModifyFunc, error) {
 	return append(currModifiers, newModifiers...), nil
 }
 
-func sendBatchModifyIamPolicy(updater ResourceIamUpdater) BatcherSendFunc {
-	return func(resourceName string, body interface{}) (interface{}, error) {
-		modifiers, ok := body.([]iamPolicyModifyFunc)
-		if !ok {
-			return nil, fmt.Errorf("provider error: expected data to be type []iamPolicyModifyFunc, got %v with type %T", body, body)
-		}
-		return nil, iamPolicyReadModifyWrite(updater, func(policy *cloudresourcemanager.Policy) error {
+func sendBatchModifyIamPolicy(updater ResourceIamUpdater, verification string) BatcherSendFunc[[]iamPolicyModifyFunc, struct{}] {
+	return func(resourceName string, modifiers []iamPolicyModifyFunc) (struct{}, error) {
+		return struct{}{}, iamPolicyReadModifyWrite(updater, func(policy *cloudresourcemanager.Policy) error {
 			for _, modifyF := range modifiers {
 				if err := modifyF(policy); err != nil {
 					return err
 				}
 			}
 			return nil
-		})
+		}, verification)
 	}
 }
@@ -1,11 +1,13 @@
 package google
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
 )
 
 func TestIamMergeBindings(t *testing.T) {
@@ -1323,3 +1325,122 @@ func TestIamListFromIamAuditConfigsMap(t *testing.T) {
 		}
 	}
 }
+
+// fakeIamUpdater stores a policy in memory, rejecting writes with a stale etag
+// like the IAM APIs do. beforeSet, if set, runs before each write, to
+// simulate a concurrent change made by another process.
+type fakeIamUpdater struct {
+	policy    *cloudresourcemanager.Policy
+	etag      int
+	gets      int
+	beforeSet func(u *fakeIamUpdater)
+}
+
+func (u *fakeIamUpdater) GetResourceIamPolicy() (*cloudresourcemanager.Policy, error) {
+	u.gets++
+	return &cloudresourcemanager.Policy{
+		Etag:     fmt.Sprintf("etag-%d", u.etag),
+		Bindings: append([]*cloudresourcemanager.Binding{}, u.policy.Bindings...),
+	}, nil
+}
+
+func (u *fakeIamUpdater) SetResourceIamPolicy(policy *cloudresourcemanager.Policy) error {
+	if u.beforeSet != nil {
+		u.beforeSet(u)
+	}
+	if policy.Etag != fmt.Sprintf("etag-%d", u.etag) {
+		return &googleapi.Error{Code: 409, Message: "There were concurrent policy changes."}
+	}
+	u.etag++
+	u.policy = &cloudresourcemanager.Policy{Bindings: policy.Bindings}
+	return nil
+}
+
+func (u *fakeIamUpdater) GetMutexKey() string      { return "iam-fake" }
+func (u *fakeIamUpdater) GetResourceId() string    { return "fake" }
+func (u *fakeIamUpdater) DescribeResource() string { return "fake resource" }
+
+func addBindingModifier(role, member string) iamPolicyModifyFunc {
+	return func(p *cloudresourcemanager.Policy) error {
+		p.Bindings = MergeBindings(append(p.Bindings, &cloudresourcemanager.Binding{Role: role, Members: []string{member}}))
+		return nil
+	}
+}
+
+func TestIamPolicyReadModifyWrite_reappliesOnConflict(t *testing.T) {
+	updater := &fakeIamUpdater{policy: &cloudresourcemanager.Policy{}}
+	concurrent := addBindingModifier("roles/viewer", "user:other@example.com")
+	updater.beforeSet = func(u *fakeIamUpdater) {
+		// Another process wins the first race.
+		u.beforeSet = nil
+		if err := concurrent(u.policy); err != nil {
+			t.Fatal(err)
+		}
+		u.etag++
+	}
+
+	err := iamPolicyReadModifyWrite(updater, addBindingModifier("roles/editor", "user:me@example.com"), IamPolicyVerificationOff)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []*cloudresourcemanager.Binding{
+		{Role: "roles/editor", Members: []string{"user:me@example.com"}},
+		{Role: "roles/viewer", Members: []string{"user:other@example.com"}},
+	}
+	if !compareBindings(updater.policy.Bindings, expected) {
+		t.Errorf("expected both changes in the policy, got %s", debugPrintBindings(updater.policy.Bindings))
+	}
+	if updater.gets != 2 {
+		t.Errorf("expected the policy to be read again after the conflict, got %d reads", updater.gets)
+	}
+}
+
+func TestIamPolicyReadModifyWrite_verification(t *testing.T) {
+	cases := map[string]int{
+		IamPolicyVerificationOff:      1,
+		IamPolicyVerificationEventual: 2,
+		IamPolicyVerificationDelay:    4,
+	}
+	for verification, expectedGets := range cases {
+		updater := &fakeIamUpdater{policy: &cloudresourcemanager.Policy{}}
+		err := iamPolicyReadModifyWrite(updater, addBindingModifier("roles/editor", "user:me@example.com"), verification)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", verification, err)
+		}
+		if updater.gets != expectedGets {
+			t.Errorf("%s: expected %d policy reads, got %d", verification, expectedGets, updater.gets)
+		}
+	}
+}
+
+func TestIamPolicyHasChange(t *testing.T) {
+	p := &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			{Role: "roles/editor", Members: []string{"user:me@example.com", "user:other@example.com"}},
+		},
+	}
+
+	applied, err := iamPolicyHasChange(p, addBindingModifier("roles/editor", "user:me@example.com"))
+	if err != nil || !applied {
+		t.Errorf("expected the change to be applied, got %v, %v", applied, err)
+	}
+	applied, err = iamPolicyHasChange(p, addBindingModifier("roles/viewer", "user:me@example.com"))
+	if err != nil || applied {
+		t.Errorf("expected the change not to be applied, got %v, %v", applied, err)
+	}
+	if len(p.Bindings) != 1 {
+		t.Errorf("expected the policy not to be modified, got %s", debugPrintBindings(p.Bindings))
+	}
+}
+
+func TestIamPolicyVerifyChange_delayDoesNotCheckPolicy(t *testing.T) {
+	// The change was never written, but the delay mode only waits.
+	updater := &fakeIamUpdater{policy: &cloudresourcemanager.Policy{}}
+	if err := iamPolicyVerifyChange(updater, addBindingModifier("roles/editor", "user:me@example.com"), IamPolicyVerificationDelay); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if updater.gets != 3 {
+		t.Errorf("expected 3 policy reads, got %d", updater.gets)
+	}
+}
@@ -56,5 +56,5 @@ func IAMWorkforcePoolOperationWaitTime(config *Config, op map[string]interface{}
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("iam_workforce_pool"))
 }
@@ -3,6 +3,8 @@ package google
 import (
 	"fmt"
 	"log"
+	"net/url"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,7 +17,11 @@ import (
 // - projects/(?P<project>[^/]+)/regions/(?P<region>[^/]+)/subnetworks/(?P<name>[^/]+) (applied first)
 // - (?P<project>[^/]+)/(?P<region>[^/]+)/(?P<name>[^/]+),
 // - (?P<name>[^/]+) (applied last)
+//
+// Full API URLs and Cloud Asset Inventory names are accepted too, see
+// normalizeImportId.
 func ParseImportId(idRegexes []string, d TerraformResourceData, config *Config) error {
+	id := normalizeImportId(d.Id(), idRegexes, config)
 	for _, idFormat := range idRegexes {
 		re, err := regexp.Compile(idFormat)
 
@@ -24,8 +30,8 @@ func ParseImportId(idRegexes []string, d TerraformResourceData, config *Config)
 			return fmt.Errorf("Import is not supported. Invalid regex formats.")
 		}
 
-		if fieldValues := re.FindStringSubmatch(d.Id()); fieldValues != nil {
-			log.Printf("[DEBUG] matching ID %s to regex %s.", d.Id(), idFormat)
+		if fieldValues := re.FindStringSubmatch(id); fieldValues != nil {
+			log.Printf("[DEBUG] matching ID %s to regex %s.", id, idFormat)
 			// Starting at index 1, the first match is the full string.
 			for i := 1; i < len(fieldValues); i++ {
 				fieldName := re.SubexpNames()[i]
@@ -114,6 +120,7 @@ func setDefaultValues(idRegex string, d TerraformResourceData, config *Config) e
 // - (?P<project>[^/]+)/(?P<region>[^/]+)/(?P<name>[^/]+),
 // - (?P<name>[^/]+) (applied last)
 func getImportIdQualifiers(idRegexes []string, d TerraformResourceData, config *Config, id string) (map[string]string, error) {
+	id = normalizeImportId(id, idRegexes, config)
 	for _, idFormat := range idRegexes {
 		re, err := regexp.Compile(idFormat)
 
@@ -172,3 +179,118 @@ func getDefaultValues(idRegex string, d TerraformResourceData, config *Config) (
 	}
 	return result, nil
 }
+
+// Rewrites an import id given as a full API URL, such as a self link
+// "https://www.googleapis.com/compute/v1/projects/p/global/networks/n", or as a
+// Cloud Asset Inventory name, such as
+// "//compute.googleapis.com/projects/p/global/networks/n", to the first of
+// idRegexes that a part of its path is in. Formats with no literal segments,
+// such as "(?P<bucket>[^/]+)/(?P<entity>[^/]+)", only take a path made of a
+// single segment, as nothing tells which segments of a longer path they match.
+// Other ids, and URLs that can't be converted to any of idRegexes, are
+// returned unchanged, to be matched as they were before URLs were accepted.
+func normalizeImportId(id string, idRegexes []string, config *Config) string {
+	var path string
+	switch {
+	case strings.HasPrefix(id, "https://") || strings.HasPrefix(id, "http://"):
+		path = id
+		if i := strings.IndexAny(path, "?#"); i >= 0 {
+			path = path[:i]
+		}
+		if basePath := longestImportBasePath(path, config); basePath != "" {
+			path = strings.TrimPrefix(path, basePath)
+		} else if u, err := url.Parse(path); err == nil {
+			path = u.Path
+		}
+	case strings.HasPrefix(id, "//"):
+		// //{service}.googleapis.com/{relative resource name}
+		path = strings.TrimPrefix(id, "//")
+		if i := strings.Index(path, "/"); i >= 0 {
+			path = path[i:]
+		} else {
+			path = ""
+		}
+	default:
+		return id
+	}
+	path = strings.Trim(path, "/")
+
+	for _, idRegex := range idRegexes {
+		re, err := regexp.Compile("^(?:" + idRegex + ")$")
+		if err != nil {
+			continue
+		}
+		if !importIdRegexHasLiterals(idRegex) {
+			if path != "" && !strings.Contains(path, "/") && re.MatchString(path) {
+				log.Printf("[DEBUG] Normalized import id %s to %s", id, path)
+				return path
+			}
+			continue
+		}
+		// Drop leading segments, such as an API version, until the rest is
+		// in this format.
+		for candidate := path; candidate != ""; {
+			if re.MatchString(candidate) {
+				log.Printf("[DEBUG] Normalized import id %s to %s", id, candidate)
+				return candidate
+			}
+			i := strings.Index(candidate, "/")
+			if i < 0 {
+				break
+			}
+			candidate = candidate[i+1:]
+		}
+	}
+	log.Printf("[DEBUG] Import id %s can't be converted to any of the accepted formats %v, using it as is", id, idRegexes)
+	return id
+}
+
+// Returns whether the import id regex idRegex has literal text outside of its
+// groups and character classes, such as "projects" in
+// "projects/(?P<project>[^/]+)", which pins the segments it matches.
+func importIdRegexHasLiterals(idRegex string) bool {
+	depth := 0
+	inClass := false
+	for i := 0; i < len(idRegex); i++ {
+		switch c := idRegex[i]; {
+		case c == '\\':
+			if depth == 0 && !inClass {
+				return true
+			}
+			i++
+		case inClass:
+			inClass = c != ']'
+		case c == '[':
+			inClass = true
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && c != '/' && c != '^' && c != '$':
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the longest of the default and configured base paths prefixing u.
+func longestImportBasePath(u string, config *Config) string {
+	var basePaths []string
+	for key, basePath := range DefaultBasePaths {
+		basePaths = append(basePaths, basePath)
+		if config == nil {
+			continue
+		}
+		if f := reflect.ValueOf(config).Elem().FieldByName(key + "BasePath"); f.IsValid() && f.Kind() == reflect.String {
+			basePaths = append(basePaths, f.String())
+		}
+	}
+
+	longest := ""
+	for _, basePath := range basePaths {
+		if basePath != "" && strings.HasPrefix(u, basePath) && len(basePath) > len(longest) {
+			longest = basePath
+		}
+	}
+	return longest
+}
@@ -37,6 +37,51 @@ func TestParseImportId(t *testing.T) {
 				"name":    "my-subnetwork",
 			},
 		},
+		"full self_link with query": {
+			ImportId:  "https://compute.googleapis.com/compute/beta/projects/my-project/regions/my-region/subnetworks/my-subnetwork?alt=json",
+			IdRegexes: regionalIdRegexes,
+			ExpectedSchemaValues: map[string]interface{}{
+				"project": "my-project",
+				"region":  "my-region",
+				"name":    "my-subnetwork",
+			},
+		},
+		"cloud asset name": {
+			ImportId:  "//compute.googleapis.com/projects/my-project/zones/my-zone/instances/my-instance",
+			IdRegexes: zonalIdRegexes,
+			ExpectedSchemaValues: map[string]interface{}{
+				"project": "my-project",
+				"zone":    "my-zone",
+				"name":    "my-instance",
+			},
+		},
+		"cloud asset name with a short canonical format": {
+			ImportId:  "//storage.googleapis.com/my-bucket",
+			IdRegexes: []string{"(?P<name>[^/]+)"},
+			ExpectedSchemaValues: map[string]interface{}{
+				"name": "my-bucket",
+			},
+		},
+		"full url on a custom endpoint": {
+			ImportId: "https://pubsub.example.com/v1/projects/my-project/topics/my-topic",
+			Config: &Config{
+				PubsubBasePath: "https://pubsub.example.com/v1/",
+			},
+			IdRegexes: []string{
+				"projects/(?P<project>[^/]+)/topics/(?P<name>[^/]+)",
+				"(?P<project>[^/]+)/(?P<name>[^/]+)",
+				"(?P<name>[^/]+)",
+			},
+			ExpectedSchemaValues: map[string]interface{}{
+				"project": "my-project",
+				"name":    "my-topic",
+			},
+		},
+		"full url with a canonical format without literals": {
+			ImportId:    "https://storage.googleapis.com/storage/v1/b/my-bucket/acl/user-me@example.com",
+			IdRegexes:   []string{"(?P<bucket>[^/]+)/(?P<entity>[^/]+)"},
+			ExpectError: true,
+		},
 		"relative self_link": {
 			ImportId:  "projects/my-project/regions/my-region/subnetworks/my-subnetwork",
 			IdRegexes: regionalIdRegexes,
@@ -145,3 +190,83 @@ func TestParseImportId(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeImportId(t *testing.T) {
+	bucketAclIdRegexes := []string{"(?P<bucket>[^/]+)/(?P<entity>[^/]+)"}
+	objectAclIdRegexes := []string{"(?P<bucket>[^/]+)/(?P<object>.+)/(?P<entity>[^/]+)"}
+
+	cases := map[string]struct {
+		ImportId  string
+		IdRegexes []string
+		Expected  string
+		// Unchanged is set when the id can't be converted to any format, and
+		// is left for ParseImportId to match as is.
+		Unchanged bool
+	}{
+		"api version dropped": {
+			ImportId:  "https://pubsub.example.com/v1/projects/my-project/topics/my-topic",
+			IdRegexes: []string{"projects/(?P<project>[^/]+)/topics/(?P<name>[^/]+)"},
+			Expected:  "projects/my-project/topics/my-topic",
+		},
+		"single segment without literals": {
+			ImportId:  "//storage.googleapis.com/my-bucket",
+			IdRegexes: []string{"(?P<name>[^/]+)"},
+			Expected:  "my-bucket",
+		},
+		"bucket acl url": {
+			ImportId:  "https://storage.googleapis.com/storage/v1/b/my-bucket/acl/user-me@example.com",
+			IdRegexes: bucketAclIdRegexes,
+			Unchanged: true,
+		},
+		"object acl url": {
+			ImportId:  "https://storage.googleapis.com/storage/v1/b/my-bucket/o/my-object/acl/user-me@example.com",
+			IdRegexes: objectAclIdRegexes,
+			Unchanged: true,
+		},
+		"object acl asset name": {
+			ImportId:  "//storage.googleapis.com/b/my-bucket/o/my-object/acl/user-me@example.com",
+			IdRegexes: objectAclIdRegexes,
+			Unchanged: true,
+		},
+		"url taken whole by a single field": {
+			ImportId:  "https://example.com/my-folder/my-name",
+			IdRegexes: []string{"(?P<name>.+)"},
+			Expected:  "https://example.com/my-folder/my-name",
+		},
+		"bucket acl canonical id": {
+			ImportId:  "my-bucket/user-me@example.com",
+			IdRegexes: bucketAclIdRegexes,
+			Expected:  "my-bucket/user-me@example.com",
+		},
+	}
+
+	for tn, tc := range cases {
+		expected := tc.Expected
+		if tc.Unchanged {
+			expected = tc.ImportId
+		}
+		if got := normalizeImportId(tc.ImportId, tc.IdRegexes, &Config{}); got != expected {
+			t.Errorf("%s failed; expected %q, got %q", tn, expected, got)
+		}
+	}
+}
+
+func TestStorageAccessControlImport(t *testing.T) {
+	bucketAcl := ResourceStorageBucketAccessControl().TestResourceData()
+	bucketAcl.SetId("my-bucket/user-me@example.com")
+	if _, err := resourceStorageBucketAccessControlImport(bucketAcl, &Config{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if bucketAcl.Get("bucket") != "my-bucket" || bucketAcl.Get("entity") != "user-me@example.com" {
+		t.Errorf("expected bucket my-bucket and entity user-me@example.com, got %q and %q", bucketAcl.Get("bucket"), bucketAcl.Get("entity"))
+	}
+
+	objectAcl := ResourceStorageObjectAccessControl().TestResourceData()
+	objectAcl.SetId("my-bucket/path/to/my-object/user-me@example.com")
+	if _, err := resourceStorageObjectAccessControlImport(objectAcl, &Config{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if objectAcl.Get("bucket") != "my-bucket" || objectAcl.Get("object") != "path/to/my-object" || objectAcl.Get("entity") != "user-me@example.com" {
+		t.Errorf("expected bucket my-bucket, object path/to/my-object and entity user-me@example.com, got %q, %q and %q", objectAcl.Get("bucket"), objectAcl.Get("object"), objectAcl.Get("entity"))
+	}
+}
@@ -0,0 +1,193 @@
+// Contains functions for handling provider-level default labels and the
+// terraform_labels / effective_labels fields of labelled resources.
+
+package google
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DefaultLabelsResources returns the names of the resources applying the
+// provider-level default_labels, derived from their schema: those exporting
+// terraform_labels, which they set through SetLabelsDiff and SetLabelsFields.
+// Other resources with a labels field ignore default_labels. The result must
+// match the default_labels documentation of the provider reference.
+func DefaultLabelsResources(resources map[string]*schema.Resource) []string {
+	var names []string
+	for name, r := range resources {
+		if _, ok := r.Schema["terraform_labels"]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForceNewLabelsResources returns the resources among DefaultLabelsResources
+// whose labels are immutable, which are replaced when default_labels change.
+func ForceNewLabelsResources(resources map[string]*schema.Resource) []string {
+	var names []string
+	for _, name := range DefaultLabelsResources(resources) {
+		if resources[name].Schema["terraform_labels"].ForceNew {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+const (
+	terraformLabelsDescription = `The combination of labels configured directly on the resource and default labels configured on the provider.`
+	effectiveLabelsDescription = `All of labels (key/value pairs) present on the resource in GCP, including the labels configured through Terraform, other clients and services.`
+)
+
+// TerraformLabelsSchema returns the schema for the computed `terraform_labels`
+// field. forceNew should be set for resources whose labels are immutable.
+func TerraformLabelsSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		ForceNew:    forceNew,
+		Description: terraformLabelsDescription,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// EffectiveLabelsSchema returns the schema for the computed `effective_labels`
+// field. forceNew should be set for resources whose labels are immutable.
+func EffectiveLabelsSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		ForceNew:    forceNew,
+		Description: effectiveLabelsDescription,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// SetLabels is called in the Read function of labelled resources to set the
+// "labels" or "terraform_labels" field (given by lineage) to the subset of
+// labels returned by the API that are present in the configuration or state.
+// Labels that are not managed by Terraform are only surfaced in
+// "effective_labels", so they don't cause a diff.
+func SetLabels(labels map[string]string, d *schema.ResourceData, lineage string) error {
+	transformed := make(map[string]interface{})
+
+	if v, ok := d.GetOk(lineage); ok {
+		if labels != nil {
+			for k := range v.(map[string]interface{}) {
+				if val, ok := labels[k]; ok {
+					transformed[k] = val
+				}
+			}
+		}
+	}
+
+	return d.Set(lineage, transformed)
+}
+
+// SetLabelsFields sets "labels", "terraform_labels" and "effective_labels"
+// from the labels returned by the API.
+func SetLabelsFields(labels map[string]string, d *schema.ResourceData) error {
+	if err := SetLabels(labels, d, "labels"); err != nil {
+		return fmt.Errorf("Error setting labels: %s", err)
+	}
+	if err := SetLabels(labels, d, "terraform_labels"); err != nil {
+		return fmt.Errorf("Error setting terraform_labels: %s", err)
+	}
+	if err := d.Set("effective_labels", labels); err != nil {
+		return fmt.Errorf("Error setting effective_labels: %s", err)
+	}
+	return nil
+}
+
+// SetDataSourceLabels sets "labels" and "terraform_labels" to the value of
+// "effective_labels" for data sources, which have no configuration to filter
+// against.
+func SetDataSourceLabels(d *schema.ResourceData) error {
+	effectiveLabels := d.Get("effective_labels")
+	if effectiveLabels == nil {
+		return nil
+	}
+
+	if err := d.Set("labels", effectiveLabels); err != nil {
+		return fmt.Errorf("Error setting labels in data source: %s", err)
+	}
+
+	if err := d.Set("terraform_labels", effectiveLabels); err != nil {
+		return fmt.Errorf("Error setting terraform_labels in data source: %s", err)
+	}
+
+	return nil
+}
+
+// SetLabelsDiff is a CustomizeDiff function that merges the provider-level
+// default labels with the resource labels into "terraform_labels", and
+// applies the change to "effective_labels" so that labels added outside of
+// Terraform are preserved.
+func SetLabelsDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := meta.(*Config)
+
+	raw := d.Get("labels")
+	if raw == nil {
+		return nil
+	}
+
+	// If "labels" is not known until apply, the merged values aren't either.
+	if rawPlan := d.GetRawPlan(); !rawPlan.IsNull() && !rawPlan.GetAttr("labels").IsWhollyKnown() {
+		if err := d.SetNewComputed("terraform_labels"); err != nil {
+			return fmt.Errorf("error setting terraform_labels to computed: %w", err)
+		}
+		if err := d.SetNewComputed("effective_labels"); err != nil {
+			return fmt.Errorf("error setting effective_labels to computed: %w", err)
+		}
+		return nil
+	}
+
+	terraformLabels := mergeDefaultLabels(config.DefaultLabels, raw.(map[string]interface{}))
+	if err := d.SetNew("terraform_labels", terraformLabels); err != nil {
+		return fmt.Errorf("error setting new terraform_labels diff: %w", err)
+	}
+
+	o, n := d.GetChange("terraform_labels")
+	effectiveLabels := applyLabelsChange(d.Get("effective_labels").(map[string]interface{}), o.(map[string]interface{}), n.(map[string]interface{}))
+	if err := d.SetNew("effective_labels", effectiveLabels); err != nil {
+		return fmt.Errorf("error setting new effective_labels diff: %w", err)
+	}
+
+	return nil
+}
+
+// mergeDefaultLabels returns the default labels overlaid with the resource
+// labels. Resource labels take precedence over default labels.
+func mergeDefaultLabels(defaultLabels map[string]string, labels map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for k, v := range defaultLabels {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return merged
+}
+
+// applyLabelsChange applies a change of the Terraform-managed labels from o to
+// n on top of the labels currently present on the resource.
+func applyLabelsChange(current, o, n map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range current {
+		result[k] = v
+	}
+	for k, v := range n {
+		result[k] = v
+	}
+	for k := range o {
+		if _, ok := n[k]; !ok {
+			delete(result, k)
+		}
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+package google
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMergeDefaultLabels(t *testing.T) {
+	cases := map[string]struct {
+		DefaultLabels map[string]string
+		Labels        map[string]interface{}
+		Expected      map[string]interface{}
+	}{
+		"no default labels": {
+			DefaultLabels: nil,
+			Labels:        map[string]interface{}{"env": "prod"},
+			Expected:      map[string]interface{}{"env": "prod"},
+		},
+		"no resource labels": {
+			DefaultLabels: map[string]string{"team": "infra"},
+			Labels:        map[string]interface{}{},
+			Expected:      map[string]interface{}{"team": "infra"},
+		},
+		"merged": {
+			DefaultLabels: map[string]string{"team": "infra", "cost_center": "123"},
+			Labels:        map[string]interface{}{"env": "prod"},
+			Expected:      map[string]interface{}{"team": "infra", "cost_center": "123", "env": "prod"},
+		},
+		"resource labels take precedence": {
+			DefaultLabels: map[string]string{"team": "infra"},
+			Labels:        map[string]interface{}{"team": "data"},
+			Expected:      map[string]interface{}{"team": "data"},
+		},
+	}
+
+	for tn, tc := range cases {
+		actual := mergeDefaultLabels(tc.DefaultLabels, tc.Labels)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Errorf("bad: %s, expected %v, got %v", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestApplyLabelsChange(t *testing.T) {
+	cases := map[string]struct {
+		Current, Old, New map[string]interface{}
+		Expected          map[string]interface{}
+	}{
+		"create": {
+			Current:  map[string]interface{}{},
+			Old:      map[string]interface{}{},
+			New:      map[string]interface{}{"env": "prod"},
+			Expected: map[string]interface{}{"env": "prod"},
+		},
+		"unmanaged labels are kept": {
+			Current:  map[string]interface{}{"env": "prod", "goog-managed": "true"},
+			Old:      map[string]interface{}{"env": "prod"},
+			New:      map[string]interface{}{"env": "prod"},
+			Expected: map[string]interface{}{"env": "prod", "goog-managed": "true"},
+		},
+		"managed label removed": {
+			Current:  map[string]interface{}{"env": "prod", "team": "infra", "goog-managed": "true"},
+			Old:      map[string]interface{}{"env": "prod", "team": "infra"},
+			New:      map[string]interface{}{"env": "prod"},
+			Expected: map[string]interface{}{"env": "prod", "goog-managed": "true"},
+		},
+		"managed label updated": {
+			Current:  map[string]interface{}{"env": "prod"},
+			Old:      map[string]interface{}{"env": "prod"},
+			New:      map[string]interface{}{"env": "dev"},
+			Expected: map[string]interface{}{"env": "dev"},
+		},
+	}
+
+	for tn, tc := range cases {
+		actual := applyLabelsChange(tc.Current, tc.Old, tc.New)
+		if !reflect.DeepEqual(actual, tc.Expected) {
+			t.Errorf("bad: %s, expected %v, got %v", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestSetLabels(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"labels": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"labels": map[string]interface{}{"env": "prod"},
+	})
+
+	if err := SetLabels(map[string]string{"env": "dev", "goog-managed": "true"}, d, "labels"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{"env": "dev"}
+	if actual := d.Get("labels").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestDefaultLabelsResources(t *testing.T) {
+	resources := Provider().ResourcesMap
+
+	// Resources added here must also be listed in the default_labels
+	// documentation of the provider reference.
+	expected := []string{
+		"google_bigtable_instance",
+		"google_cloudfunctions_function",
+		"google_composer_environment",
+		"google_compute_instance",
+		"google_compute_instance_from_machine_image",
+		"google_compute_instance_from_template",
+		"google_compute_instance_template",
+		"google_compute_region_instance_template",
+		"google_dataproc_cluster",
+		"google_dataproc_job",
+		"google_project",
+		"google_storage_bucket",
+	}
+	actual := DefaultLabelsResources(resources)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the resources applying default_labels to be %v, got %v", expected, actual)
+	}
+	for _, name := range actual {
+		r := resources[name]
+		if _, ok := r.Schema["effective_labels"]; !ok {
+			t.Errorf("%s doesn't export effective_labels", name)
+		}
+		if r.CustomizeDiff == nil {
+			t.Errorf("%s doesn't merge default labels in its diff", name)
+		}
+	}
+
+	// These are called out in the default_labels documentation.
+	expected = []string{
+		"google_compute_instance_template",
+		"google_compute_region_instance_template",
+		"google_dataproc_job",
+	}
+	if actual := ForceNewLabelsResources(resources); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the resources replaced when default_labels change to be %v, got %v", expected, actual)
+	}
+}
@@ -53,7 +53,7 @@ func LoggingOperationWaitTimeWithResponse(config *Config, op map[string]interfac
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("logging")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -69,5 +69,5 @@ func LoggingOperationWaitTime(config *Config, op map[string]interface{}, activit
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("logging"))
 }
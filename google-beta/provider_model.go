@@ -16,10 +16,18 @@ type ProviderModel struct {
 	Region                             types.String `tfsdk:"region"`
 	Zone                               types.String `tfsdk:"zone"`
 	Scopes                             types.List   `tfsdk:"scopes"`
+	ExternalAccount                    types.List   `tfsdk:"external_account"`
 	Batching                           types.List   `tfsdk:"batching"`
+	Retry                              types.List   `tfsdk:"retry"`
+	RateLimit                          types.List   `tfsdk:"rate_limit"`
+	AuditLog                           types.List   `tfsdk:"audit_log"`
+	Polling                            types.List   `tfsdk:"polling"`
 	UserProjectOverride                types.Bool   `tfsdk:"user_project_override"`
 	RequestTimeout                     types.String `tfsdk:"request_timeout"`
 	RequestReason                      types.String `tfsdk:"request_reason"`
+	IamPolicyVerification              types.String `tfsdk:"iam_policy_verification"`
+	DisruptiveUpdatesAsErrors          types.Bool   `tfsdk:"disruptive_updates_as_errors"`
+	DefaultLabels                      types.Map    `tfsdk:"default_labels"`
 
 	// Generated Products
 	AccessApprovalCustomEndpoint       types.String `tfsdk:"access_approval_custom_endpoint"`
@@ -151,17 +159,62 @@ type ProviderModel struct {
 	GkehubFeatureCustomEndpoint        types.String `tfsdk:"gkehub_feature_custom_endpoint"`
 }
 
+type ProviderExternalAccount struct {
+	Audience            types.String `tfsdk:"audience"`
+	SubjectTokenType    types.String `tfsdk:"subject_token_type"`
+	TokenURL            types.String `tfsdk:"token_url"`
+	SubjectTokenFile    types.String `tfsdk:"subject_token_file"`
+	SubjectTokenURL     types.String `tfsdk:"subject_token_url"`
+	SubjectTokenHeaders types.Map    `tfsdk:"subject_token_headers"`
+	SubjectTokenCommand types.List   `tfsdk:"subject_token_command"`
+	SubjectTokenFormat  types.String `tfsdk:"subject_token_format"`
+	SubjectTokenField   types.String `tfsdk:"subject_token_field"`
+}
+
 type ProviderBatching struct {
-	SendAfter      types.String `tfsdk:"send_after"`
-	EnableBatching types.Bool   `tfsdk:"enable_batching"`
+	SendAfter           types.String `tfsdk:"send_after"`
+	EnableBatching      types.Bool   `tfsdk:"enable_batching"`
+	MaxBatchSize        types.Int64  `tfsdk:"max_batch_size"`
+	MaxConcurrentPerKey types.Int64  `tfsdk:"max_concurrent_per_key"`
 }
 
 var ProviderBatchingAttributes = map[string]attr.Type{
-	"send_after":      types.StringType,
-	"enable_batching": types.BoolType,
+	"send_after":             types.StringType,
+	"enable_batching":        types.BoolType,
+	"max_batch_size":         types.Int64Type,
+	"max_concurrent_per_key": types.Int64Type,
+}
+
+type ProviderRetry struct {
+	MaxAttempts     types.Int64  `tfsdk:"max_attempts"`
+	InitialBackoff  types.String `tfsdk:"initial_backoff"`
+	MaxBackoff      types.String `tfsdk:"max_backoff"`
+	BackoffStrategy types.String `tfsdk:"backoff_strategy"`
+	Jitter          types.Bool   `tfsdk:"jitter"`
+	Timeout         types.String `tfsdk:"timeout"`
+}
+
+type ProviderRateLimit struct {
+	Host              types.String  `tfsdk:"host"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+}
+
+type ProviderAuditLog struct {
+	Path         types.String `tfsdk:"path"`
+	RedactFields types.List   `tfsdk:"redact_fields"`
+}
+
+type ProviderPolling struct {
+	Service         types.String  `tfsdk:"service"`
+	InitialInterval types.String  `tfsdk:"initial_interval"`
+	MaxInterval     types.String  `tfsdk:"max_interval"`
+	Multiplier      types.Float64 `tfsdk:"multiplier"`
 }
 
 // ProviderMetaModel describes the provider meta model
 type ProviderMetaModel struct {
-	ModuleName types.String `tfsdk:"module_name"`
+	ModuleName                         types.String `tfsdk:"module_name"`
+	ImpersonateServiceAccount          types.String `tfsdk:"impersonate_service_account"`
+	ImpersonateServiceAccountDelegates types.List   `tfsdk:"impersonate_service_account_delegates"`
 }
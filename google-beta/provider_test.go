@@ -1,14 +1,20 @@
 package google
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/oauth2"
 )
 
 func TestProvider(t *testing.T) {
@@ -17,6 +23,87 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProvider_providerMetaImpersonation(t *testing.T) {
+	var got interface{}
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			got = meta
+			return nil
+		},
+	}
+	withProviderMetaImpersonation(r)
+
+	config := &Config{
+		Scopes:              DefaultClientScopes,
+		BatchingConfig:      &batchingConfig{SendAfter: time.Second},
+		context:             context.Background(),
+		tokenSource:         oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "provider-token"}),
+		identity:            "provider@example.com",
+		impersonatedConfigs: &impersonatedConfigCache{configs: make(map[string]*Config)},
+	}
+	d := r.TestResourceData()
+	if err := r.Read(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != config {
+		t.Errorf("expected the provider Config without provider_meta, got %v", got)
+	}
+
+	providerMeta := func(serviceAccount string, delegates ...string) cty.Value {
+		delegatesVal := cty.NullVal(cty.List(cty.String))
+		if len(delegates) > 0 {
+			var vals []cty.Value
+			for _, d := range delegates {
+				vals = append(vals, cty.StringVal(d))
+			}
+			delegatesVal = cty.ListVal(vals)
+		}
+		serviceAccountVal := cty.NullVal(cty.String)
+		if serviceAccount != "" {
+			serviceAccountVal = cty.StringVal(serviceAccount)
+		}
+		return cty.ObjectVal(map[string]cty.Value{
+			"module_name":                           cty.StringVal("my-module"),
+			"impersonate_service_account":           serviceAccountVal,
+			"impersonate_service_account_delegates": delegatesVal,
+		})
+	}
+	refresh := func(meta cty.Value) *Config {
+		t.Helper()
+		got = nil
+		state := &terraform.InstanceState{ID: "id", Attributes: map[string]string{"id": "id"}, ProviderMeta: meta}
+		if _, diags := r.RefreshWithoutUpgrade(context.Background(), state, config); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return got.(*Config)
+	}
+
+	if c := refresh(providerMeta("")); c != config {
+		t.Errorf("expected the provider Config with provider_meta setting only module_name, got %+v", c)
+	}
+
+	tenant := "tenant@my-project.iam.gserviceaccount.com"
+	impersonated := refresh(providerMeta(tenant))
+	if impersonated == config || impersonated.identity != tenant || impersonated.ImpersonateServiceAccount != tenant {
+		t.Errorf("expected a Config impersonating %s, got %+v", tenant, impersonated)
+	}
+	if c := refresh(providerMeta(tenant)); c != impersonated {
+		t.Errorf("expected the impersonated Config to be shared by resources using the same service account")
+	}
+
+	delegate := "delegate@my-project.iam.gserviceaccount.com"
+	delegated := refresh(providerMeta(tenant, delegate))
+	if delegated == impersonated || !reflect.DeepEqual(delegated.ImpersonateServiceAccountDelegates, []string{delegate}) {
+		t.Errorf("expected a Config impersonating %s through %s, got %+v", tenant, delegate, delegated)
+	}
+}
+
 func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
@@ -0,0 +1,173 @@
+// A http.RoundTripper that throttles requests per API host using token buckets.
+//
+// The limiters are created once per Config in LoadAndValidate, so every client
+// created from the Config (the New*Client helpers, SendRequest, ...) shares
+// the same buckets.
+
+package google
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig configures a token bucket for the requests sent to a host.
+type RateLimitConfig struct {
+	// Host is either a hostname, e.g. "compute.googleapis.com", or a base
+	// URL, e.g. the value of a *BasePath, that requests are matched against.
+	Host string
+	// RequestsPerSecond is the rate at which tokens are added to the bucket.
+	RequestsPerSecond float64
+	// Burst is the size of the bucket. Defaults to RequestsPerSecond rounded up.
+	Burst int
+}
+
+func (r RateLimitConfig) matches(req *http.Request) bool {
+	if strings.Contains(r.Host, "://") {
+		return strings.HasPrefix(req.URL.String(), r.Host)
+	}
+	return strings.EqualFold(req.URL.Hostname(), r.Host)
+}
+
+// tokenBucket is a minimal token bucket rate limiter. Waiters reserve a token
+// up front, so concurrent requests are served in the order they arrived.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = int(math.Ceil(rate))
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller has
+// to wait before the token is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token to the bucket, e.g. if the request was
+// cancelled while waiting.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// Wait blocks until a token is available or the context is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	wait := b.reserve(time.Now())
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type hostRateLimiter struct {
+	config RateLimitConfig
+	bucket *tokenBucket
+}
+
+// rateLimiters holds the token buckets shared by all clients of a Config.
+type rateLimiters struct {
+	limiters []*hostRateLimiter
+}
+
+func newRateLimiters(configs []RateLimitConfig) *rateLimiters {
+	rl := &rateLimiters{}
+	for _, c := range configs {
+		if c.RequestsPerSecond <= 0 {
+			continue
+		}
+		rl.limiters = append(rl.limiters, &hostRateLimiter{
+			config: c,
+			bucket: newTokenBucket(c.RequestsPerSecond, c.Burst),
+		})
+	}
+	return rl
+}
+
+// limiterFor returns the limiter of the first config matching the request,
+// or nil if the request isn't rate limited.
+func (rl *rateLimiters) limiterFor(req *http.Request) *hostRateLimiter {
+	for _, l := range rl.limiters {
+		if l.config.matches(req) {
+			return l
+		}
+	}
+	return nil
+}
+
+type rateLimitTransport struct {
+	limiters *rateLimiters
+	internal http.RoundTripper
+}
+
+// newTransportWithRateLimits wraps the given transport with the given shared
+// limiters. If there are no limiters, the transport is returned as is.
+func newTransportWithRateLimits(t http.RoundTripper, limiters *rateLimiters) http.RoundTripper {
+	if limiters == nil || len(limiters.limiters) == 0 {
+		return t
+	}
+	return &rateLimitTransport{
+		limiters: limiters,
+		internal: t,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface method.
+// It waits for a token from the limiter matching the request host, if any,
+// before sending the request.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if l := t.limiters.limiterFor(req); l != nil {
+		start := time.Now()
+		if err := l.bucket.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		if waited := time.Since(start); waited > time.Second {
+			log.Printf("[DEBUG] Rate Limit Transport: waited %s for a token for %s", waited, l.config.Host)
+		}
+	}
+	return t.internal.RoundTrip(req)
+}
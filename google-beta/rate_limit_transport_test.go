@@ -0,0 +1,148 @@
+package google
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitConfig_matches(t *testing.T) {
+	cases := map[string]struct {
+		Host     string
+		URL      string
+		Expected bool
+	}{
+		"host": {
+			Host:     "compute.googleapis.com",
+			URL:      "https://compute.googleapis.com/compute/beta/projects/p/zones/z/instances",
+			Expected: true,
+		},
+		"host case insensitive": {
+			Host:     "Compute.googleapis.com",
+			URL:      "https://compute.googleapis.com/compute/beta/",
+			Expected: true,
+		},
+		"other host": {
+			Host:     "compute.googleapis.com",
+			URL:      "https://storage.googleapis.com/storage/v1/b",
+			Expected: false,
+		},
+		"base path": {
+			Host:     "https://www.googleapis.com/storage/v1/",
+			URL:      "https://www.googleapis.com/storage/v1/b/bucket",
+			Expected: true,
+		},
+		"other base path on same host": {
+			Host:     "https://www.googleapis.com/storage/v1/",
+			URL:      "https://www.googleapis.com/compute/v1/projects",
+			Expected: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		req, err := http.NewRequest("GET", tc.URL, nil)
+		if err != nil {
+			t.Fatalf("bad: %s, unexpected error: %s", tn, err)
+		}
+		if actual := (RateLimitConfig{Host: tc.Host}).matches(req); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %t, got %t", tn, tc.Expected, actual)
+		}
+	}
+}
+
+func TestTokenBucket_reserve(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(2, 2)
+	b.last = now
+
+	// The bucket starts full.
+	for i := 0; i < 2; i++ {
+		if wait := b.reserve(now); wait != 0 {
+			t.Fatalf("expected token %d to be available, got wait of %s", i, wait)
+		}
+	}
+
+	// Waiters queue up behind each other.
+	if wait := b.reserve(now); wait != 500*time.Millisecond {
+		t.Fatalf("expected wait of 500ms, got %s", wait)
+	}
+	if wait := b.reserve(now); wait != time.Second {
+		t.Fatalf("expected wait of 1s, got %s", wait)
+	}
+
+	// Tokens are refilled over time, but never beyond the burst size.
+	later := now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		if wait := b.reserve(later); wait != 0 {
+			t.Fatalf("expected token %d to be available, got wait of %s", i, wait)
+		}
+	}
+	if wait := b.reserve(later); wait == 0 {
+		t.Fatalf("expected bucket to be empty")
+	}
+}
+
+func TestTokenBucket_defaultBurst(t *testing.T) {
+	if b := newTokenBucket(2.5, 0); b.burst != 3 {
+		t.Fatalf("expected burst of 3, got %v", b.burst)
+	}
+	if b := newTokenBucket(0.1, 0); b.burst != 1 {
+		t.Fatalf("expected burst of 1, got %v", b.burst)
+	}
+}
+
+func TestTokenBucket_WaitContextCancelled(t *testing.T) {
+	b := newTokenBucket(0.01, 1)
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); err == nil {
+		t.Fatalf("expected error waiting for token with cancelled context")
+	}
+}
+
+func TestRateLimitTransport_sharedLimiter(t *testing.T) {
+	var count int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	limiters := newRateLimiters([]RateLimitConfig{{Host: "127.0.0.1", RequestsPerSecond: 20, Burst: 1}})
+
+	// Two clients sharing the same limiters, as clients created from the same Config do.
+	clientA := &http.Client{Transport: newTransportWithRateLimits(http.DefaultTransport, limiters)}
+	clientB := &http.Client{Transport: newTransportWithRateLimits(http.DefaultTransport, limiters)}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		for _, c := range []*http.Client{clientA, clientB} {
+			resp, err := c.Get(ts.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+		}
+	}
+
+	// 6 requests with a burst of 1 at 20/s need at least 5 * 50ms.
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, took %s", elapsed)
+	}
+	if count != 6 {
+		t.Fatalf("expected 6 requests, got %d", count)
+	}
+}
+
+func TestRateLimitTransport_noLimiters(t *testing.T) {
+	if tr := newTransportWithRateLimits(http.DefaultTransport, newRateLimiters(nil)); tr != http.DefaultTransport {
+		t.Fatalf("expected transport to be returned unchanged")
+	}
+}
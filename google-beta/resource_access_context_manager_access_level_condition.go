@@ -279,7 +279,7 @@ func resourceAccessContextManagerAccessLevelConditionCreate(d *schema.ResourceDa
 	}
 	d.SetId(id)
 
-	err = PollingWaitTime(resourceAccessContextManagerAccessLevelConditionPollRead(d, meta), PollCheckForExistence, "Creating AccessLevelCondition", d.Timeout(schema.TimeoutCreate), 1)
+	err = PollingWaitTime(resourceAccessContextManagerAccessLevelConditionPollRead(d, meta), PollCheckForExistence, "Creating AccessLevelCondition", d.Timeout(schema.TimeoutCreate), 1, config.Polling.For("access_context_manager"))
 	if err != nil {
 		return fmt.Errorf("Error waiting to create AccessLevelCondition: %s", err)
 	}
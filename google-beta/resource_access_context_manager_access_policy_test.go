@@ -6,6 +6,7 @@ import (
 	"log"
 	neturl "net/url"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -62,6 +63,13 @@ func testSweepAccessContextManagerPolicies(region string) error {
 	}
 
 	policy := policies[0].(map[string]interface{})
+	if created, ok := sweepCreationTime(policy); ok && time.Since(created) < sweepMinAge() {
+		log.Printf("[DEBUG] Access Policy %q may belong to a test that is still running, skipping", policy["name"])
+		return nil
+	}
+	if skipSweepInDryRun("gcp_access_context_manager_policy", policy["name"].(string)) {
+		return nil
+	}
 	log.Printf("[DEBUG] Deleting test Access Policies %q", policy["name"])
 
 	policyUrl := config.AccessContextManagerBasePath + policy["name"].(string)
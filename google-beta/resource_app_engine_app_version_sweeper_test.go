@@ -59,11 +59,15 @@ func testSweepAppEngineAppVersion(region string) error {
 
 		id := obj["id"].(string)
 		// Increment count and skip if resource is not sweepable.
-		if !IsSweepableTestResource(id) {
+		if !IsSweepableTestObject(id, obj) {
 			nonPrefixCount++
 			continue
 		}
 
+		if skipSweepInDryRun(resourceName, id) {
+			continue
+		}
+
 		deleteUrl := servicesUrl + "/" + id
 		// Don't wait on operations as we may have a lot to delete
 		_, err = SendRequest(config, "DELETE", config.Project, deleteUrl, config.UserAgent, nil)
@@ -61,11 +61,15 @@ func testSweepBigqueryReservation(region string) error {
 		reservationNameParts := strings.Split(reservationName, "/")
 		reservationShortName := reservationNameParts[len(reservationNameParts)-1]
 		// Increment count and skip if resource is not sweepable.
-		if !IsSweepableTestResource(reservationShortName) {
+		if !IsSweepableTestObject(reservationShortName, obj) {
 			nonPrefixCount++
 			continue
 		}
 
+		if skipSweepInDryRun(resourceName, reservationShortName) {
+			continue
+		}
+
 		deleteAllAssignments(config, reservationName)
 
 		deleteUrl := servicesUrl + "/" + reservationShortName
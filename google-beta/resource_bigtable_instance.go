@@ -25,6 +25,7 @@ func ResourceBigtableInstance() *schema.Resource {
 
 		CustomizeDiff: customdiff.All(
 			resourceBigtableInstanceClusterReorderTypeList,
+			SetLabelsDiff,
 		),
 
 		SchemaVersion: 1,
@@ -148,6 +149,10 @@ func ResourceBigtableInstance() *schema.Resource {
 				Description: `A mapping of labels to assign to the resource.`,
 			},
 
+			"terraform_labels": TerraformLabelsSchema(false),
+
+			"effective_labels": EffectiveLabelsSchema(false),
+
 			"project": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -184,7 +189,7 @@ func resourceBigtableInstanceCreate(d *schema.ResourceData, meta interface{}) er
 	}
 	conf.DisplayName = displayName.(string)
 
-	if _, ok := d.GetOk("labels"); ok {
+	if _, ok := d.GetOk("effective_labels"); ok {
 		conf.Labels = expandLabels(d)
 	}
 
@@ -279,8 +284,8 @@ func resourceBigtableInstanceRead(d *schema.ResourceData, meta interface{}) erro
 	if err := d.Set("display_name", instance.DisplayName); err != nil {
 		return fmt.Errorf("Error setting display_name: %s", err)
 	}
-	if err := d.Set("labels", instance.Labels); err != nil {
-		return fmt.Errorf("Error setting labels: %s", err)
+	if err := SetLabelsFields(instance.Labels, d); err != nil {
+		return err
 	}
 	// Don't set instance_type: we don't want to detect drift on it because it can
 	// change under-the-hood.
@@ -317,7 +322,7 @@ func resourceBigtableInstanceUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 	conf.DisplayName = displayName.(string)
 
-	if d.HasChange("labels") {
+	if d.HasChange("effective_labels") {
 		conf.Labels = expandLabels(d)
 	}
 
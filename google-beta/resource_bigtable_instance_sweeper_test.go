@@ -60,11 +60,15 @@ func testSweepBigtableInstance(region string) error {
 
 		id := obj["displayName"].(string)
 		// Increment count and skip if resource is not sweepable.
-		if !IsSweepableTestResource(id) {
+		if !IsSweepableTestObject(id, obj) {
 			nonPrefixCount++
 			continue
 		}
 
+		if skipSweepInDryRun(resourceName, id) {
+			continue
+		}
+
 		deleteUrl := servicesUrl + "/" + id
 		// Don't wait on operations as we may have a lot to delete
 		_, err = SendRequest(config, "DELETE", config.Project, deleteUrl, config.UserAgent, nil)
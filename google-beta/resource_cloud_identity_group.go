@@ -233,7 +233,7 @@ func resourceCloudIdentityGroupCreate(d *schema.ResourceData, meta interface{})
 	}
 	d.SetId(name.(string))
 
-	err = PollingWaitTime(resourceCloudIdentityGroupPollRead(d, meta), PollCheckForExistenceWith403, "Creating Group", d.Timeout(schema.TimeoutCreate), 10)
+	err = PollingWaitTime(resourceCloudIdentityGroupPollRead(d, meta), PollCheckForExistenceWith403, "Creating Group", d.Timeout(schema.TimeoutCreate), 10, config.Polling.For("cloud_identity"))
 	if err != nil {
 		return fmt.Errorf("Error waiting to create Group: %s", err)
 	}
@@ -392,7 +392,7 @@ func resourceCloudIdentityGroupUpdate(d *schema.ResourceData, meta interface{})
 		log.Printf("[DEBUG] Finished updating Group %q: %#v", d.Id(), res)
 	}
 
-	err = PollingWaitTime(resourceCloudIdentityGroupPollRead(d, meta), PollCheckForExistenceWith403, "Updating Group", d.Timeout(schema.TimeoutUpdate), 10)
+	err = PollingWaitTime(resourceCloudIdentityGroupPollRead(d, meta), PollCheckForExistenceWith403, "Updating Group", d.Timeout(schema.TimeoutUpdate), 10, config.Polling.For("cloud_identity"))
 	if err != nil {
 		return err
 	}
@@ -427,7 +427,7 @@ func resourceCloudIdentityGroupDelete(d *schema.ResourceData, meta interface{})
 		return handleNotFoundError(err, d, "Group")
 	}
 
-	err = PollingWaitTime(resourceCloudIdentityGroupPollRead(d, meta), PollCheckForAbsenceWith403, "Deleting Group", d.Timeout(schema.TimeoutCreate), 10)
+	err = PollingWaitTime(resourceCloudIdentityGroupPollRead(d, meta), PollCheckForAbsenceWith403, "Deleting Group", d.Timeout(schema.TimeoutCreate), 10, config.Polling.For("cloud_identity"))
 	if err != nil {
 		return fmt.Errorf("Error waiting to delete Group: %s", err)
 	}
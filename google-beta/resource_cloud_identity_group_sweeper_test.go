@@ -81,7 +81,7 @@ func testSweepCloudIdentityGroup(region string) error {
 
 		name := obj["name"].(string)
 		// Skip resources that shouldn't be sweeped
-		if !IsSweepableTestResource(obj["displayName"].(string)) {
+		if !IsSweepableTestObject(obj["displayName"].(string), obj) {
 			nonPrefixCount++
 			continue
 		}
@@ -94,6 +94,10 @@ func testSweepCloudIdentityGroup(region string) error {
 		}
 		deleteUrl = deleteUrl + name
 
+		if skipSweepInDryRun(resourceName, name) {
+			continue
+		}
+
 		// Don't wait on operations as we may have a lot to delete
 		_, err = SendRequest(config, "DELETE", config.Project, deleteUrl, config.UserAgent, nil)
 		if err != nil {
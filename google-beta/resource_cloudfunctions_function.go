@@ -129,6 +129,8 @@ func ResourceCloudFunctionsFunction() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: SetLabelsDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Read:   schema.DefaultTimeout(5 * time.Minute),
@@ -255,6 +257,10 @@ func ResourceCloudFunctionsFunction() *schema.Resource {
 				Description:  `A set of key/value label pairs to assign to the function. Label keys must follow the requirements at https://cloud.google.com/resource-manager/docs/creating-managing-labels#requirements.`,
 			},
 
+			"terraform_labels": TerraformLabelsSchema(false),
+
+			"effective_labels": EffectiveLabelsSchema(false),
+
 			"runtime": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -548,7 +554,7 @@ func resourceCloudFunctionsCreate(d *schema.ResourceData, meta interface{}) erro
 		function.IngressSettings = v.(string)
 	}
 
-	if _, ok := d.GetOk("labels"); ok {
+	if _, ok := d.GetOk("effective_labels"); ok {
 		function.Labels = expandLabels(d)
 	}
 
@@ -656,8 +662,8 @@ func resourceCloudFunctionsRead(d *schema.ResourceData, meta interface{}) error
 	if err := d.Set("ingress_settings", function.IngressSettings); err != nil {
 		return fmt.Errorf("Error setting ingress_settings: %s", err)
 	}
-	if err := d.Set("labels", function.Labels); err != nil {
-		return fmt.Errorf("Error setting labels: %s", err)
+	if err := SetLabelsFields(function.Labels, d); err != nil {
+		return err
 	}
 	if err := d.Set("runtime", function.Runtime); err != nil {
 		return fmt.Errorf("Error setting runtime: %s", err)
@@ -821,7 +827,7 @@ func resourceCloudFunctionsUpdate(d *schema.ResourceData, meta interface{}) erro
 		updateMaskArr = append(updateMaskArr, "ingressSettings")
 	}
 
-	if d.HasChange("labels") {
+	if d.HasChange("effective_labels") {
 		function.Labels = expandLabels(d)
 		updateMaskArr = append(updateMaskArr, "labels")
 	}
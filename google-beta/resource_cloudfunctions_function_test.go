@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"archive/zip"
 	"io/ioutil"
@@ -901,6 +902,10 @@ func sweepCloudFunctionSourceZipArchives(_ string) error {
 			continue
 		}
 		if strings.HasPrefix(f.Name(), testFunctionsSourceArchivePrefix) {
+			// Archives of tests that may still be running are kept.
+			if time.Since(f.ModTime()) < sweepMinAge() || skipSweepInDryRun("gcp_cloud_function_source_archive", f.Name()) {
+				continue
+			}
 			filepath := fmt.Sprintf("%s/%s", os.TempDir(), f.Name())
 			if err := os.Remove(filepath); err != nil {
 				log.Printf("Error removing files: %s", err)
@@ -134,6 +134,8 @@ func ResourceComposerEnvironment() *schema.Resource {
 			State: resourceComposerEnvironmentImport,
 		},
 
+		CustomizeDiff: SetLabelsDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			// Composer takes <= 1 hr for create/update.
 			Create: schema.DefaultTimeout(120 * time.Minute),
@@ -834,6 +836,8 @@ func ResourceComposerEnvironment() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: `User-defined labels for this environment. The labels map can contain no more than 64 entries. Entries of the labels map are UTF8 strings that comply with the following restrictions: Label keys must be between 1 and 63 characters long and must conform to the following regular expression: [a-z]([-a-z0-9]*[a-z0-9])?. Label values must be between 0 and 63 characters long and must conform to the regular expression ([a-z]([-a-z0-9]*[a-z0-9])?)?. No more than 64 labels can be associated with a given environment. Both keys and values must be <= 128 bytes in size.`,
 			},
+			"terraform_labels": TerraformLabelsSchema(false),
+			"effective_labels": EffectiveLabelsSchema(false),
 		},
 		UseJSONNumber: true,
 	}
@@ -936,7 +940,7 @@ func resourceComposerEnvironmentRead(d *schema.ResourceData, meta interface{}) e
 	if err := d.Set("config", flattenComposerEnvironmentConfig(res.Config)); err != nil {
 		return fmt.Errorf("Error setting Environment: %s", err)
 	}
-	if err := d.Set("labels", res.Labels); err != nil {
+	if err := SetLabelsFields(res.Labels, d); err != nil {
 		return fmt.Errorf("Error setting Environment: %s", err)
 	}
 	return nil
@@ -1162,7 +1166,7 @@ func resourceComposerEnvironmentUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	if d.HasChange("labels") {
+	if d.HasChange("effective_labels") {
 		patchEnv := &composer.Environment{Labels: expandLabels(d)}
 		err := resourceComposerEnvironmentPatchField("labels", userAgent, patchEnv, d, tfConfig)
 		if err != nil {
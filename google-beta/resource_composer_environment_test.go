@@ -2403,6 +2403,9 @@ func testSweepComposerEnvironments(config *Config, region string) error {
 		case "ERROR":
 			fallthrough
 		default:
+			if skipSweepInDryRun("gcp_composer_environment", e.Name) {
+				continue
+			}
 			op, deleteErr := config.NewComposerClient(config.UserAgent).Projects.Locations.Environments.Delete(e.Name).Do()
 			if deleteErr != nil {
 				allErrors = multierror.Append(allErrors, fmt.Errorf("composer: unable to delete environment %q: %s", e.Name, deleteErr))
@@ -2451,6 +2454,14 @@ func testSweepComposerEnvironmentBuckets(config *Config, region string) error {
 }
 
 func testSweepComposerEnvironmentCleanUpBucket(config *Config, bucket *storage.Bucket) error {
+	if created, err := time.Parse(time.RFC3339Nano, bucket.TimeCreated); err == nil && time.Since(created) < sweepMinAge() {
+		log.Printf("composer: skipped bucket %q, it may belong to a test that is still running", bucket.Name)
+		return nil
+	}
+	if skipSweepInDryRun("gcp_composer_environment", bucket.Name) {
+		return nil
+	}
+
 	var allErrors error
 	objList, err := config.NewStorageClient(config.UserAgent).Objects.List(bucket.Name).Do()
 	if err != nil {
@@ -542,6 +542,10 @@ func ResourceComputeInstance() *schema.Resource {
 				Description: `A set of key/value label pairs assigned to the instance.`,
 			},
 
+			"terraform_labels": TerraformLabelsSchema(false),
+
+			"effective_labels": EffectiveLabelsSchema(false),
+
 			"metadata": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -947,6 +951,8 @@ be from 0 to 999,999,999 inclusive.`,
 			),
 			desiredStatusDiff,
 			forceNewIfNetworkIPNotUpdatable,
+			SetLabelsDiff,
+			customizeDiffDisruptiveUpdates(computeInstanceDisruptiveUpdates),
 		),
 		UseJSONNumber: true,
 	}
@@ -1274,7 +1280,7 @@ func resourceComputeInstanceRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	if err := d.Set("labels", instance.Labels); err != nil {
+	if err := SetLabelsFields(instance.Labels, d); err != nil {
 		return err
 	}
 
@@ -1551,7 +1557,7 @@ func resourceComputeInstanceUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	if d.HasChange("labels") {
+	if d.HasChange("effective_labels") {
 		labels := expandLabels(d)
 		labelFingerprint := d.Get("label_fingerprint").(string)
 		req := compute.InstancesSetLabelsRequest{Labels: labels, LabelFingerprint: labelFingerprint}
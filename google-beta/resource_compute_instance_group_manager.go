@@ -584,19 +584,11 @@ func resourceComputeInstanceGroupManagerCreate(d *schema.ResourceData, meta inte
 	// Wait for the operation to complete
 	err = ComputeOperationWaitTime(config, op, project, "Creating InstanceGroupManager", userAgent, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		// Check if the create operation failed because Terraform was prematurely terminated. If it was we can persist the
-		// operation id to state so that a subsequent refresh of this resource will wait until the operation has terminated
-		// before attempting to Read the state of the manager. This allows a graceful resumption of a Create that was killed
-		// by the upstream Terraform process exiting early such as a sigterm.
-		select {
-		case <-config.context.Done():
-			log.Printf("[DEBUG] Persisting %s so this operation can be resumed \n", op.Name)
-			if err := d.Set("operation", op.Name); err != nil {
-				return fmt.Errorf("Error setting operation: %s", err)
-			}
-			return nil
-		default:
-			// leaving default case to ensure this is non blocking
+		// Check if the create operation failed because Terraform was prematurely terminated or the wait timed out. If it
+		// did we can persist the operation id to state so that a subsequent refresh of this resource will wait until the
+		// operation has terminated before attempting to Read the state of the manager.
+		if persisted, perr := PersistPendingOperation(d, config, op.Name, err); perr != nil || persisted {
+			return perr
 		}
 		return err
 	}
@@ -692,24 +684,19 @@ func resourceComputeInstanceGroupManagerRead(d *schema.ResourceData, meta interf
 		return err
 	}
 
-	operation := d.Get("operation").(string)
-	if operation != "" {
-		log.Printf("[DEBUG] in progress operation detected at %v, attempting to resume", operation)
+	err = ResumePendingOperation(d, config, func(opName string) error {
 		zone, _ := getZone(d, config)
 		op := &compute.Operation{
-			Name: operation,
+			Name: opName,
 			Zone: zone,
 		}
-		if err := d.Set("operation", op.Name); err != nil {
-			return fmt.Errorf("Error setting operation: %s", err)
-		}
-		err = ComputeOperationWaitTime(config, op, project, "Creating InstanceGroupManager", userAgent, d.Timeout(schema.TimeoutCreate))
-		if err != nil {
-			// remove from state to allow refresh to finish
-			log.Printf("[DEBUG] Resumed operation returned an error, removing from state: %s", err)
-			d.SetId("")
-			return nil
-		}
+		return ComputeOperationWaitTime(config, op, project, "Creating InstanceGroupManager", userAgent, d.Timeout(schema.TimeoutCreate))
+	})
+	if err != nil {
+		// remove from state to allow refresh to finish
+		log.Printf("[DEBUG] Resumed operation returned an error, removing from state: %s", err)
+		d.SetId("")
+		return nil
 	}
 
 	manager, err := getManager(d, meta)
@@ -45,11 +45,15 @@ func testSweepComputeInstanceGroupManager(region string) error {
 	nonPrefixCount := 0
 	for zone, itemList := range found.Items {
 		for _, igm := range itemList.InstanceGroupManagers {
-			if !IsSweepableTestResource(igm.Name) {
+			if !IsSweepableTestResourceCreatedAt(igm.Name, igm.CreationTimestamp, nil) {
 				nonPrefixCount++
 				continue
 			}
 
+			if skipSweepInDryRun(resourceName, igm.Name) {
+				continue
+			}
+
 			// Don't wait on operations as we may have a lot to delete
 			_, err := config.NewComputeClient(config.UserAgent).InstanceGroupManagers.Delete(config.Project, GetResourceNameFromSelfLink(zone), igm.Name).Do()
 			if err != nil {
@@ -52,6 +52,7 @@ func ResourceComputeInstanceTemplate() *schema.Resource {
 			resourceComputeInstanceTemplateSourceImageCustomizeDiff,
 			resourceComputeInstanceTemplateScratchDiskCustomizeDiff,
 			resourceComputeInstanceTemplateBootDiskCustomizeDiff,
+			SetLabelsDiff,
 		),
 		MigrateState: resourceComputeInstanceTemplateMigrateState,
 
@@ -840,6 +841,10 @@ be from 0 to 999,999,999 inclusive.`,
 				Description: `A set of key/value label pairs to assign to instances created from this template,`,
 			},
 
+			"terraform_labels": TerraformLabelsSchema(true),
+
+			"effective_labels": EffectiveLabelsSchema(true),
+
 			"resource_policies": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -1216,7 +1221,7 @@ func resourceComputeInstanceTemplateCreate(d *schema.ResourceData, meta interfac
 		ReservationAffinity:        reservationAffinity,
 	}
 
-	if _, ok := d.GetOk("labels"); ok {
+	if _, ok := d.GetOk("effective_labels"); ok {
 		instanceProperties.Labels = expandLabels(d)
 	}
 
@@ -1528,8 +1533,8 @@ func resourceComputeInstanceTemplateRead(d *schema.ResourceData, meta interface{
 		}
 	}
 	if instanceTemplate.Properties.Labels != nil {
-		if err := d.Set("labels", instanceTemplate.Properties.Labels); err != nil {
-			return fmt.Errorf("Error setting labels: %s", err)
+		if err := SetLabelsFields(instanceTemplate.Properties.Labels, d); err != nil {
+			return err
 		}
 	}
 	if err = d.Set("self_link", instanceTemplate.SelfLink); err != nil {
@@ -10,8 +10,9 @@ import (
 // This will sweep Compute Instance Templates
 func init() {
 	resource.AddTestSweepers("ComputeInstanceTemplate", &resource.Sweeper{
-		Name: "ComputeInstanceTemplate",
-		F:    testSweepComputeInstanceTemplate,
+		Name:         "ComputeInstanceTemplate",
+		Dependencies: sweeperDependencies["ComputeInstanceTemplate"],
+		F:            testSweepComputeInstanceTemplate,
 	})
 }
 
@@ -48,12 +49,20 @@ func testSweepComputeInstanceTemplate(region string) error {
 	// Count items that weren't sweeped.
 	nonPrefixCount := 0
 	for _, instanceTemplate := range instanceTemplates.Items {
+		var labels map[string]string
+		if instanceTemplate.Properties != nil {
+			labels = instanceTemplate.Properties.Labels
+		}
 		// Increment count and skip if resource is not sweepable.
-		if !IsSweepableTestResource(instanceTemplate.Name) {
+		if !IsSweepableTestResourceCreatedAt(instanceTemplate.Name, instanceTemplate.CreationTimestamp, labels) {
 			nonPrefixCount++
 			continue
 		}
 
+		if skipSweepInDryRun(resourceName, instanceTemplate.Name) {
+			continue
+		}
+
 		// Don't wait on operations as we may have a lot to delete
 		_, err := config.NewComputeClient(config.UserAgent).InstanceTemplates.Delete(config.Project, instanceTemplate.Name).Do()
 		if err != nil {
@@ -312,3 +312,68 @@ resource "google_compute_network" "bar" {
 }
 `, suffix)
 }
+
+func TestFakeGcpComputeNetwork_subnetworkAndFirewall(t *testing.T) {
+	srv := newFakeGcpServer(t)
+
+	fakeGcpTest(t, srv, resource.TestCase{
+		CheckDestroy: fakeGcpCheckNoResources(srv, "compute/projects/fake-project/global/networks/"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeGcpComputeNetwork_subnetworkAndFirewall(false),
+				Check:  resource.TestCheckResourceAttr("google_compute_subnetwork.subnetwork", "gateway_address", "10.2.0.1"),
+			},
+			{
+				ResourceName:      "google_compute_network.network",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "google_compute_subnetwork.subnetwork",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "google_compute_firewall.firewall",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testFakeGcpComputeNetwork_subnetworkAndFirewall(true),
+				Check: fakeGcpCheckResource(srv, "compute/projects/fake-project/regions/us-central1/subnetworks/subnetwork", func(s map[string]interface{}) error {
+					if s["privateIpGoogleAccess"] != true {
+						return fmt.Errorf("expected private Google access to be enabled, got %v", s)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
+
+func testFakeGcpComputeNetwork_subnetworkAndFirewall(privateIpGoogleAccess bool) string {
+	return fmt.Sprintf(`
+resource "google_compute_network" "network" {
+  name                    = "network"
+  auto_create_subnetworks = false
+}
+
+resource "google_compute_subnetwork" "subnetwork" {
+  name                     = "subnetwork"
+  network                  = google_compute_network.network.self_link
+  ip_cidr_range            = "10.2.0.0/16"
+  private_ip_google_access = %t
+}
+
+resource "google_compute_firewall" "firewall" {
+  name          = "firewall"
+  network       = google_compute_network.network.name
+  source_ranges = ["10.0.0.0/8"]
+
+  allow {
+    protocol = "tcp"
+    ports    = ["22"]
+  }
+}
+`, privateIpGoogleAccess)
+}
@@ -72,7 +72,7 @@ func resourceComputeProjectMetadataItemCreate(d *schema.ResourceData, meta inter
 	key := d.Get("key").(string)
 	val := d.Get("value").(string)
 
-	err = updateComputeCommonInstanceMetadata(config, projectID, key, userAgent, &val, d.Timeout(schema.TimeoutCreate), failIfPresent)
+	err = BatchRequestUpdateProjectMetadataItem(projectID, computeMetadataItemChange{Key: key, Value: &val, FailIfPresent: failIfPresent}, userAgent, config, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return err
 	}
@@ -138,7 +138,7 @@ func resourceComputeProjectMetadataItemUpdate(d *schema.ResourceData, meta inter
 		_, n := d.GetChange("value")
 		new := n.(string)
 
-		err = updateComputeCommonInstanceMetadata(config, projectID, key, userAgent, &new, d.Timeout(schema.TimeoutUpdate), overwritePresent)
+		err = BatchRequestUpdateProjectMetadataItem(projectID, computeMetadataItemChange{Key: key, Value: &new, FailIfPresent: overwritePresent}, userAgent, config, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return err
 		}
@@ -160,7 +160,7 @@ func resourceComputeProjectMetadataItemDelete(d *schema.ResourceData, meta inter
 
 	key := d.Get("key").(string)
 
-	err = updateComputeCommonInstanceMetadata(config, projectID, key, userAgent, nil, d.Timeout(schema.TimeoutDelete), overwritePresent)
+	err = BatchRequestUpdateProjectMetadataItem(projectID, computeMetadataItemChange{Key: key, FailIfPresent: overwritePresent}, userAgent, config, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return err
 	}
@@ -169,7 +169,9 @@ func resourceComputeProjectMetadataItemDelete(d *schema.ResourceData, meta inter
 	return nil
 }
 
-func updateComputeCommonInstanceMetadata(config *Config, projectID, key, userAgent string, afterVal *string, timeout time.Duration, failIfPresent metadataPresentBehavior) error {
+// updateComputeCommonInstanceMetadata applies the given changes to the
+// project's common instance metadata in a single read-modify-write.
+func updateComputeCommonInstanceMetadata(config *Config, projectID, userAgent string, changes []computeMetadataItemChange, timeout time.Duration) error {
 	updateMD := func() error {
 		lockName := fmt.Sprintf("projects/%s/commoninstancemetadata", projectID)
 		mutexKV.Lock(lockName)
@@ -183,27 +185,36 @@ func updateComputeCommonInstanceMetadata(config *Config, projectID, key, userAge
 
 		md := flattenMetadata(project.CommonInstanceMetadata)
 
-		val, ok := md[key]
-
-		if !ok {
-			if afterVal == nil {
-				// Asked to set no value and we didn't find one - we're done
-				return nil
-			}
-		} else {
-			if failIfPresent {
-				return fmt.Errorf("key %q already present in metadata for project %q. Use `terraform import` to manage it with Terraform", key, projectID)
+		changed := false
+		for _, change := range changes {
+			val, ok := md[change.Key]
+
+			if !ok {
+				if change.Value == nil {
+					// Asked to set no value and we didn't find one - nothing to do
+					continue
+				}
+			} else {
+				if change.FailIfPresent {
+					return fmt.Errorf("key %q already present in metadata for project %q. Use `terraform import` to manage it with Terraform", change.Key, projectID)
+				}
+				if change.Value != nil && *change.Value == val {
+					// Asked to set a value and it's already set - nothing to do
+					continue
+				}
 			}
-			if afterVal != nil && *afterVal == val {
-				// Asked to set a value and it's already set - we're done.
-				return nil
+
+			if change.Value == nil {
+				delete(md, change.Key)
+			} else {
+				md[change.Key] = *change.Value
 			}
+			changed = true
 		}
 
-		if afterVal == nil {
-			delete(md, key)
-		} else {
-			md[key] = *afterVal
+		if !changed {
+			// All changes were already applied - we're done
+			return nil
 		}
 
 		// Attempt to write the new value now
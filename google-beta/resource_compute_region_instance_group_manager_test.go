@@ -45,11 +45,15 @@ func testSweepComputeRegionInstanceGroupManager(region string) error {
 	// Keep count of items that aren't sweepable for logging.
 	nonPrefixCount := 0
 	for _, rigm := range found.Items {
-		if !IsSweepableTestResource(rigm.Name) {
+		if !IsSweepableTestResourceCreatedAt(rigm.Name, rigm.CreationTimestamp, nil) {
 			nonPrefixCount++
 			continue
 		}
 
+		if skipSweepInDryRun(resourceName, rigm.Name) {
+			continue
+		}
+
 		// Don't wait on operations as we may have a lot to delete
 		_, err := config.NewComputeClient(config.UserAgent).RegionInstanceGroupManagers.Delete(config.Project, region, rigm.Name).Do()
 		if err != nil {
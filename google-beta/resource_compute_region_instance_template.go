@@ -26,6 +26,7 @@ func ResourceComputeRegionInstanceTemplate() *schema.Resource {
 			resourceComputeInstanceTemplateSourceImageCustomizeDiff,
 			resourceComputeInstanceTemplateScratchDiskCustomizeDiff,
 			resourceComputeInstanceTemplateBootDiskCustomizeDiff,
+			SetLabelsDiff,
 		),
 
 		Timeouts: &schema.ResourceTimeout{
@@ -808,6 +809,10 @@ be from 0 to 999,999,999 inclusive.`,
 				Description: `A set of key/value label pairs to assign to instances created from this template,`,
 			},
 
+			"terraform_labels": TerraformLabelsSchema(true),
+
+			"effective_labels": EffectiveLabelsSchema(true),
+
 			"resource_policies": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -936,7 +941,7 @@ func resourceComputeRegionInstanceTemplateCreate(d *schema.ResourceData, meta in
 		ReservationAffinity:        reservationAffinity,
 	}
 
-	if _, ok := d.GetOk("labels"); ok {
+	if _, ok := d.GetOk("effective_labels"); ok {
 		instanceProperties.Labels = expandLabels(d)
 	}
 
@@ -1049,8 +1054,8 @@ func resourceComputeRegionInstanceTemplateRead(d *schema.ResourceData, meta inte
 		}
 	}
 	if instanceProperties.Labels != nil {
-		if err := d.Set("labels", instanceProperties.Labels); err != nil {
-			return fmt.Errorf("Error setting labels: %s", err)
+		if err := SetLabelsFields(instanceProperties.Labels, d); err != nil {
+			return err
 		}
 	}
 	if err = d.Set("self_link", instanceTemplate["selfLink"]); err != nil {
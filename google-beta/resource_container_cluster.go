@@ -178,6 +178,7 @@ func ResourceContainerCluster() *schema.Resource {
 			containerClusterNodeVersionRemoveDefaultCustomizeDiff,
 			containerClusterNetworkPolicyEmptyCustomizeDiff,
 			containerClusterSurgeSettingsCustomizeDiff,
+			customizeDiffDisruptiveUpdates(containerClusterDisruptiveUpdates),
 		),
 
 		Timeouts: &schema.ResourceTimeout{
@@ -2164,19 +2165,13 @@ func resourceContainerClusterCreate(d *schema.ResourceData, meta interface{}) er
 	// Wait until it's created
 	waitErr := ContainerOperationWait(config, op, project, location, "creating GKE cluster", userAgent, d.Timeout(schema.TimeoutCreate))
 	if waitErr != nil {
-		// Check if the create operation failed because Terraform was prematurely terminated. If it was we can persist the
-		// operation id to state so that a subsequent refresh of this resource will wait until the operation has terminated
-		// before attempting to Read the state of the cluster. This allows a graceful resumption of a Create that was killed
-		// by the upstream Terraform process exiting early such as a sigterm.
-		select {
-		case <-config.context.Done():
-			log.Printf("[DEBUG] Persisting %s so this operation can be resumed \n", op.Name)
-			if err := d.Set("operation", op.Name); err != nil {
-				return fmt.Errorf("Error setting operation: %s", err)
-			}
-			return nil
-		default:
-			// leaving default case to ensure this is non blocking
+		// Check if the create operation failed because it timed out or because Terraform was prematurely terminated.
+		// If it was we can persist the operation id to state so that a subsequent refresh of this resource will wait
+		// until the operation has terminated before attempting to Read the state of the cluster. This allows a graceful
+		// resumption of a Create that was killed by the upstream Terraform process exiting early such as a sigterm, or
+		// that took longer than the create timeout.
+		if persisted, err := PersistPendingOperation(d, config, op.Name, waitErr); err != nil || persisted {
+			return err
 		}
 		// Try a GET on the cluster so we can see the state in debug logs. This will help classify error states.
 		clusterGetCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.Get(containerClusterFullName(project, location, clusterName))
@@ -2256,38 +2251,33 @@ func resourceContainerClusterRead(d *schema.ResourceData, meta interface{}) erro
 
 	clusterName := d.Get("name").(string)
 
-	operation := d.Get("operation").(string)
-	if operation != "" {
-		log.Printf("[DEBUG] in progress operation detected at %v, attempting to resume", operation)
+	waitErr := ResumePendingOperation(d, config, func(opName string) error {
 		op := &container.Operation{
-			Name: operation,
+			Name: opName,
+		}
+		return ContainerOperationWait(config, op, project, location, "resuming GKE cluster", userAgent, d.Timeout(schema.TimeoutRead))
+	})
+	if waitErr != nil {
+		// Try a GET on the cluster so we can see the state in debug logs. This will help classify error states.
+		clusterGetCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.Get(containerClusterFullName(project, location, clusterName))
+		if config.UserProjectOverride {
+			clusterGetCall.Header().Add("X-Goog-User-Project", project)
 		}
-		if err := d.Set("operation", ""); err != nil {
-			return fmt.Errorf("Error setting operation: %s", err)
+		_, getErr := clusterGetCall.Do()
+		if getErr != nil {
+			log.Printf("[WARN] Cluster %s was created in an error state and not found", clusterName)
+			d.SetId("")
 		}
-		waitErr := ContainerOperationWait(config, op, project, location, "resuming GKE cluster", userAgent, d.Timeout(schema.TimeoutRead))
-		if waitErr != nil {
-			// Try a GET on the cluster so we can see the state in debug logs. This will help classify error states.
-			clusterGetCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.Get(containerClusterFullName(project, location, clusterName))
-			if config.UserProjectOverride {
-				clusterGetCall.Header().Add("X-Goog-User-Project", project)
-			}
-			_, getErr := clusterGetCall.Do()
-			if getErr != nil {
-				log.Printf("[WARN] Cluster %s was created in an error state and not found", clusterName)
-				d.SetId("")
-			}
 
-			if deleteErr := cleanFailedContainerCluster(d, meta); deleteErr != nil {
-				log.Printf("[WARN] Unable to clean up cluster from failed creation: %s", deleteErr)
-				// Leave ID set as the cluster likely still exists and should not be removed from state yet.
-			} else {
-				log.Printf("[WARN] Verified failed creation of cluster %s was cleaned up", d.Id())
-				d.SetId("")
-			}
-			// The resource didn't actually create
-			return waitErr
+		if deleteErr := cleanFailedContainerCluster(d, meta); deleteErr != nil {
+			log.Printf("[WARN] Unable to clean up cluster from failed creation: %s", deleteErr)
+			// Leave ID set as the cluster likely still exists and should not be removed from state yet.
+		} else {
+			log.Printf("[WARN] Verified failed creation of cluster %s was cleaned up", d.Id())
+			d.SetId("")
 		}
+		// The resource didn't actually create
+		return waitErr
 	}
 
 	name := containerClusterFullName(project, location, clusterName)
@@ -2573,6 +2563,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, updateDescription, userAgent, d.Timeout(schema.TimeoutUpdate))
 		}
@@ -2740,6 +2731,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating Release Channel", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -2776,6 +2768,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating GKE Intra Node Visibility", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -2808,6 +2801,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating GKE Private IPv6 Google Access", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -2845,6 +2839,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating L4", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -2909,6 +2904,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating GKE Default SNAT status", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -2940,6 +2936,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, "maintenance_policy")
 
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, "updating GKE cluster maintenance policy", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -3018,6 +3015,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, "enable_legacy_abac")
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating GKE legacy ABAC", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -3053,6 +3051,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, "updating GKE logging+monitoring service", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -3083,6 +3082,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, "network_policy")
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating GKE cluster network policy", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -3194,6 +3194,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 				if err != nil {
 					return err
 				}
+				logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 				// Wait until it's updated
 				return ContainerOperationWait(config, op, project, location, "updating GKE image type", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -3225,6 +3226,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating Notification Config", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -3276,6 +3278,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, "updating GKE cluster service externalips config", userAgent, d.Timeout(schema.TimeoutUpdate))
 		}
@@ -3303,6 +3306,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, "updating GKE cluster mesh certificates config", userAgent, d.Timeout(schema.TimeoutUpdate))
 		}
@@ -3330,6 +3334,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, "updating GKE cluster database encryption config", userAgent, d.Timeout(schema.TimeoutUpdate))
 		}
@@ -3357,6 +3362,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, "updating GKE cluster pod security policy config", userAgent, d.Timeout(schema.TimeoutUpdate))
 		}
@@ -3463,6 +3469,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, "resource_labels")
 
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, "updating GKE resource labels", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -3512,6 +3519,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 			// Wait until it's updated
 			return ContainerOperationWait(config, op, project, location, "updating GKE cluster resource usage export config", userAgent, d.Timeout(schema.TimeoutUpdate))
 		}
@@ -3623,6 +3631,7 @@ func resourceContainerClusterUpdate(d *schema.ResourceData, meta interface{}) er
 			if err != nil {
 				return err
 			}
+			logContainerClusterOperation(d.Id(), op, containerClusterUpdateFields(req.Update)...)
 
 			// Wait until it's updated
 			err = ContainerOperationWait(config, op, project, location, "updating Cluster Telemetry", userAgent, d.Timeout(schema.TimeoutUpdate))
@@ -5528,6 +5537,35 @@ func containerClusterMutexKey(project, location, clusterName string) string {
 	return fmt.Sprintf("google-container-cluster/%s/%s/%s", project, location, clusterName)
 }
 
+// containerClusterUpdateFields returns the JSON names of the fields set in a
+// ClusterUpdate, so the fields applied by each update operation can be logged.
+func containerClusterUpdateFields(update *container.ClusterUpdate) []string {
+	if update == nil {
+		return nil
+	}
+	forced := make(map[string]bool)
+	for _, f := range update.ForceSendFields {
+		forced[f] = true
+	}
+	var fields []string
+	v := reflect.ValueOf(*update)
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.Name == "ForceSendFields" || f.Name == "NullFields" {
+			continue
+		}
+		if v.Field(i).IsZero() && !forced[f.Name] {
+			continue
+		}
+		fields = append(fields, strings.Split(f.Tag.Get("json"), ",")[0])
+	}
+	return fields
+}
+
+func logContainerClusterOperation(id string, op *container.Operation, fields ...string) {
+	log.Printf("[DEBUG] GKE cluster %s: operation %s applies %s", id, op.Name, strings.Join(fields, ", "))
+}
+
 func containerClusterFullName(project, location, cluster string) string {
 	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s", project, location, cluster)
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"regexp"
 	"strconv"
 	"testing"
@@ -46,7 +47,7 @@ func testSweepContainerClusters(region string) error {
 	}
 
 	for _, cluster := range found.Clusters {
-		if IsSweepableTestResource(cluster.Name) {
+		if IsSweepableTestResourceCreatedAt(cluster.Name, cluster.CreateTime, cluster.ResourceLabels) && !skipSweepInDryRun("gcp_container_cluster", cluster.Name) {
 			log.Printf("Sweeping Container Cluster: %s", cluster.Name)
 			clusterURL := fmt.Sprintf("projects/%s/locations/%s/clusters/%s", config.Project, cluster.Location, cluster.Name)
 			_, err := config.NewContainerClient(config.UserAgent).Projects.Locations.Clusters.Delete(clusterURL).Do()
@@ -7587,3 +7588,38 @@ func TestValidateNodePoolAutoConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestContainerClusterUpdateFields(t *testing.T) {
+	cases := map[string]struct {
+		Update   *container.ClusterUpdate
+		Expected []string
+	}{
+		"nil": {
+			Update: nil,
+		},
+		"single field": {
+			Update:   &container.ClusterUpdate{DesiredLoggingService: "none"},
+			Expected: []string{"desiredLoggingService"},
+		},
+		"several fields": {
+			Update: &container.ClusterUpdate{
+				DesiredLoggingService:    "none",
+				DesiredMonitoringService: "none",
+			},
+			Expected: []string{"desiredLoggingService", "desiredMonitoringService"},
+		},
+		"force sent zero value": {
+			Update: &container.ClusterUpdate{
+				DesiredNodePoolId: "pool",
+				ForceSendFields:   []string{"DesiredImageType"},
+			},
+			Expected: []string{"desiredImageType", "desiredNodePoolId"},
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := containerClusterUpdateFields(tc.Update); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s: expected %v, got %v", tn, tc.Expected, got)
+		}
+	}
+}
@@ -39,6 +39,7 @@ func ResourceContainerNodePool() *schema.Resource {
 
 		CustomizeDiff: customdiff.All(
 			resourceNodeConfigEmptyGuestAccelerator,
+			customizeDiffDisruptiveUpdates(containerNodePoolDisruptiveUpdates),
 		),
 
 		UseJSONNumber: true,
@@ -523,20 +524,6 @@ func resourceContainerNodePoolCreate(d *schema.ResourceData, meta interface{}) e
 		nodePoolInfo.location, "creating GKE NodePool", userAgent, timeout)
 
 	if waitErr != nil {
-		// Check if the create operation failed because Terraform was prematurely terminated. If it was we can persist the
-		// operation id to state so that a subsequent refresh of this resource will wait until the operation has terminated
-		// before attempting to Read the state of the cluster. This allows a graceful resumption of a Create that was killed
-		// by the upstream Terraform process exiting early such as a sigterm.
-		select {
-		case <-config.context.Done():
-			log.Printf("[DEBUG] Persisting %s so this operation can be resumed \n", operation.Name)
-			if err := d.Set("operation", operation.Name); err != nil {
-				return fmt.Errorf("Error setting operation: %s", err)
-			}
-			return nil
-		default:
-			// leaving default case to ensure this is non blocking
-		}
 		// Check if resource was created but apply timed out.
 		// Common cause for that is GCE_STOCKOUT which will wait for resources and return error after timeout,
 		// but in fact nodepool will be created so we have to capture that in state.
@@ -545,6 +532,12 @@ func resourceContainerNodePoolCreate(d *schema.ResourceData, meta interface{}) e
 			d.SetId("")
 			return waitErr
 		}
+		// Check if the create operation failed because Terraform was prematurely terminated or the wait timed out. If it
+		// did we can persist the operation id to state so that a subsequent refresh of this resource will wait until the
+		// operation has terminated before attempting to Read the state of the node pool.
+		if persisted, err := PersistPendingOperation(d, config, operation.Name, waitErr); err != nil || persisted {
+			return err
+		}
 	}
 
 	log.Printf("[INFO] GKE NodePool %s has been created", nodePool.Name)
@@ -577,19 +570,14 @@ func resourceContainerNodePoolRead(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
-	operation := d.Get("operation").(string)
-	if operation != "" {
-		log.Printf("[DEBUG] in progress operation detected at %v, attempting to resume", operation)
+	err = ResumePendingOperation(d, config, func(opName string) error {
 		op := &container.Operation{
-			Name: operation,
-		}
-		if err := d.Set("operation", ""); err != nil {
-			return fmt.Errorf("Error setting operation: %s", err)
-		}
-		waitErr := ContainerOperationWait(config, op, nodePoolInfo.project, nodePoolInfo.location, "resuming GKE node pool", userAgent, d.Timeout(schema.TimeoutRead))
-		if waitErr != nil {
-			return waitErr
+			Name: opName,
 		}
+		return ContainerOperationWait(config, op, nodePoolInfo.project, nodePoolInfo.location, "resuming GKE node pool", userAgent, d.Timeout(schema.TimeoutRead))
+	})
+	if err != nil {
+		return err
 	}
 
 	name := getNodePoolName(d.Id())
@@ -1194,73 +1182,10 @@ func nodePoolUpdate(d *schema.ResourceData, meta interface{}, nodePoolInfo *Node
 	}
 
 	if d.HasChange(prefix + "node_config") {
-
-		if d.HasChange(prefix + "node_config.0.logging_variant") {
-			if v, ok := d.GetOk(prefix + "node_config.0.logging_variant"); ok {
-				loggingVariant := v.(string)
-				req := &container.UpdateNodePoolRequest{
-					Name: name,
-					LoggingConfig: &container.NodePoolLoggingConfig{
-						VariantConfig: &container.LoggingVariantConfig{
-							Variant: loggingVariant,
-						},
-					},
-				}
-
-				updateF := func() error {
-					clusterNodePoolsUpdateCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.NodePools.Update(nodePoolInfo.fullyQualifiedName(name), req)
-					if config.UserProjectOverride {
-						clusterNodePoolsUpdateCall.Header().Add("X-Goog-User-Project", nodePoolInfo.project)
-					}
-					op, err := clusterNodePoolsUpdateCall.Do()
-					if err != nil {
-						return err
-					}
-
-					// Wait until it's updated
-					return ContainerOperationWait(config, op,
-						nodePoolInfo.project,
-						nodePoolInfo.location,
-						"updating GKE node pool logging_variant", userAgent,
-						timeout)
-				}
-
-				if err := retryWhileIncompatibleOperation(timeout, npLockKey, updateF); err != nil {
-					return err
-				}
-
-				log.Printf("[INFO] Updated logging_variant for node pool %s", name)
-			}
-		}
-
-		if d.HasChange(prefix + "node_config.0.tags") {
-			req := &container.UpdateNodePoolRequest{
-				Name: name,
-			}
-			if v, ok := d.GetOk(prefix + "node_config.0.tags"); ok {
-				tagsList := v.([]interface{})
-				tags := []string{}
-				for _, v := range tagsList {
-					if v != nil {
-						tags = append(tags, v.(string))
-					}
-				}
-				ntags := &container.NetworkTags{
-					Tags: tags,
-				}
-				req.Tags = ntags
-			}
-
-			// sets tags to the empty list when user removes a previously defined list of tags entriely
-			// aka the node pool goes from having tags to no longer having any
-			if req.Tags == nil {
-				tags := []string{}
-				ntags := &container.NetworkTags{
-					Tags: tags,
-				}
-				req.Tags = ntags
-			}
-
+		// UpdateNodePool accepts several node_config fields in one request, so
+		// the changed ones are sent together in a single operation.
+		req, fields := expandNodePoolNodeConfigUpdate(d, prefix, name)
+		if len(fields) > 0 {
 			updateF := func() error {
 				clusterNodePoolsUpdateCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.NodePools.Update(nodePoolInfo.fullyQualifiedName(name), req)
 				if config.UserProjectOverride {
@@ -1270,95 +1195,20 @@ func nodePoolUpdate(d *schema.ResourceData, meta interface{}, nodePoolInfo *Node
 				if err != nil {
 					return err
 				}
+				log.Printf("[DEBUG] Operation %s updates %s for node pool %s", op.Name, strings.Join(fields, ", "), name)
 
 				// Wait until it's updated
 				return ContainerOperationWait(config, op,
 					nodePoolInfo.project,
 					nodePoolInfo.location,
-					"updating GKE node pool tags", userAgent,
+					"updating GKE node pool node_config", userAgent,
 					timeout)
 			}
 
 			if err := retryWhileIncompatibleOperation(timeout, npLockKey, updateF); err != nil {
 				return err
 			}
-			log.Printf("[INFO] Updated tags for node pool %s", name)
-		}
-
-		if d.HasChange(prefix + "node_config.0.resource_labels") {
-			req := &container.UpdateNodePoolRequest{
-				Name: name,
-			}
-
-			if v, ok := d.GetOk(prefix + "node_config.0.resource_labels"); ok {
-				resourceLabels := v.(map[string]interface{})
-				req.ResourceLabels = &container.ResourceLabels{
-					Labels: convertStringMap(resourceLabels),
-				}
-			}
-
-			updateF := func() error {
-				clusterNodePoolsUpdateCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.NodePools.Update(nodePoolInfo.fullyQualifiedName(name), req)
-				if config.UserProjectOverride {
-					clusterNodePoolsUpdateCall.Header().Add("X-Goog-User-Project", nodePoolInfo.project)
-				}
-				op, err := clusterNodePoolsUpdateCall.Do()
-				if err != nil {
-					return err
-				}
-
-				// Wait until it's updated
-				return ContainerOperationWait(config, op,
-					nodePoolInfo.project,
-					nodePoolInfo.location,
-					"updating GKE node pool resource labels", userAgent,
-					timeout)
-			}
-
-			// Call update serially.
-			if err := retryWhileIncompatibleOperation(timeout, npLockKey, updateF); err != nil {
-				return err
-			}
-
-			log.Printf("[INFO] Updated resource labels for node pool %s", name)
-		}
-
-		if d.HasChange(prefix + "node_config.0.labels") {
-			req := &container.UpdateNodePoolRequest{
-				Name: name,
-			}
-
-			if v, ok := d.GetOk(prefix + "node_config.0.labels"); ok {
-				labels := v.(map[string]interface{})
-				req.Labels = &container.NodeLabels{
-					Labels: convertStringMap(labels),
-				}
-			}
-
-			updateF := func() error {
-				clusterNodePoolsUpdateCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.NodePools.Update(nodePoolInfo.fullyQualifiedName(name), req)
-				if config.UserProjectOverride {
-					clusterNodePoolsUpdateCall.Header().Add("X-Goog-User-Project", nodePoolInfo.project)
-				}
-				op, err := clusterNodePoolsUpdateCall.Do()
-				if err != nil {
-					return err
-				}
-
-				// Wait until it's updated
-				return ContainerOperationWait(config, op,
-					nodePoolInfo.project,
-					nodePoolInfo.location,
-					"updating GKE node pool labels", userAgent,
-					timeout)
-			}
-
-			// Call update serially.
-			if err := retryWhileIncompatibleOperation(timeout, npLockKey, updateF); err != nil {
-				return err
-			}
-
-			log.Printf("[INFO] Updated labels for node pool %s", name)
+			log.Printf("[INFO] Updated %s for node pool %s", strings.Join(fields, ", "), name)
 		}
 
 		if d.HasChange(prefix + "node_config.0.image_type") {
@@ -1392,107 +1242,6 @@ func nodePoolUpdate(d *schema.ResourceData, meta interface{}, nodePoolInfo *Node
 			log.Printf("[INFO] Updated image type in Node Pool %s", d.Id())
 		}
 
-		if d.HasChange(prefix + "node_config.0.workload_metadata_config") {
-			req := &container.UpdateNodePoolRequest{
-				NodePoolId: name,
-				WorkloadMetadataConfig: expandWorkloadMetadataConfig(
-					d.Get(prefix + "node_config.0.workload_metadata_config")),
-			}
-			if req.WorkloadMetadataConfig == nil {
-				req.ForceSendFields = []string{"WorkloadMetadataConfig"}
-			}
-			updateF := func() error {
-				clusterNodePoolsUpdateCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.NodePools.Update(nodePoolInfo.fullyQualifiedName(name), req)
-				if config.UserProjectOverride {
-					clusterNodePoolsUpdateCall.Header().Add("X-Goog-User-Project", nodePoolInfo.project)
-				}
-				op, err := clusterNodePoolsUpdateCall.Do()
-
-				if err != nil {
-					return err
-				}
-
-				// Wait until it's updated
-				return ContainerOperationWait(config, op,
-					nodePoolInfo.project,
-					nodePoolInfo.location,
-					"updating GKE node pool workload_metadata_config", userAgent,
-					timeout)
-			}
-
-			if err := retryWhileIncompatibleOperation(timeout, npLockKey, updateF); err != nil {
-				return err
-			}
-			log.Printf("[INFO] Updated workload_metadata_config for node pool %s", name)
-		}
-
-		if d.HasChange(prefix + "node_config.0.kubelet_config") {
-			req := &container.UpdateNodePoolRequest{
-				NodePoolId: name,
-				KubeletConfig: expandKubeletConfig(
-					d.Get(prefix + "node_config.0.kubelet_config")),
-			}
-			if req.KubeletConfig == nil {
-				req.ForceSendFields = []string{"KubeletConfig"}
-			}
-			updateF := func() error {
-				clusterNodePoolsUpdateCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.NodePools.Update(nodePoolInfo.fullyQualifiedName(name), req)
-				if config.UserProjectOverride {
-					clusterNodePoolsUpdateCall.Header().Add("X-Goog-User-Project", nodePoolInfo.project)
-				}
-				op, err := clusterNodePoolsUpdateCall.Do()
-				if err != nil {
-					return err
-				}
-
-				// Wait until it's updated
-				return ContainerOperationWait(config, op,
-					nodePoolInfo.project,
-					nodePoolInfo.location,
-					"updating GKE node pool kubelet_config", userAgent,
-					timeout)
-			}
-
-			if err := retryWhileIncompatibleOperation(timeout, npLockKey, updateF); err != nil {
-				return err
-			}
-
-			log.Printf("[INFO] Updated kubelet_config for node pool %s", name)
-		}
-		if d.HasChange(prefix + "node_config.0.linux_node_config") {
-			req := &container.UpdateNodePoolRequest{
-				NodePoolId: name,
-				LinuxNodeConfig: expandLinuxNodeConfig(
-					d.Get(prefix + "node_config.0.linux_node_config")),
-			}
-			if req.LinuxNodeConfig == nil {
-				req.ForceSendFields = []string{"LinuxNodeConfig"}
-			}
-			updateF := func() error {
-				clusterNodePoolsUpdateCall := config.NewContainerClient(userAgent).Projects.Locations.Clusters.NodePools.Update(nodePoolInfo.fullyQualifiedName(name), req)
-				if config.UserProjectOverride {
-					clusterNodePoolsUpdateCall.Header().Add("X-Goog-User-Project", nodePoolInfo.project)
-				}
-				op, err := clusterNodePoolsUpdateCall.Do()
-				if err != nil {
-					return err
-				}
-
-				// Wait until it's updated
-				return ContainerOperationWait(config, op,
-					nodePoolInfo.project,
-					nodePoolInfo.location,
-					"updating GKE node pool linux_node_config", userAgent,
-					timeout)
-			}
-
-			if err := retryWhileIncompatibleOperation(timeout, npLockKey, updateF); err != nil {
-				return err
-			}
-
-			log.Printf("[INFO] Updated linux_node_config for node pool %s", name)
-		}
-
 	}
 
 	if d.HasChange(prefix + "node_count") {
@@ -1725,6 +1474,89 @@ func nodePoolUpdate(d *schema.ResourceData, meta interface{}, nodePoolInfo *Node
 	return nil
 }
 
+// expandNodePoolNodeConfigUpdate builds a single UpdateNodePoolRequest from
+// the changed node_config fields that UpdateNodePool accepts together, and
+// returns the names of the fields it sets. image_type is updated through
+// UpdateCluster and isn't included.
+func expandNodePoolNodeConfigUpdate(d *schema.ResourceData, prefix, name string) (*container.UpdateNodePoolRequest, []string) {
+	req := &container.UpdateNodePoolRequest{
+		Name: name,
+	}
+	var fields []string
+
+	if d.HasChange(prefix + "node_config.0.logging_variant") {
+		if v, ok := d.GetOk(prefix + "node_config.0.logging_variant"); ok {
+			req.LoggingConfig = &container.NodePoolLoggingConfig{
+				VariantConfig: &container.LoggingVariantConfig{
+					Variant: v.(string),
+				},
+			}
+			fields = append(fields, "logging_variant")
+		}
+	}
+
+	if d.HasChange(prefix + "node_config.0.tags") {
+		// sets tags to the empty list when user removes a previously defined list of tags entriely
+		// aka the node pool goes from having tags to no longer having any
+		tags := []string{}
+		if v, ok := d.GetOk(prefix + "node_config.0.tags"); ok {
+			for _, v := range v.([]interface{}) {
+				if v != nil {
+					tags = append(tags, v.(string))
+				}
+			}
+		}
+		req.Tags = &container.NetworkTags{
+			Tags: tags,
+		}
+		fields = append(fields, "tags")
+	}
+
+	if d.HasChange(prefix + "node_config.0.resource_labels") {
+		if v, ok := d.GetOk(prefix + "node_config.0.resource_labels"); ok {
+			req.ResourceLabels = &container.ResourceLabels{
+				Labels: convertStringMap(v.(map[string]interface{})),
+			}
+		}
+		fields = append(fields, "resource_labels")
+	}
+
+	if d.HasChange(prefix + "node_config.0.labels") {
+		if v, ok := d.GetOk(prefix + "node_config.0.labels"); ok {
+			req.Labels = &container.NodeLabels{
+				Labels: convertStringMap(v.(map[string]interface{})),
+			}
+		}
+		fields = append(fields, "labels")
+	}
+
+	if d.HasChange(prefix + "node_config.0.workload_metadata_config") {
+		req.WorkloadMetadataConfig = expandWorkloadMetadataConfig(d.Get(prefix + "node_config.0.workload_metadata_config"))
+		if req.WorkloadMetadataConfig == nil {
+			req.ForceSendFields = append(req.ForceSendFields, "WorkloadMetadataConfig")
+		}
+		fields = append(fields, "workload_metadata_config")
+	}
+
+	if d.HasChange(prefix + "node_config.0.kubelet_config") {
+		req.KubeletConfig = expandKubeletConfig(d.Get(prefix + "node_config.0.kubelet_config"))
+		if req.KubeletConfig == nil {
+			req.ForceSendFields = append(req.ForceSendFields, "KubeletConfig")
+		}
+		fields = append(fields, "kubelet_config")
+	}
+
+	if d.HasChange(prefix + "node_config.0.linux_node_config") {
+		req.LinuxNodeConfig = expandLinuxNodeConfig(d.Get(prefix + "node_config.0.linux_node_config"))
+		if req.LinuxNodeConfig == nil {
+			req.ForceSendFields = append(req.ForceSendFields, "LinuxNodeConfig")
+		}
+		fields = append(fields, "linux_node_config")
+	}
+
+	return req, fields
+}
+
 func getNodePoolName(id string) string {
 	// name can be specified with name, name_prefix, or neither, so read it from the id.
 	splits := strings.Split(id, "/")
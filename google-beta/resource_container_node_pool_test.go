@@ -2,10 +2,12 @@ package google
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -2984,3 +2986,40 @@ resource "google_container_node_pool" "np2" {
   }
 `, cluster, np1, np2)
 }
+
+func TestExpandNodePoolNodeConfigUpdate(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceContainerNodePool().Schema, map[string]interface{}{
+		"name": "pool",
+		"node_config": []interface{}{
+			map[string]interface{}{
+				"logging_variant": "MAX_THROUGHPUT",
+				"tags":            []interface{}{"a", "b"},
+				"labels":          map[string]interface{}{"env": "test"},
+				"resource_labels": map[string]interface{}{"team": "infra"},
+			},
+		},
+	})
+
+	req, fields := expandNodePoolNodeConfigUpdate(d, "", "pool")
+	if expected := []string{"logging_variant", "tags", "resource_labels", "labels"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected fields %v, got %v", expected, fields)
+	}
+	if req.Name != "pool" {
+		t.Errorf("expected request for node pool %q, got %q", "pool", req.Name)
+	}
+	if req.Tags == nil || !reflect.DeepEqual(req.Tags.Tags, []string{"a", "b"}) {
+		t.Errorf("expected tags [a b], got %#v", req.Tags)
+	}
+	if req.Labels == nil || req.Labels.Labels["env"] != "test" {
+		t.Errorf("expected labels env=test, got %#v", req.Labels)
+	}
+	if req.ResourceLabels == nil || req.ResourceLabels.Labels["team"] != "infra" {
+		t.Errorf("expected resource labels team=infra, got %#v", req.ResourceLabels)
+	}
+	if req.LoggingConfig == nil || req.LoggingConfig.VariantConfig.Variant != "MAX_THROUGHPUT" {
+		t.Errorf("expected logging variant MAX_THROUGHPUT, got %#v", req.LoggingConfig)
+	}
+	if req.KubeletConfig != nil || req.LinuxNodeConfig != nil || req.WorkloadMetadataConfig != nil {
+		t.Errorf("expected only the changed fields to be set, got %#v", req)
+	}
+}
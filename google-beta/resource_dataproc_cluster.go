@@ -152,6 +152,8 @@ func ResourceDataprocCluster() *schema.Resource {
 		Update: resourceDataprocClusterUpdate,
 		Delete: resourceDataprocClusterDelete,
 
+		CustomizeDiff: SetLabelsDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(45 * time.Minute),
 			Update: schema.DefaultTimeout(45 * time.Minute),
@@ -220,6 +222,10 @@ func ResourceDataprocCluster() *schema.Resource {
 				Description:      `The list of labels (key/value pairs) to be applied to instances in the cluster. GCP generates some itself including goog-dataproc-cluster-name which is the name of the cluster.`,
 			},
 
+			"terraform_labels": TerraformLabelsSchema(false),
+
+			"effective_labels": EffectiveLabelsSchema(false),
+
 			"virtual_cluster_config": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -1320,7 +1326,7 @@ func resourceDataprocClusterCreate(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
-	if _, ok := d.GetOk("labels"); ok {
+	if _, ok := d.GetOk("effective_labels"); ok {
 		cluster.Labels = expandLabels(d)
 	}
 
@@ -1965,13 +1971,8 @@ func resourceDataprocClusterUpdate(d *schema.ResourceData, meta interface{}) err
 
 	updMask := []string{}
 
-	if d.HasChange("labels") {
-		v := d.Get("labels")
-		m := make(map[string]string)
-		for k, val := range v.(map[string]interface{}) {
-			m[k] = val.(string)
-		}
-		cluster.Labels = m
+	if d.HasChange("effective_labels") {
+		cluster.Labels = expandLabels(d)
 
 		updMask = append(updMask, "labels")
 	}
@@ -2079,8 +2080,8 @@ func resourceDataprocClusterRead(d *schema.ResourceData, meta interface{}) error
 	if err := d.Set("region", region); err != nil {
 		return fmt.Errorf("Error setting region: %s", err)
 	}
-	if err := d.Set("labels", cluster.Labels); err != nil {
-		return fmt.Errorf("Error setting labels: %s", err)
+	if err := SetLabelsFields(cluster.Labels, d); err != nil {
+		return err
 	}
 
 	var cfg []map[string]interface{}
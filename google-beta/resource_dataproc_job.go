@@ -20,6 +20,8 @@ func ResourceDataprocJob() *schema.Resource {
 		Read:   resourceDataprocJobRead,
 		Delete: resourceDataprocJobDelete,
 
+		CustomizeDiff: SetLabelsDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
@@ -145,6 +147,10 @@ func ResourceDataprocJob() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 
+			"terraform_labels": TerraformLabelsSchema(true),
+
+			"effective_labels": EffectiveLabelsSchema(true),
+
 			"scheduling": {
 				Type:        schema.TypeList,
 				Description: "Optional. Job scheduling configuration.",
@@ -225,7 +231,7 @@ func resourceDataprocJobCreate(d *schema.ResourceData, meta interface{}) error {
 		submitReq.Job.Scheduling = expandJobScheduling(config)
 	}
 
-	if _, ok := d.GetOk("labels"); ok {
+	if _, ok := d.GetOk("effective_labels"); ok {
 		submitReq.Job.Labels = expandLabels(d)
 	}
 
@@ -306,8 +312,8 @@ func resourceDataprocJobRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("force_delete", d.Get("force_delete")); err != nil {
 		return fmt.Errorf("Error setting force_delete: %s", err)
 	}
-	if err := d.Set("labels", job.Labels); err != nil {
-		return fmt.Errorf("Error setting labels: %s", err)
+	if err := SetLabelsFields(job.Labels, d); err != nil {
+		return err
 	}
 	if err := d.Set("driver_output_resource_uri", job.DriverOutputResourceUri); err != nil {
 		return fmt.Errorf("Error setting driver_output_resource_uri: %s", err)
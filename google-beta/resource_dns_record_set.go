@@ -356,24 +356,12 @@ func resourceDnsRecordSetCreate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	log.Printf("[DEBUG] DNS Record create request: %#v", chg)
-	chg, err = config.NewDnsClient(userAgent).Changes.Create(project, zone, chg).Do()
-	if err != nil {
+	if err := BatchRequestDnsChange(project, zone, chg, userAgent, config, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf("Error creating DNS RecordSet: %s", err)
 	}
 
 	d.SetId(fmt.Sprintf("projects/%s/managedZones/%s/rrsets/%s/%s", project, zone, name, rType))
 
-	w := &DnsChangeWaiter{
-		Service:     config.NewDnsClient(userAgent),
-		Change:      chg,
-		Project:     project,
-		ManagedZone: zone,
-	}
-	_, err = w.Conf().WaitForState()
-	if err != nil {
-		return fmt.Errorf("Error waiting for Google DNS change: %s", err)
-	}
-
 	return resourceDnsRecordSetRead(d, meta)
 }
 
@@ -491,22 +479,10 @@ func resourceDnsRecordSetDelete(d *schema.ResourceData, meta interface{}) error
 	}
 
 	log.Printf("[DEBUG] DNS Record delete request: %#v", chg)
-	chg, err = config.NewDnsClient(userAgent).Changes.Create(project, zone, chg).Do()
-	if err != nil {
+	if err := BatchRequestDnsChange(project, zone, chg, userAgent, config, d.Timeout(schema.TimeoutDelete)); err != nil {
 		return handleNotFoundError(err, d, "google_dns_record_set")
 	}
 
-	w := &DnsChangeWaiter{
-		Service:     config.NewDnsClient(userAgent),
-		Change:      chg,
-		Project:     project,
-		ManagedZone: zone,
-	}
-	_, err = w.Conf().WaitForState()
-	if err != nil {
-		return fmt.Errorf("Error waiting for Google DNS change: %s", err)
-	}
-
 	d.SetId("")
 	return nil
 }
@@ -572,21 +548,10 @@ func resourceDnsRecordSetUpdate(d *schema.ResourceData, meta interface{}) error
 		chg.Deletions[0].Rrdatas[i] = oldRR.(string)
 	}
 	log.Printf("[DEBUG] DNS Record change request: %#v old: %#v new: %#v", chg, chg.Deletions[0], chg.Additions[0])
-	chg, err = config.NewDnsClient(userAgent).Changes.Create(project, zone, chg).Do()
-	if err != nil {
+	if err := BatchRequestDnsChange(project, zone, chg, userAgent, config, d.Timeout(schema.TimeoutUpdate)); err != nil {
 		return fmt.Errorf("Error changing DNS RecordSet: %s", err)
 	}
 
-	w := &DnsChangeWaiter{
-		Service:     config.NewDnsClient(userAgent),
-		Change:      chg,
-		Project:     project,
-		ManagedZone: zone,
-	}
-	if _, err = w.Conf().WaitForState(); err != nil {
-		return fmt.Errorf("Error waiting for Google DNS change: %s", err)
-	}
-
 	d.SetId(fmt.Sprintf("projects/%s/managedZones/%s/rrsets/%s/%s", project, zone, recordName, newType))
 
 	return resourceDnsRecordSetRead(d, meta)
@@ -86,12 +86,16 @@ func testSweepFirebaseAndroidApp(region string) error {
 		}
 
 		// Skip resources that shouldn't be sweeped
-		if !IsSweepableTestResource(obj["displayName"].(string)) {
+		if !IsSweepableTestObject(obj["displayName"].(string), obj) {
 			nonPrefixCount++
 			continue
 		}
 
 		name := obj["name"].(string)
+		if skipSweepInDryRun(resourceName, name) {
+			continue
+		}
+
 		deleteUrl := fmt.Sprintf("https://firebase.googleapis.com/v1beta1/%s:remove", name)
 
 		body := make(map[string]interface{})
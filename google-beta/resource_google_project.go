@@ -38,6 +38,8 @@ func ResourceGoogleProject() *schema.Resource {
 			State: resourceProjectImportState,
 		},
 
+		CustomizeDiff: SetLabelsDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -102,6 +104,8 @@ func ResourceGoogleProject() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: `A set of key/value label pairs to assign to the project.`,
 			},
+			"terraform_labels": TerraformLabelsSchema(false),
+			"effective_labels": EffectiveLabelsSchema(false),
 		},
 		UseJSONNumber: true,
 	}
@@ -131,7 +135,7 @@ func resourceGoogleProjectCreate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	if _, ok := d.GetOk("labels"); ok {
+	if _, ok := d.GetOk("effective_labels"); ok {
 		project.Labels = expandLabels(d)
 	}
 
@@ -277,8 +281,8 @@ func resourceGoogleProjectRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("name", p.Name); err != nil {
 		return fmt.Errorf("Error setting name: %s", err)
 	}
-	if err := d.Set("labels", p.Labels); err != nil {
-		return fmt.Errorf("Error setting labels: %s", err)
+	if err := SetLabelsFields(p.Labels, d); err != nil {
+		return err
 	}
 
 	if p.Parent != nil {
@@ -419,7 +423,7 @@ func resourceGoogleProjectUpdate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	// Project Labels have changed
-	if ok := d.HasChange("labels"); ok {
+	if ok := d.HasChange("effective_labels"); ok {
 		p.Labels = expandLabels(d)
 
 		// Do Update on project
@@ -248,3 +248,28 @@ resource "google_project_iam_member" "acceptance" {
 }
 `, pid, pid, org, role, member, conditionTitle)
 }
+
+func TestFakeGcpProjectIamMember_basic(t *testing.T) {
+	srv := newFakeGcpServer(t)
+
+	fakeGcpTest(t, srv, resource.TestCase{
+		CheckDestroy: fakeGcpCheckResource(srv, "cloudresourcemanager/projects/fake-project/iamPolicy", func(policy map[string]interface{}) error {
+			if bindings := policy["bindings"].([]interface{}); len(bindings) != 1 {
+				return fmt.Errorf("expected only the owner binding to be left, got %v", bindings)
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "google_project_iam_member" "member" {
+  project = "fake-project"
+  role    = "roles/viewer"
+  member  = "user:viewer@example.com"
+}
+`,
+			},
+			projectIamMemberImportStep("google_project_iam_member.member", fakeGcpProject, "roles/viewer", "user:viewer@example.com"),
+		},
+	})
+}
@@ -144,11 +144,10 @@ func resourceGoogleProjectServiceCreate(d *schema.ResourceData, meta interface{}
 	id := project + "/" + srv
 
 	// Check if the service has already been enabled
-	servicesRaw, err := BatchRequestReadServices(project, d, config)
+	servicesList, err := BatchRequestReadServices(project, d, config)
 	if err != nil {
 		return handleNotFoundError(err, d, fmt.Sprintf("Project Service %s", d.Id()))
 	}
-	servicesList := servicesRaw.(map[string]struct{})
 	if _, ok := servicesList[srv]; ok {
 		log.Printf("[DEBUG] service %s was already found to be enabled in project %s", srv, project)
 		d.SetId(id)
@@ -206,11 +205,10 @@ func resourceGoogleProjectServiceRead(d *schema.ResourceData, meta interface{})
 		return handleNotFoundError(err, d, fmt.Sprintf("Project Service %s", d.Id()))
 	}
 
-	servicesRaw, err := BatchRequestReadServices(project, d, config)
+	servicesList, err := BatchRequestReadServices(project, d, config)
 	if err != nil {
 		return handleNotFoundError(err, d, fmt.Sprintf("Project Service %s", d.Id()))
 	}
-	servicesList := servicesRaw.(map[string]struct{})
 
 	srv := d.Get("service").(string)
 	if _, ok := servicesList[srv]; ok {
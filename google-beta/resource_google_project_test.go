@@ -65,6 +65,10 @@ func testSweepProject(region string) error {
 		}
 
 		for _, project := range found.Projects {
+			if !IsSweepableTestResourceCreatedAt(project.ProjectId, project.CreateTime, project.Labels) || skipSweepInDryRun("GoogleProject", project.ProjectId) {
+				continue
+			}
+
 			log.Printf("[INFO][SWEEPER_LOG] Sweeping Project id: %s", project.ProjectId)
 			_, err := config.NewResourceManagerClient(config.UserAgent).Projects.Delete(project.ProjectId).Do()
 			if err != nil {
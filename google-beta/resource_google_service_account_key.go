@@ -3,6 +3,7 @@ package google
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -53,6 +54,14 @@ func ResourceGoogleServiceAccountKey() *schema.Resource {
 				ConflictsWith: []string{"key_algorithm", "private_key_type"},
 				Description:   `A field that allows clients to upload their own public key. If set, use this public key data to create a service account key for given service account. Please note, the expected format for this field is a base64 encoded X509_PEM.`,
 			},
+			"private_key_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateRegexp(`^projects/[^/]+/secrets/[^/]+$`),
+				ConflictsWith: []string{"public_key_data"},
+				Description:   `A Secret Manager secret, in the format projects/{{project}}/secrets/{{secret_id}}, to store the private key in instead of state. The private key is added to the secret as a new version, and private_key is left empty. Use keepers to rotate the key.`,
+			},
 			"keepers": {
 				Description: "Arbitrary map of values that, when changed, will trigger recreation of resource.",
 				Type:        schema.TypeMap,
@@ -78,6 +87,11 @@ func ResourceGoogleServiceAccountKey() *schema.Resource {
 				Sensitive:   true,
 				Description: `The private key in JSON format, base64 encoded. This is what you normally get as a file when creating service account keys through the CLI or web console. This is only populated when creating a new key.`,
 			},
+			"private_key_secret_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: `The Secret Manager secret version holding the private key, when private_key_secret is set.`,
+			},
 			"valid_after": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -134,7 +148,15 @@ func resourceGoogleServiceAccountKeyCreate(d *schema.ResourceData, meta interfac
 	if err := d.Set("valid_before", sak.ValidBeforeTime); err != nil {
 		return fmt.Errorf("Error setting valid_before: %s", err)
 	}
-	if err := d.Set("private_key", sak.PrivateKeyData); err != nil {
+	if secret := d.Get("private_key_secret").(string); secret != "" {
+		version, err := addServiceAccountKeySecretVersion(config, userAgent, secret, sak.PrivateKeyData)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("private_key_secret_version", version); err != nil {
+			return fmt.Errorf("Error setting private_key_secret_version: %s", err)
+		}
+	} else if err := d.Set("private_key", sak.PrivateKeyData); err != nil {
 		return fmt.Errorf("Error setting private_key: %s", err)
 	}
 
@@ -145,6 +167,24 @@ func resourceGoogleServiceAccountKeyCreate(d *schema.ResourceData, meta interfac
 	return resourceGoogleServiceAccountKeyRead(d, meta)
 }
 
+// addServiceAccountKeySecretVersion stores the private key of a new service
+// account key as a new version of secret, returning the name of the version.
+func addServiceAccountKeySecretVersion(config *Config, userAgent, secret, privateKeyData string) (string, error) {
+	project := strings.Split(secret, "/")[1]
+	// privateKeyData is already base64 encoded, as secret payloads are sent.
+	body := map[string]interface{}{
+		"payload": map[string]interface{}{
+			"data": privateKeyData,
+		},
+	}
+	res, err := SendRequest(config, "POST", project, config.SecretManagerBasePath+secret+":addVersion", userAgent, body)
+	if err != nil {
+		return "", fmt.Errorf("Error storing the private key in secret %s: %s", secret, err)
+	}
+	name, _ := res["name"].(string)
+	return name, nil
+}
+
 func resourceGoogleServiceAccountKeyRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	userAgent, err := generateUserAgentString(d, config.UserAgent)
@@ -1,7 +1,11 @@
 package google
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -144,3 +148,33 @@ resource "google_service_account_key" "acceptance" {
 }
 `, account, name)
 }
+
+func TestFakeGcpServiceAccountKey_secretVersion(t *testing.T) {
+	p, srv := newFakeGcpProvider(t)
+	config := p.Meta().(*Config)
+
+	url := config.SecretManagerBasePath + "projects/" + fakeGcpProject + "/secrets?secretId=key"
+	secret := map[string]interface{}{
+		"replication": map[string]interface{}{"automatic": map[string]interface{}{}},
+	}
+	if _, err := SendRequest(config, "POST", fakeGcpProject, url, config.UserAgent, secret); err != nil {
+		t.Fatal(err)
+	}
+	privateKeyData := base64.StdEncoding.EncodeToString([]byte(`{"type": "service_account"}`))
+	version, err := addServiceAccountKeySecretVersion(config, config.UserAgent, "projects/"+fakeGcpProject+"/secrets/key", privateKeyData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(version, "/secrets/key/versions/1") {
+		t.Errorf("expected the first version of the secret, got %q", version)
+	}
+
+	res, err := http.Get(srv.URL + "/secretmanager/v1/projects/fake-project/secrets/key/versions/1:access")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if b, _ := ioutil.ReadAll(res.Body); !strings.Contains(string(b), privateKeyData) {
+		t.Errorf("expected the version to contain the private key, got %s", b)
+	}
+}
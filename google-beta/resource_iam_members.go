@@ -0,0 +1,297 @@
+package google
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+var IamMembersBaseSchema = map[string]*schema.Schema{
+	"member": {
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"member": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateIAMMember,
+				},
+				"condition": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"expression": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"title": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"description": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	"etag": {
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+}
+
+// iamMembersImport imports the (role, member, condition) tuples listed in the
+// import ID, in the format
+// 'resource_name role member [condition_title][;role member [condition_title]]...'.
+func iamMembersImport(newUpdaterFunc newResourceIamUpdaterFunc, resourceIdParser resourceIdParserFunc) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if resourceIdParser == nil {
+			return nil, errors.New("Import not supported for this IAM resource.")
+		}
+		config := m.(*Config)
+		s := strings.Fields(d.Id())
+		if len(s) < 3 {
+			d.SetId("")
+			return nil, fmt.Errorf("Wrong number of parts to Members id %s; expected 'resource_name role member [condition_title][;role member [condition_title]]...'.", s)
+		}
+
+		var tuples [][]string
+		for _, t := range strings.Split(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(d.Id()), s[0])), ";") {
+			parts := strings.Fields(t)
+			if len(parts) < 2 {
+				return nil, fmt.Errorf("Wrong number of parts to Members id tuple %q; expected 'role member [condition_title]'.", t)
+			}
+			tuples = append(tuples, []string{parts[0], parts[1], strings.Join(parts[2:], " ")})
+		}
+
+		// Set the ID only to the first part so all IAM types can share the same resourceIdParserFunc.
+		d.SetId(s[0])
+		if err := resourceIdParser(d, config); err != nil {
+			return nil, err
+		}
+
+		// Read the upstream policy so we can set the full conditions.
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return nil, err
+		}
+		p, err := iamPolicyReadWithRetry(updater)
+		if err != nil {
+			return nil, err
+		}
+
+		var members []interface{}
+		for _, t := range tuples {
+			role, member, conditionTitle := t[0], normalizeIamMemberCasing(t[1]), t[2]
+			var binding *cloudresourcemanager.Binding
+			for _, b := range p.Bindings {
+				if b.Role != role || conditionKeyFromCondition(b.Condition).Title != conditionTitle || !iamBindingHasMember(b, member) {
+					continue
+				}
+				if binding != nil {
+					return nil, fmt.Errorf("Cannot import IAM member %q with condition title %q, it matches multiple conditions", member, conditionTitle)
+				}
+				binding = b
+			}
+			if binding == nil {
+				return nil, fmt.Errorf("Cannot find binding for %q with role %q, member %q, and condition title %q", updater.DescribeResource(), role, member, conditionTitle)
+			}
+			members = append(members, map[string]interface{}{
+				"role":      role,
+				"member":    member,
+				"condition": flattenIamCondition(binding.Condition),
+			})
+		}
+		if err := d.Set("member", members); err != nil {
+			return nil, fmt.Errorf("Error setting member: %s", err)
+		}
+
+		d.SetId(updater.GetResourceId())
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// ResourceIamMembers manages a set of (role, member, condition) tuples on a
+// resource non-authoritatively, like a group of _iam_member resources, but
+// changing them all in a single read-modify-write of the policy.
+func ResourceIamMembers(parentSpecificSchema map[string]*schema.Schema, newUpdaterFunc newResourceIamUpdaterFunc, resourceIdParser resourceIdParserFunc, options ...func(*IamSettings)) *schema.Resource {
+	settings := &IamSettings{}
+	for _, o := range options {
+		o(settings)
+	}
+
+	return &schema.Resource{
+		Create: resourceIamMembersCreate(newUpdaterFunc),
+		Read:   resourceIamMembersRead(newUpdaterFunc),
+		Update: resourceIamMembersUpdate(newUpdaterFunc),
+		Delete: resourceIamMembersDelete(newUpdaterFunc),
+
+		// if non-empty, this will be used to send a deprecation message when the
+		// resource is used.
+		DeprecationMessage: settings.DeprecationMessage,
+
+		Schema: mergeSchemas(IamMembersBaseSchema, parentSpecificSchema),
+		Importer: &schema.ResourceImporter{
+			State: iamMembersImport(newUpdaterFunc, resourceIdParser),
+		},
+		UseJSONNumber: true,
+	}
+}
+
+// Converts the tuples of a `member` set to bindings of a single member each.
+func expandIamMembers(v interface{}) []*cloudresourcemanager.Binding {
+	var bindings []*cloudresourcemanager.Binding
+	for _, raw := range v.(*schema.Set).List() {
+		m := raw.(map[string]interface{})
+		b := &cloudresourcemanager.Binding{
+			Role:    m["role"].(string),
+			Members: []string{m["member"].(string)},
+		}
+		if c := expandIamCondition(m["condition"]); c != nil {
+			b.Condition = c
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings
+}
+
+func iamBindingHasMember(b *cloudresourcemanager.Binding, member string) bool {
+	for _, m := range b.Members {
+		if normalizeIamMemberCasing(m) == normalizeIamMemberCasing(member) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a modify function adding the `add` tuples to a policy and removing
+// the `remove` ones.
+func iamMembersModifyFunc(add, remove []*cloudresourcemanager.Binding) iamPolicyModifyFunc {
+	return func(ep *cloudresourcemanager.Policy) error {
+		ep.Bindings = subtractFromBindings(ep.Bindings, remove...)
+		ep.Bindings = MergeBindings(append(ep.Bindings, add...))
+		ep.Version = IamPolicyVersion
+		return nil
+	}
+}
+
+func resourceIamMembersCreate(newUpdaterFunc newResourceIamUpdaterFunc) schema.CreateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		members := expandIamMembers(d.Get("member"))
+		log.Printf("[DEBUG]: Adding %d IAM members to %s", len(members), updater.DescribeResource())
+		err = iamPolicyReadModifyWrite(updater, iamMembersModifyFunc(members, nil), config.IamPolicyVerification)
+		if err != nil {
+			return err
+		}
+		d.SetId(updater.GetResourceId())
+		return resourceIamMembersRead(newUpdaterFunc)(d, meta)
+	}
+}
+
+func resourceIamMembersRead(newUpdaterFunc newResourceIamUpdaterFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		p, err := iamPolicyReadWithRetry(updater)
+		if err != nil {
+			return handleNotFoundError(err, d, fmt.Sprintf("Resource %q with IAM Members", updater.DescribeResource()))
+		}
+
+		// Keep the tuples still in the policy, so that the others show up
+		// as additions in the plan.
+		var members []interface{}
+		for _, raw := range d.Get("member").(*schema.Set).List() {
+			m := raw.(map[string]interface{})
+			eCondition := conditionKeyFromCondition(expandIamCondition(m["condition"]))
+			found := false
+			for _, b := range p.Bindings {
+				if b.Role == m["role"].(string) && conditionKeyFromCondition(b.Condition) == eCondition && iamBindingHasMember(b, m["member"].(string)) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				log.Printf("[DEBUG]: Member %q for role %q with condition %#v does not exist in policy of %s, removing from state.", m["member"], m["role"], eCondition, updater.DescribeResource())
+				continue
+			}
+			members = append(members, m)
+		}
+
+		if err := d.Set("etag", p.Etag); err != nil {
+			return fmt.Errorf("Error setting etag: %s", err)
+		}
+		if err := d.Set("member", members); err != nil {
+			return fmt.Errorf("Error setting member: %s", err)
+		}
+		return nil
+	}
+}
+
+func resourceIamMembersUpdate(newUpdaterFunc newResourceIamUpdaterFunc) schema.UpdateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		if d.HasChange("member") {
+			o, n := d.GetChange("member")
+			add := expandIamMembers(n.(*schema.Set).Difference(o.(*schema.Set)))
+			remove := expandIamMembers(o.(*schema.Set).Difference(n.(*schema.Set)))
+			log.Printf("[DEBUG]: Adding %d and removing %d IAM members of %s", len(add), len(remove), updater.DescribeResource())
+			err = iamPolicyReadModifyWrite(updater, iamMembersModifyFunc(add, remove), config.IamPolicyVerification)
+			if err != nil {
+				return err
+			}
+		}
+
+		return resourceIamMembersRead(newUpdaterFunc)(d, meta)
+	}
+}
+
+func resourceIamMembersDelete(newUpdaterFunc newResourceIamUpdaterFunc) schema.DeleteFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		members := expandIamMembers(d.Get("member"))
+		err = iamPolicyReadModifyWrite(updater, iamMembersModifyFunc(nil, members), config.IamPolicyVerification)
+		if err != nil {
+			return handleNotFoundError(err, d, fmt.Sprintf("Resource %s for IAM Members", updater.GetResourceId()))
+		}
+		return nil
+	}
+}
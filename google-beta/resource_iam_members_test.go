@@ -0,0 +1,112 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func testIamMembersResource(updater *fakeIamUpdater) *schema.Resource {
+	return ResourceIamMembers(map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+	}, func(d TerraformResourceData, config *Config) (ResourceIamUpdater, error) {
+		return updater, nil
+	}, func(d *schema.ResourceData, config *Config) error {
+		return d.Set("name", d.Id())
+	})
+}
+
+func TestResourceIamMembers_lifecycle(t *testing.T) {
+	updater := &fakeIamUpdater{policy: &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			{Role: "roles/viewer", Members: []string{"user:unmanaged@example.com"}},
+		},
+	}}
+	r := testIamMembersResource(updater)
+	config := &Config{IamPolicyVerification: IamPolicyVerificationOff}
+	condition := []interface{}{map[string]interface{}{"title": "expires", "expression": "request.time < timestamp(\"2030-01-01T00:00:00Z\")", "description": ""}}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "fake",
+		"member": []interface{}{
+			map[string]interface{}{"role": "roles/viewer", "member": "user:a@example.com"},
+			map[string]interface{}{"role": "roles/editor", "member": "user:a@example.com"},
+			map[string]interface{}{"role": "roles/editor", "member": "user:b@example.com", "condition": condition},
+		},
+	})
+	if err := r.Create(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if updater.etag != 1 {
+		t.Errorf("expected a single policy write, got %d", updater.etag)
+	}
+	expected := []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:unmanaged@example.com", "user:a@example.com"}},
+		{Role: "roles/editor", Members: []string{"user:a@example.com"}},
+		{Role: "roles/editor", Members: []string{"user:b@example.com"}, Condition: expandIamCondition(condition)},
+	}
+	if !compareBindings(updater.policy.Bindings, expected) {
+		t.Errorf("expected bindings %s, got %s", debugPrintBindings(expected), debugPrintBindings(updater.policy.Bindings))
+	}
+	if n := d.Get("member").(*schema.Set).Len(); n != 3 {
+		t.Errorf("expected 3 members in state, got %d", n)
+	}
+
+	// Members removed out of band are dropped from state, to be re-added.
+	updater.policy.Bindings = subtractFromBindings(updater.policy.Bindings, &cloudresourcemanager.Binding{Role: "roles/editor", Members: []string{"user:a@example.com"}})
+	if err := r.Read(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := d.Get("member").(*schema.Set).Len(); n != 2 {
+		t.Errorf("expected 2 members in state, got %d", n)
+	}
+
+	if err := r.Delete(d, config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:unmanaged@example.com"}},
+	}
+	if !compareBindings(updater.policy.Bindings, expected) {
+		t.Errorf("expected only the unmanaged binding to be left, got %s", debugPrintBindings(updater.policy.Bindings))
+	}
+}
+
+func TestResourceIamMembers_import(t *testing.T) {
+	updater := &fakeIamUpdater{policy: &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			{Role: "roles/viewer", Members: []string{"user:a@example.com"}},
+			{Role: "roles/editor", Members: []string{"user:b@example.com"}, Condition: &cloudresourcemanager.Expr{Title: "my title", Expression: "true"}},
+		},
+	}}
+	r := testIamMembersResource(updater)
+	config := &Config{}
+
+	d := r.TestResourceData()
+	d.SetId("fake roles/viewer user:A@example.com;roles/editor user:b@example.com my title")
+	imported, err := r.Importer.State(d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	members := imported[0].Get("member").(*schema.Set)
+	if imported[0].Id() != "fake" || members.Len() != 2 {
+		t.Fatalf("expected 2 members imported into fake, got %q: %v", imported[0].Id(), members.List())
+	}
+	for _, raw := range members.List() {
+		m := raw.(map[string]interface{})
+		if m["role"] == "roles/editor" && len(m["condition"].([]interface{})) != 1 {
+			t.Errorf("expected the condition of %v to be imported", m)
+		}
+	}
+
+	d = r.TestResourceData()
+	d.SetId("fake roles/owner user:a@example.com")
+	if _, err := r.Importer.State(d, config); err == nil {
+		t.Errorf("expected an error importing a member not in the policy")
+	}
+}
@@ -153,7 +153,7 @@ func resourceIapBrandCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 	d.SetId(name.(string))
 
-	err = PollingWaitTime(resourceIapBrandPollRead(d, meta), PollCheckForExistence, "Creating Brand", d.Timeout(schema.TimeoutCreate), 5)
+	err = PollingWaitTime(resourceIapBrandPollRead(d, meta), PollCheckForExistence, "Creating Brand", d.Timeout(schema.TimeoutCreate), 5, config.Polling.For("iap"))
 	if err != nil {
 		return fmt.Errorf("Error waiting to create Brand: %s", err)
 	}
@@ -280,7 +280,7 @@ func resourceMonitoringMetricDescriptorCreate(d *schema.ResourceData, meta inter
 	}
 	d.SetId(id)
 
-	err = PollingWaitTime(resourceMonitoringMetricDescriptorPollRead(d, meta), PollCheckForExistence, "Creating MetricDescriptor", d.Timeout(schema.TimeoutCreate), 20)
+	err = PollingWaitTime(resourceMonitoringMetricDescriptorPollRead(d, meta), PollCheckForExistence, "Creating MetricDescriptor", d.Timeout(schema.TimeoutCreate), 20, config.Polling.For("monitoring"))
 	if err != nil {
 		return fmt.Errorf("Error waiting to create MetricDescriptor: %s", err)
 	}
@@ -481,7 +481,7 @@ func resourceMonitoringMetricDescriptorUpdate(d *schema.ResourceData, meta inter
 		log.Printf("[DEBUG] Finished updating MetricDescriptor %q: %#v", d.Id(), res)
 	}
 
-	err = PollingWaitTime(resourceMonitoringMetricDescriptorPollRead(d, meta), PollCheckForExistence, "Updating MetricDescriptor", d.Timeout(schema.TimeoutUpdate), 20)
+	err = PollingWaitTime(resourceMonitoringMetricDescriptorPollRead(d, meta), PollCheckForExistence, "Updating MetricDescriptor", d.Timeout(schema.TimeoutUpdate), 20, config.Polling.For("monitoring"))
 	if err != nil {
 		return err
 	}
@@ -522,7 +522,7 @@ func resourceMonitoringMetricDescriptorDelete(d *schema.ResourceData, meta inter
 		return handleNotFoundError(err, d, "MetricDescriptor")
 	}
 
-	err = PollingWaitTime(resourceMonitoringMetricDescriptorPollRead(d, meta), PollCheckForAbsence, "Deleting MetricDescriptor", d.Timeout(schema.TimeoutCreate), 20)
+	err = PollingWaitTime(resourceMonitoringMetricDescriptorPollRead(d, meta), PollCheckForAbsence, "Deleting MetricDescriptor", d.Timeout(schema.TimeoutCreate), 20, config.Polling.For("monitoring"))
 	if err != nil {
 		return fmt.Errorf("Error waiting to delete MetricDescriptor: %s", err)
 	}
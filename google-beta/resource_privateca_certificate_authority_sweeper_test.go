@@ -90,7 +90,7 @@ func testSweepCertificateAuthority(region string) error {
 			// Increment count and skip if resource is not sweepable.
 			nameParts := strings.Split(caName, "/")
 			id := nameParts[len(nameParts)-1]
-			if !IsSweepableTestResource(id) {
+			if !IsSweepableTestObject(id, obj) {
 				nonPrefixCount++
 				continue
 			}
@@ -99,6 +99,10 @@ func testSweepCertificateAuthority(region string) error {
 				continue
 			}
 
+			if skipSweepInDryRun(resourceName, caName) {
+				continue
+			}
+
 			if obj["state"] == "ENABLED" {
 				disableUrl := fmt.Sprintf("%s%s:disable", config.PrivatecaBasePath, caName)
 				_, err = SendRequest(config, "POST", config.Project, disableUrl, config.UserAgent, nil)
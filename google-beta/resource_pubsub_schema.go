@@ -248,7 +248,7 @@ func resourcePubsubSchemaDelete(d *schema.ResourceData, meta interface{}) error
 		return handleNotFoundError(err, d, "Schema")
 	}
 
-	err = PollingWaitTime(resourcePubsubSchemaPollRead(d, meta), PollCheckForAbsence, "Deleting Schema", d.Timeout(schema.TimeoutCreate), 10)
+	err = PollingWaitTime(resourcePubsubSchemaPollRead(d, meta), PollCheckForAbsence, "Deleting Schema", d.Timeout(schema.TimeoutCreate), 10, config.Polling.For("pubsub"))
 	if err != nil {
 		return fmt.Errorf("Error waiting to delete Schema: %s", err)
 	}
@@ -294,3 +294,50 @@ func testAccCheckPubsubSubscriptionCache404(t *testing.T, subName string) resour
 		return nil
 	}
 }
+
+func TestFakeGcpPubsubSubscription_update(t *testing.T) {
+	srv := newFakeGcpServer(t)
+
+	fakeGcpTest(t, srv, resource.TestCase{
+		CheckDestroy: fakeGcpCheckNoResources(srv, "pubsub/"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeGcpPubsubSubscription_update(""),
+				Check:  resource.TestCheckResourceAttr("google_pubsub_subscription.subscription", "ack_deadline_seconds", "10"),
+			},
+			{
+				Config: testFakeGcpPubsubSubscription_update("ack_deadline_seconds = 20"),
+				Check: fakeGcpCheckResource(srv, "pubsub/projects/fake-project/subscriptions/subscription", func(s map[string]interface{}) error {
+					if s["ackDeadlineSeconds"] != float64(20) {
+						return fmt.Errorf("expected the ack deadline to be updated, got %v", s)
+					}
+					return nil
+				}),
+			},
+			{
+				ResourceName:      "google_pubsub_subscription.subscription",
+				ImportStateId:     "fake-project/subscription",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testFakeGcpPubsubSubscription_update(extra string) string {
+	return fmt.Sprintf(`
+resource "google_pubsub_topic" "topic" {
+  name = "topic"
+
+  labels = {
+    env = "test"
+  }
+}
+
+resource "google_pubsub_subscription" "subscription" {
+  name  = "subscription"
+  topic = google_pubsub_topic.topic.id
+  %s
+}
+`, extra)
+}
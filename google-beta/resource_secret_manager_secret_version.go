@@ -30,6 +30,10 @@ import (
 func resourceSecretManagerSecretVersionUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 
+	if err := verifySecretManagerSecretVersionImportedData(d, config); err != nil {
+		return err
+	}
+
 	_, err := expandSecretManagerSecretVersionEnabled(d.Get("enabled"), d, config)
 	if err != nil {
 		return err
@@ -48,6 +52,8 @@ func ResourceSecretManagerSecretVersion() *schema.Resource {
 			State: resourceSecretManagerSecretVersionImport,
 		},
 
+		CustomizeDiff: resourceSecretManagerSecretVersionPayloadDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
@@ -57,11 +63,26 @@ func ResourceSecretManagerSecretVersion() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"secret_data": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: `The secret data. Must be no larger than 64KiB.`,
-				Sensitive:   true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  `The secret data. Must be no larger than 64KiB. The secret data is stored in state; use 'secret_data_wo' to keep it out of state.`,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"secret_data", "secret_data_wo"},
+			},
+			"secret_data_wo": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				StateFunc: writeOnlyStateFunc,
+				Description: `The secret data, written to the secret version but never stored in state or read back. Must be no
+larger than 64KiB. Changes to the value alone are ignored; change 'secret_data_wo_version' to create a new version with it.`,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"secret_data", "secret_data_wo"},
+			},
+			"secret_data_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"secret_data_wo"},
+				Description:  `A version of 'secret_data_wo' of your choosing. Changing it creates a new secret version with the current value of 'secret_data_wo'.`,
 			},
 
 			"secret": {
@@ -335,6 +356,10 @@ func flattenSecretManagerSecretVersionDestroyTime(v interface{}, d *schema.Resou
 }
 
 func flattenSecretManagerSecretVersionPayload(v interface{}, d *schema.ResourceData, config *Config) interface{} {
+	if !secretManagerSecretVersionReadsSecretData(d) {
+		return nil
+	}
+
 	transformed := make(map[string]interface{})
 
 	// if this secret version is disabled, the api will return an error, as the value cannot be accessed, return what we have
@@ -404,7 +429,7 @@ func expandSecretManagerSecretVersionEnabled(v interface{}, d TerraformResourceD
 
 func expandSecretManagerSecretVersionPayload(v interface{}, d TerraformResourceData, config *Config) (interface{}, error) {
 	transformed := make(map[string]interface{})
-	transformedSecretData, err := expandSecretManagerSecretVersionPayloadSecretData(d.Get("secret_data"), d, config)
+	transformedSecretData, err := expandSecretManagerSecretVersionPayloadSecretData(secretManagerSecretVersionSecretData(d), d, config)
 	if err != nil {
 		return nil, err
 	} else if val := reflect.ValueOf(transformedSecretData); val.IsValid() && !isEmptyValue(val) {
@@ -1,9 +1,16 @@
 package google
 
 import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-google-beta/google-beta/fakegcp"
 )
 
 func TestAccSecretManagerSecretVersion_update(t *testing.T) {
@@ -25,6 +32,8 @@ func TestAccSecretManagerSecretVersion_update(t *testing.T) {
 				ResourceName:      "google_secret_manager_secret_version.secret-version-basic",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// the secret data isn't read on import
+				ImportStateVerifyIgnore: []string{"secret_data"},
 			},
 			{
 				Config: testAccSecretManagerSecretVersion_disable(context),
@@ -33,8 +42,7 @@ func TestAccSecretManagerSecretVersion_update(t *testing.T) {
 				ResourceName:      "google_secret_manager_secret_version.secret-version-basic",
 				ImportState:       true,
 				ImportStateVerify: true,
-				// at this point the secret data is disabled and so reading the data on import will
-				// give an empty string
+				// the secret data isn't read on import
 				ImportStateVerifyIgnore: []string{"secret_data"},
 			},
 			{
@@ -44,6 +52,8 @@ func TestAccSecretManagerSecretVersion_update(t *testing.T) {
 				ResourceName:      "google_secret_manager_secret_version.secret-version-basic",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// the secret data isn't read on import
+				ImportStateVerifyIgnore: []string{"secret_data"},
 			},
 		},
 	})
@@ -94,3 +104,223 @@ resource "google_secret_manager_secret_version" "secret-version-basic" {
 }
 `, context)
 }
+
+func TestFakeGcpSecretManagerSecretVersion_import(t *testing.T) {
+	srv := newFakeGcpServer(t)
+	imported := testFakeGcpSecretManagerSecretVersion_imported("imported", `secret_data = "s3cr3t"`)
+	other := testFakeGcpSecretManagerSecretVersion_imported("other", `secret_data = "0th3r"`)
+
+	fakeGcpTest(t, srv, resource.TestCase{
+		CheckDestroy: fakeGcpCheckNoResources(srv, "secretmanager/"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeGcpSecretManagerSecretVersion_import(""),
+				Check:  testFakeGcpCheckLatestSecretVersion(srv, "s3cr3t"),
+			},
+			// The secret data of an imported version is only stored once
+			// planned from the configuration, without replacing the version
+			// if it matches.
+			{
+				PreConfig:          testFakeGcpAddSecretVersion(t, srv, "s3cr3t"),
+				Config:             testFakeGcpSecretManagerSecretVersion_import(imported),
+				ResourceName:       "google_secret_manager_secret_version.imported",
+				ImportStateIdFunc:  testFakeGcpSecretManagerSecretVersionId(2),
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateCheck:   testFakeGcpCheckImportedSecretVersion(2, "s3cr3t"),
+			},
+			{
+				Config: testFakeGcpSecretManagerSecretVersion_import(imported),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_secret_manager_secret_version.imported", "secret_data", "s3cr3t"),
+					testFakeGcpCheckSecretVersionCount(srv, 2),
+				),
+			},
+			// Imported versions are checked when applying, as the SDK doesn't
+			// pass provider_meta to CustomizeDiff.
+			{
+				PreConfig:          testFakeGcpAddSecretVersion(t, srv, "s3cr3t"),
+				Config:             testFakeGcpSecretManagerSecretVersion_import(imported + other),
+				ResourceName:       "google_secret_manager_secret_version.other",
+				ImportStateIdFunc:  testFakeGcpSecretManagerSecretVersionId(3),
+				ImportState:        true,
+				ImportStatePersist: true,
+			},
+			{
+				Config:      testFakeGcpSecretManagerSecretVersion_import(imported + other),
+				ExpectError: regexp.MustCompile("doesn't match the configuration"),
+			},
+		},
+	})
+}
+
+func TestFakeGcpSecretManagerSecretVersion_writeOnly(t *testing.T) {
+	srv := newFakeGcpServer(t)
+	imported := testFakeGcpSecretManagerSecretVersion_imported("imported", "secret_data_wo = \"s3cr3t\"\n  secret_data_wo_version = 1")
+
+	fakeGcpTest(t, srv, resource.TestCase{
+		CheckDestroy: fakeGcpCheckNoResources(srv, "secretmanager/"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeGcpSecretManagerSecretVersion_writeOnly("s3cr3t", 1, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testFakeGcpCheckNoSecretDataInState("s3cr3t"),
+					resource.TestCheckResourceAttr("google_secret_manager_secret_version.version", "secret_data_wo", writeOnlyStateMarker),
+					testFakeGcpCheckLatestSecretVersion(srv, "s3cr3t"),
+				),
+			},
+			{
+				PreConfig:          testFakeGcpAddSecretVersion(t, srv, "s3cr3t"),
+				Config:             testFakeGcpSecretManagerSecretVersion_writeOnly("s3cr3t", 1, imported),
+				ResourceName:       "google_secret_manager_secret_version.imported",
+				ImportStateIdFunc:  testFakeGcpSecretManagerSecretVersionId(2),
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateCheck:   testFakeGcpCheckImportedSecretVersion(2, "s3cr3t"),
+			},
+			{
+				Config: testFakeGcpSecretManagerSecretVersion_writeOnly("s3cr3t", 1, imported),
+				Check: resource.ComposeTestCheckFunc(
+					testFakeGcpCheckNoSecretDataInState("s3cr3t"),
+					testFakeGcpCheckSecretVersionCount(srv, 2),
+				),
+			},
+			// Changes to the write-only value alone are ignored.
+			{
+				Config:   testFakeGcpSecretManagerSecretVersion_writeOnly("n3w-s3cr3t", 1, imported),
+				PlanOnly: true,
+			},
+			// A new secret_data_wo_version replaces the version.
+			{
+				Config:             testFakeGcpSecretManagerSecretVersion_writeOnly("n3w-s3cr3t", 2, imported),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testFakeGcpSecretManagerSecretVersion_import(extra string) string {
+	return fmt.Sprintf(`
+resource "google_secret_manager_secret" "secret" {
+  secret_id = "secret"
+
+  replication {
+    automatic = true
+  }
+}
+
+resource "google_secret_manager_secret_version" "version" {
+  secret      = google_secret_manager_secret.secret.name
+  secret_data = "s3cr3t"
+}
+%s`, extra)
+}
+
+func testFakeGcpSecretManagerSecretVersion_writeOnly(data string, version int, extra string) string {
+	return fmt.Sprintf(`
+resource "google_secret_manager_secret" "secret" {
+  secret_id = "secret"
+
+  replication {
+    automatic = true
+  }
+}
+
+resource "google_secret_manager_secret_version" "version" {
+  secret                 = google_secret_manager_secret.secret.name
+  secret_data_wo         = %q
+  secret_data_wo_version = %d
+}
+%s`, data, version, extra)
+}
+
+// testFakeGcpSecretManagerSecretVersion_imported returns the configuration of
+// a version imported from the fake server, with the given secret data fields.
+// The API names the secret by project number, as its name does.
+func testFakeGcpSecretManagerSecretVersion_imported(name, data string) string {
+	return fmt.Sprintf(`
+resource "google_secret_manager_secret_version" "%s" {
+  secret = google_secret_manager_secret.secret.name
+  %s
+}
+`, name, data)
+}
+
+// testFakeGcpAddSecretVersion returns a function adding a version of the
+// secret outside of Terraform, to be imported.
+func testFakeGcpAddSecretVersion(t *testing.T, srv *fakegcp.Server, data string) func() {
+	return func() {
+		body := fmt.Sprintf(`{"payload": {"data": %q}}`, base64.StdEncoding.EncodeToString([]byte(data)))
+		res, err := http.Post(srv.URL+"/secretmanager/v1/projects/fake-project/secrets/secret:addVersion", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("error adding a secret version: %s", res.Status)
+		}
+	}
+}
+
+func testFakeGcpSecretManagerSecretVersionId(version int) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources["google_secret_manager_secret.secret"]
+		if !ok {
+			return "", fmt.Errorf("google_secret_manager_secret.secret not found")
+		}
+		return fmt.Sprintf("%s/versions/%d", rs.Primary.Attributes["name"], version), nil
+	}
+}
+
+// testFakeGcpCheckImportedSecretVersion checks that the secret data isn't
+// read when importing the given version.
+func testFakeGcpCheckImportedSecretVersion(version int, data string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		for _, s := range states {
+			if !strings.HasSuffix(s.ID, fmt.Sprintf("/versions/%d", version)) {
+				continue
+			}
+			for k, v := range s.Attributes {
+				if strings.Contains(v, data) {
+					return fmt.Errorf("expected the secret data not to be read on import, got %s = %q", k, v)
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("version %d not imported", version)
+	}
+}
+
+func testFakeGcpCheckNoSecretDataInState(data string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for name, rs := range s.RootModule().Resources {
+			for k, v := range rs.Primary.Attributes {
+				if strings.Contains(v, data) {
+					return fmt.Errorf("expected the secret data not to be stored in state, got %s.%s = %q", name, k, v)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func testFakeGcpCheckLatestSecretVersion(srv *fakegcp.Server, data string) resource.TestCheckFunc {
+	return fakeGcpCheckContent(srv, "/secretmanager/v1/projects/fake-project/secrets/secret/versions/latest:access", func(b []byte) error {
+		if !strings.Contains(string(b), base64.StdEncoding.EncodeToString([]byte(data))) {
+			return fmt.Errorf("expected the latest version to contain the secret data, got %s", b)
+		}
+		return nil
+	})
+}
+
+// testFakeGcpCheckSecretVersionCount checks the number of versions of the
+// secret, to check that none was replaced.
+func testFakeGcpCheckSecretVersionCount(srv *fakegcp.Server, n int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if got := srv.Resources("secretmanager/projects/fake-project/secrets/secret/versions/"); len(got) != n {
+			return fmt.Errorf("expected %d secret versions, got %v", n, got)
+		}
+		return nil
+	}
+}
@@ -62,11 +62,15 @@ func testSweepSpannerInstance(region string) error {
 		shortName := name[strings.LastIndex(name, "/")+1:]
 
 		// Increment count and skip if resource is not sweepable.
-		if !IsSweepableTestResource(shortName) {
+		if !IsSweepableTestObject(shortName, obj) {
 			nonPrefixCount++
 			continue
 		}
 
+		if skipSweepInDryRun(resourceName, shortName) {
+			continue
+		}
+
 		deleteUrl := spannerUrl + "/" + name
 		// Don't wait on operations as we may have a lot to delete
 		_, err = SendRequest(config, "DELETE", config.Project, deleteUrl, config.UserAgent, nil)
@@ -115,6 +115,7 @@ func ResourceSqlDatabaseInstance() *schema.Resource {
 			customdiff.IfValueChange("instance_type", isReplicaPromoteRequested, checkPromoteConfigurationsAndUpdateDiff),
 			privateNetworkCustomizeDiff,
 			pitrSupportDbCustomizeDiff,
+			customizeDiffDisruptiveUpdates(sqlDatabaseInstanceDisruptiveUpdates),
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -616,10 +617,25 @@ is set to true. Defaults to ZONAL.`,
 			},
 
 			"root_password": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: `Initial root password. Required for MS SQL Server.`,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"root_password_wo"},
+				Description:   `Initial root password. Required for MS SQL Server. The password is stored in state; use root_password_wo to keep it out of state.`,
+			},
+			"root_password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				StateFunc:     writeOnlyStateFunc,
+				ConflictsWith: []string{"root_password"},
+				Description:   `Initial root password, sent to Cloud SQL but never stored in state. Changes to the value alone are ignored; change root_password_wo_version to update the root password.`,
+			},
+			"root_password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"root_password_wo"},
+				Description:  `A version of root_password_wo of your choosing. Changing it updates the root password to the current value of root_password_wo.`,
 			},
 			"ip_address": {
 				Type:     schema.TypeList,
@@ -847,6 +863,35 @@ is set to true. Defaults to ZONAL.`,
 					},
 				},
 			},
+			"restore": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: `Restores the existing instance from one of its backups whenever restore_trigger changes.`,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"restore_trigger": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: `An arbitrary value, such as a timestamp or a counter. Changing it from a previous value restores the instance, overwriting its data. Adding the restore block only records it.`,
+						},
+						"backup_run_id": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ExactlyOneOf: []string{"restore.0.backup_run_id", "restore.0.backup_before"},
+							Description:  `The ID of the backup run to restore.`,
+						},
+						"backup_before": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ExactlyOneOf:     []string{"restore.0.backup_run_id", "restore.0.backup_before"},
+							ValidateFunc:     validateRFC3339Date,
+							DiffSuppressFunc: TimestampDiffSuppress(time.RFC3339Nano),
+							Description:      `A timestamp in RFC3339 format. The most recent successful backup run that completed at or before it is restored. This is not a point-in-time recovery: changes made after that backup run are lost.`,
+						},
+					},
+				},
+			},
 			"clone": {
 				Type:         schema.TypeList,
 				Optional:     true,
@@ -989,6 +1034,9 @@ func resourceSqlDatabaseInstanceCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	instance.RootPassword = d.Get("root_password").(string)
+	if v := getWriteOnlyString(d, "root_password_wo"); v != "" {
+		instance.RootPassword = v
+	}
 
 	// Modifying a replica during Create can cause problems if the master is
 	// modified at the same time. Lock the master until we're done in order
@@ -1124,7 +1172,7 @@ func resourceSqlDatabaseInstanceCreate(d *schema.ResourceData, meta interface{})
 
 	// Perform a backup restore if the backup context exists
 	if r, ok := d.GetOk("restore_backup_context"); ok {
-		err = sqlDatabaseInstanceRestoreFromBackup(d, config, userAgent, project, name, r)
+		err = sqlDatabaseInstanceRestoreFromBackup(d, config, userAgent, project, name, expandRestoreBackupContext(r.([]interface{})))
 		if err != nil {
 			return err
 		}
@@ -1585,9 +1633,24 @@ func resourceSqlDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 
 	// Check if the root_password is being updated, because updating root_password is an atomic operation and can not be
 	// performed with other fields, we first update root password before updating the rest of the fields.
-	if d.HasChange("root_password") {
+	if d.HasChange("root_password") || d.HasChange("root_password_wo_version") {
 		oldPwd, newPwd := d.GetChange("root_password")
 		password := newPwd.(string)
+		if v := getWriteOnlyString(d, "root_password_wo"); v != "" {
+			password = v
+		}
+		oldVersion, _ := d.GetChange("root_password_wo_version")
+		// If the update fails, the previous password and version are kept in
+		// state so that the next apply retries it.
+		resetRootPassword := func() error {
+			if err := d.Set("root_password", oldPwd.(string)); err != nil {
+				return fmt.Errorf("Error re-setting root_password: %s", err)
+			}
+			if err := d.Set("root_password_wo_version", oldVersion); err != nil {
+				return fmt.Errorf("Error re-setting root_password_wo_version: %s", err)
+			}
+			return nil
+		}
 		dv := d.Get("database_version").(string)
 		name := ""
 		host := ""
@@ -1599,14 +1662,14 @@ func resourceSqlDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 		} else if strings.Contains(dv, "SQLSERVER") {
 			name = "sqlserver"
 			if len(password) == 0 {
-				if err := d.Set("root_password", oldPwd.(string)); err != nil {
-					return fmt.Errorf("Error re-setting root_password: %s", err)
+				if err := resetRootPassword(); err != nil {
+					return err
 				}
 				return fmt.Errorf("Error, root password cannot be empty for SQL Server instance.")
 			}
 		} else {
-			if err := d.Set("root_password", oldPwd.(string)); err != nil {
-				return fmt.Errorf("Error re-setting root_password: %s", err)
+			if err := resetRootPassword(); err != nil {
+				return err
 			}
 			return fmt.Errorf("Error, invalid database version")
 		}
@@ -1628,8 +1691,8 @@ func resourceSqlDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 		err = RetryTimeDuration(updateFunc, d.Timeout(schema.TimeoutUpdate))
 
 		if err != nil {
-			if err := d.Set("root_password", oldPwd.(string)); err != nil {
-				return fmt.Errorf("Error re-setting root_password: %s", err)
+			if err := resetRootPassword(); err != nil {
+				return err
 			}
 			return fmt.Errorf("Error, failed to update root_password : %s", err)
 		}
@@ -1637,8 +1700,8 @@ func resourceSqlDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 		err = SqlAdminOperationWaitTime(config, op, project, "Insert User", userAgent, d.Timeout(schema.TimeoutUpdate))
 
 		if err != nil {
-			if err := d.Set("root_password", oldPwd.(string)); err != nil {
-				return fmt.Errorf("Error re-setting root_password: %s", err)
+			if err := resetRootPassword(); err != nil {
+				return err
 			}
 			return fmt.Errorf("Error, failed to update root_password : %s", err)
 		}
@@ -1722,13 +1785,37 @@ func resourceSqlDatabaseInstanceUpdate(d *schema.ResourceData, meta interface{})
 	// Perform a backup restore if the backup context exists and has changed
 	if r, ok := d.GetOk("restore_backup_context"); ok {
 		if d.HasChange("restore_backup_context") {
-			err = sqlDatabaseInstanceRestoreFromBackup(d, config, userAgent, project, d.Get("name").(string), r)
+			err = sqlDatabaseInstanceRestoreFromBackup(d, config, userAgent, project, d.Get("name").(string), expandRestoreBackupContext(r.([]interface{})))
 			if err != nil {
 				return err
 			}
 		}
 	}
 
+	if isSqlDatabaseInstanceRestoreRequested(d) {
+		oldTrigger, _ := d.GetChange("restore.0.restore_trigger")
+		// If the restore fails, the previous trigger is kept in state so that
+		// the next apply retries it.
+		resetRestoreTrigger := func() error {
+			restore := d.Get("restore").([]interface{})
+			restore[0].(map[string]interface{})["restore_trigger"] = oldTrigger.(string)
+			if err := d.Set("restore", restore); err != nil {
+				return fmt.Errorf("Error re-setting restore.0.restore_trigger: %s", err)
+			}
+			return nil
+		}
+		restoreContext, err := expandSqlDatabaseInstanceRestore(d, config, userAgent, project)
+		if err == nil {
+			err = sqlDatabaseInstanceRestoreFromBackup(d, config, userAgent, project, d.Get("name").(string), restoreContext)
+		}
+		if err != nil {
+			if err := resetRestoreTrigger(); err != nil {
+				return err
+			}
+			return err
+		}
+	}
+
 	return resourceSqlDatabaseInstanceRead(d, meta)
 }
 
@@ -2149,12 +2236,89 @@ func expandRestoreBackupContext(configured []interface{}) *sqladmin.RestoreBacku
 	}
 }
 
-func sqlDatabaseInstanceRestoreFromBackup(d *schema.ResourceData, config *Config, userAgent, project, instanceId string, r interface{}) error {
+// isSqlDatabaseInstanceRestoreRequested returns whether the restore block asks
+// for a restore, that is whether its trigger changed from a previous value.
+// Adding the block, to a new instance or to an existing one, only records the
+// trigger: a restore overwrites the data of the instance, so it's never done
+// without the trigger being changed on purpose.
+func isSqlDatabaseInstanceRestoreRequested(d interface {
+	GetChange(string) (interface{}, interface{})
+}) bool {
+	o, n := d.GetChange("restore.0.restore_trigger")
+	oldTrigger, _ := o.(string)
+	newTrigger, _ := n.(string)
+	return oldTrigger != "" && newTrigger != "" && oldTrigger != newTrigger
+}
+
+// expandSqlDatabaseInstanceRestore returns the backup run to restore for the
+// restore block, looking up the latest backup run before backup_before if set.
+func expandSqlDatabaseInstanceRestore(d *schema.ResourceData, config *Config, userAgent, project string) (*sqladmin.RestoreBackupContext, error) {
+	instance := d.Get("name").(string)
+	if id, ok := d.GetOk("restore.0.backup_run_id"); ok {
+		return &sqladmin.RestoreBackupContext{
+			BackupRunId: int64(id.(int)),
+			InstanceId:  instance,
+			Project:     project,
+		}, nil
+	}
+
+	before, err := time.Parse(time.RFC3339Nano, d.Get("restore.0.backup_before").(string))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing restore.0.backup_before: %s", err)
+	}
+
+	var runs []*sqladmin.BackupRun
+	err = config.NewSqlAdminClient(userAgent).BackupRuns.List(project, instance).Pages(config.context, func(res *sqladmin.BackupRunsListResponse) error {
+		runs = append(runs, res.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing backup runs of SQL Database Instance %s: %s", instance, err)
+	}
+
+	run, err := sqlDatabaseInstanceBackupRunBefore(runs, before)
+	if err != nil {
+		return nil, fmt.Errorf("Error finding the backup run of SQL Database Instance %s to restore: %s", instance, err)
+	}
+	log.Printf("[DEBUG] Restoring SQL Database Instance %s from backup run %d, completed at %s", instance, run.Id, run.EndTime)
+
+	return &sqladmin.RestoreBackupContext{
+		BackupRunId: run.Id,
+		InstanceId:  instance,
+		Project:     project,
+	}, nil
+}
+
+// sqlDatabaseInstanceBackupRunBefore returns the most recent of the successful
+// runs that completed at or before the given time.
+func sqlDatabaseInstanceBackupRunBefore(runs []*sqladmin.BackupRun, before time.Time) (*sqladmin.BackupRun, error) {
+	var found *sqladmin.BackupRun
+	var foundEnd time.Time
+	for _, run := range runs {
+		if run.Status != "SUCCESSFUL" {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339Nano, run.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing end time of backup run %d: %s", run.Id, err)
+		}
+		if end.After(before) || (found != nil && !end.After(foundEnd)) {
+			continue
+		}
+		found, foundEnd = run, end
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no successful backup run completed at or before %s", before.Format(time.RFC3339))
+	}
+	return found, nil
+}
+
+func sqlDatabaseInstanceRestoreFromBackup(d *schema.ResourceData, config *Config, userAgent, project, instanceId string, restoreContext *sqladmin.RestoreBackupContext) error {
 	log.Printf("[DEBUG] Initiating SQL database instance backup restore")
-	restoreContext := r.([]interface{})
 
 	backupRequest := &sqladmin.InstancesRestoreBackupRequest{
-		RestoreBackupContext: expandRestoreBackupContext(restoreContext),
+		RestoreBackupContext: restoreContext,
 	}
 
 	var op *sqladmin.Operation
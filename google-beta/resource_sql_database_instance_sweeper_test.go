@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 )
 
 func init() {
@@ -42,7 +43,7 @@ func testSweepSQLDatabaseInstance(region string) error {
 	running := map[string]struct{}{}
 
 	for _, d := range found.Items {
-		if !IsSweepableTestResource(d.Name) {
+		if !IsSweepableTestResourceCreatedAt(d.Name, "", sqlDatabaseInstanceUserLabels(d)) {
 			continue
 		}
 
@@ -53,7 +54,7 @@ func testSweepSQLDatabaseInstance(region string) error {
 	}
 
 	for _, d := range found.Items {
-		if !IsSweepableTestResource(d.Name) {
+		if !IsSweepableTestResourceCreatedAt(d.Name, d.CreateTime, sqlDatabaseInstanceUserLabels(d)) {
 			continue
 		}
 
@@ -62,6 +63,9 @@ func testSweepSQLDatabaseInstance(region string) error {
 		if d.ReplicaConfiguration != nil {
 			continue
 		}
+		if skipSweepInDryRun("SQLDatabaseInstance", d.Name) {
+			continue
+		}
 		log.Printf("Destroying SQL Instance (%s)", d.Name)
 
 		// replicas need to be stopped and destroyed before destroying a master
@@ -129,3 +133,10 @@ func testSweepSQLDatabaseInstance(region string) error {
 
 	return nil
 }
+
+func sqlDatabaseInstanceUserLabels(d *sqladmin.DatabaseInstance) map[string]string {
+	if d.Settings == nil {
+		return nil
+	}
+	return d.Settings.UserLabels
+}
@@ -60,6 +60,57 @@ func TestMaintenanceVersionDiffSuppress(t *testing.T) {
 	}
 }
 
+func TestSqlDatabaseInstanceBackupRunBefore(t *testing.T) {
+	t.Parallel()
+
+	runs := []*sqladmin.BackupRun{
+		{Id: 3, Status: "SUCCESSFUL", EndTime: "2023-01-03T00:00:00.000Z"},
+		{Id: 2, Status: "FAILED", EndTime: "2023-01-02T12:00:00.000Z"},
+		{Id: 1, Status: "SUCCESSFUL", EndTime: "2023-01-02T00:00:00.000Z"},
+		{Id: 0, Status: "SUCCESSFUL", EndTime: "2023-01-01T00:00:00.000Z"},
+	}
+
+	cases := map[string]struct {
+		Before      string
+		ExpectedId  int64
+		ExpectError bool
+	}{
+		"between runs restores the previous run": {
+			Before:     "2023-01-02T18:00:00Z",
+			ExpectedId: 1,
+		},
+		"end of a run restores that run": {
+			Before:     "2023-01-03T00:00:00Z",
+			ExpectedId: 3,
+		},
+		"before any run": {
+			Before:      "2022-12-31T00:00:00Z",
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		before, err := time.Parse(time.RFC3339, tc.Before)
+		if err != nil {
+			t.Fatal(err)
+		}
+		run, err := sqlDatabaseInstanceBackupRunBefore(runs, before)
+		if tc.ExpectError {
+			if err == nil {
+				t.Errorf("bad: %s, expected an error, got backup run %d", tn, run.Id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("bad: %s, unexpected error: %s", tn, err)
+			continue
+		}
+		if run.Id != tc.ExpectedId {
+			t.Errorf("bad: %s, expected backup run %d, got %d", tn, tc.ExpectedId, run.Id)
+		}
+	}
+}
+
 func TestAccSqlDatabaseInstance_basicInferredName(t *testing.T) {
 	// Randomness
 	SkipIfVcr(t)
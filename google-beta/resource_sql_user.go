@@ -81,11 +81,29 @@ func ResourceSqlUser() *schema.Resource {
 			},
 
 			"password": {
-				Type:      schema.TypeString,
-				Optional:  true,
-				Sensitive: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password_wo"},
 				Description: `The password for the user. Can be updated. For Postgres instances this is a Required field, unless type is set to
-                either CLOUD_IAM_USER or CLOUD_IAM_SERVICE_ACCOUNT.`,
+                either CLOUD_IAM_USER or CLOUD_IAM_SERVICE_ACCOUNT. The password is stored in state; use password_wo to keep it out of state.`,
+			},
+
+			"password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				StateFunc:     writeOnlyStateFunc,
+				ConflictsWith: []string{"password"},
+				Description: `The password for the user, sent to Cloud SQL but never stored in state. Changes to the value alone are
+                ignored; change password_wo_version to update the user's password.`,
+			},
+
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  `A version of password_wo of your choosing. Changing it updates the user's password to the current value of password_wo.`,
 			},
 
 			"type": {
@@ -235,6 +253,9 @@ func resourceSqlUserCreate(d *schema.ResourceData, meta interface{}) error {
 	name := d.Get("name").(string)
 	instance := d.Get("instance").(string)
 	password := d.Get("password").(string)
+	if v := getWriteOnlyString(d, "password_wo"); v != "" {
+		password = v
+	}
 	host := d.Get("host").(string)
 	typ := d.Get("type").(string)
 
@@ -429,7 +450,7 @@ func resourceSqlUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	if d.HasChange("password") || d.HasChange("password_policy") {
+	if d.HasChange("password") || d.HasChange("password_wo_version") || d.HasChange("password_policy") {
 		project, err := getProject(d, config)
 		if err != nil {
 			return err
@@ -438,6 +459,9 @@ func resourceSqlUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		name := d.Get("name").(string)
 		instance := d.Get("instance").(string)
 		password := d.Get("password").(string)
+		if v := getWriteOnlyString(d, "password_wo"); v != "" {
+			password = v
+		}
 		host := d.Get("host").(string)
 
 		user := &sqladmin.User{
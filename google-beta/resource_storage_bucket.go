@@ -33,6 +33,7 @@ func ResourceStorageBucket() *schema.Resource {
 		},
 		CustomizeDiff: customdiff.All(
 			customdiff.ForceNewIfChange("retention_policy.0.is_locked", isPolicyLocked),
+			SetLabelsDiff,
 		),
 
 		Timeouts: &schema.ResourceTimeout{
@@ -87,6 +88,10 @@ func ResourceStorageBucket() *schema.Resource {
 				Description:      `A set of key/value label pairs to assign to the bucket.`,
 			},
 
+			"terraform_labels": TerraformLabelsSchema(false),
+
+			"effective_labels": EffectiveLabelsSchema(false),
+
 			"location": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -669,7 +674,7 @@ func resourceStorageBucketUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	if d.HasChange("labels") {
+	if d.HasChange("effective_labels") {
 		sb.Labels = expandLabels(d)
 		if len(sb.Labels) == 0 {
 			sb.NullFields = append(sb.NullFields, "Labels")
@@ -677,7 +682,7 @@ func resourceStorageBucketUpdate(d *schema.ResourceData, meta interface{}) error
 
 		// To delete a label using PATCH, we have to explicitly set its value
 		// to null.
-		old, _ := d.GetChange("labels")
+		old, _ := d.GetChange("effective_labels")
 		for k := range old.(map[string]interface{}) {
 			if _, ok := sb.Labels[k]; !ok {
 				sb.NullFields = append(sb.NullFields, fmt.Sprintf("Labels.%s", k))
@@ -1563,8 +1568,8 @@ func setStorageBucket(d *schema.ResourceData, config *Config, res *storage.Bucke
 	if err := d.Set("lifecycle_rule", flattenBucketLifecycle(res.Lifecycle)); err != nil {
 		return fmt.Errorf("Error setting lifecycle_rule: %s", err)
 	}
-	if err := d.Set("labels", res.Labels); err != nil {
-		return fmt.Errorf("Error setting labels: %s", err)
+	if err := SetLabelsFields(res.Labels, d); err != nil {
+		return err
 	}
 	if err := d.Set("website", flattenBucketWebsite(res.Website)); err != nil {
 		return fmt.Errorf("Error setting website: %s", err)
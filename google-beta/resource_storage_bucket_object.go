@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"crypto/md5"
 	"crypto/sha256"
@@ -129,13 +130,18 @@ func ResourceStorageBucketObject() *schema.Resource {
 				// 2. Compare the computed md5 hash with the hash stored in Cloud Storage
 				// 3. Don't suppress the diff iff they don't match
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					getFileHash, getContentHash := getFileMd5Hash, getContentMd5Hash
+					if strings.HasPrefix(old, detectCrc32cPrefix) {
+						getFileHash, getContentHash = getFileCrc32c, getContentCrc32c
+					}
+
 					localMd5Hash := ""
 					if source, ok := d.GetOkExists("source"); ok {
-						localMd5Hash = getFileMd5Hash(source.(string))
+						localMd5Hash = getFileHash(source.(string))
 					}
 
 					if content, ok := d.GetOkExists("content"); ok {
-						localMd5Hash = getContentMd5Hash([]byte(content.(string)))
+						localMd5Hash = getContentHash([]byte(content.(string)))
 					}
 
 					// If `source` or `content` is dynamically set, both field will be empty.
@@ -218,6 +224,34 @@ func ResourceStorageBucketObject() *schema.Resource {
 				Description: `Whether an object is under temporary hold. While this flag is set to true, the object is protected against deletion and overwrites.`,
 			},
 
+			"upload_chunk_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateStorageObjectUploadChunkSize,
+				Description:  `The size in bytes of the chunks of resumable uploads, a multiple of 256 KiB. Data larger than a chunk is uploaded one chunk at a time, retrying failed chunks rather than the whole upload. Defaults to 16 MiB.`,
+			},
+
+			"upload_chunk_retry_deadline": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateNonNegativeDuration(),
+				Description:  `How long the upload of a chunk is retried for, such as "2m". Defaults to 32s.`,
+			},
+
+			"parallel_composite_upload_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  `The size in bytes from which the data is uploaded in parts uploaded in parallel, then composed into the object. Composite objects have no MD5 hash. Disabled when 0, the default.`,
+			},
+
+			"parallel_composite_upload_parts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(2, storageObjectMaxComposeParts),
+				Description:  `The number of parts of parallel composite uploads. Defaults to 8.`,
+			},
+
 			"metadata": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -274,20 +308,31 @@ func resourceStorageBucketObjectCreate(d *schema.ResourceData, meta interface{})
 
 	bucket := d.Get("bucket").(string)
 	name := d.Get("name").(string)
-	var media io.Reader
+	var media io.ReaderAt
+	var size int64
 
 	if v, ok := d.GetOk("source"); ok {
-		var err error
-		media, err = os.Open(v.(string))
+		f, err := os.Open(v.(string))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		fi, err := f.Stat()
 		if err != nil {
 			return err
 		}
+		media, size = f, fi.Size()
 	} else if v, ok := d.GetOk("content"); ok {
-		media = bytes.NewReader([]byte(v.(string)))
+		media, size = bytes.NewReader([]byte(v.(string))), int64(len(v.(string)))
 	} else {
 		return fmt.Errorf("Error, either \"content\" or \"source\" must be specified")
 	}
 
+	uploadOptions, err := expandStorageObjectUploadOptions(d)
+	if err != nil {
+		return err
+	}
+
 	objectsService := storage.NewObjectsService(config.NewStorageClientWithTimeoutOverride(userAgent, d.Timeout(schema.TimeoutCreate)))
 	object := &storage.Object{Bucket: bucket}
 
@@ -331,20 +376,13 @@ func resourceStorageBucketObjectCreate(d *schema.ResourceData, meta interface{})
 		object.TemporaryHold = v.(bool)
 	}
 
-	insertCall := objectsService.Insert(bucket, object)
-	insertCall.Name(name)
-	insertCall.Media(media)
-
-	// This is done late as we need to add headers to enable customer encryption
+	var customerEncryption map[string]string
 	if v, ok := d.GetOk("customer_encryption"); ok {
-		customerEncryption := expandCustomerEncryption(v.([]interface{}))
-		setEncryptionHeaders(customerEncryption, insertCall.Header())
+		customerEncryption = expandCustomerEncryption(v.([]interface{}))
 	}
 
-	_, err = insertCall.Do()
-
-	if err != nil {
-		return fmt.Errorf("Error uploading object %s: %s", name, err)
+	if _, err := uploadStorageObject(objectsService, object, name, media, size, uploadOptions, customerEncryption); err != nil {
+		return err
 	}
 
 	return resourceStorageBucketObjectRead(d, meta)
@@ -357,6 +395,11 @@ func resourceStorageBucketObjectUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	// The upload settings only apply to the next upload of the object.
+	if !d.HasChange("event_based_hold") && !d.HasChange("temporary_hold") {
+		return nil
+	}
+
 	bucket := d.Get("bucket").(string)
 	name := d.Get("name").(string)
 
@@ -415,7 +458,13 @@ func resourceStorageBucketObjectRead(d *schema.ResourceData, meta interface{}) e
 	if err := d.Set("md5hash", res.Md5Hash); err != nil {
 		return fmt.Errorf("Error setting md5hash: %s", err)
 	}
-	if err := d.Set("detect_md5hash", res.Md5Hash); err != nil {
+	// Composite objects, such as those uploaded in parallel, have no MD5 hash,
+	// so changes to them are detected with their CRC32C checksum instead.
+	detectHash := res.Md5Hash
+	if detectHash == "" && res.Crc32c != "" {
+		detectHash = detectCrc32cPrefix + res.Crc32c
+	}
+	if err := d.Set("detect_md5hash", detectHash); err != nil {
 		return fmt.Errorf("Error setting detect_md5hash: %s", err)
 	}
 	if err := d.Set("crc32c", res.Crc32c); err != nil {
@@ -521,6 +570,29 @@ func getContentMd5Hash(content []byte) string {
 	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
 
+func getFileCrc32c(filename string) string {
+	f, err := os.Open(filename)
+	if err != nil {
+		log.Printf("[WARN] Failed to read source file %q. Cannot compute crc32c checksum for it.", filename)
+		return ""
+	}
+	defer f.Close()
+	crc, err := storageCrc32c(f)
+	if err != nil {
+		log.Printf("[WARN] Failed to compute crc32c checksum for source file %q: %v", filename, err)
+		return ""
+	}
+	return detectCrc32cPrefix + crc
+}
+
+func getContentCrc32c(content []byte) string {
+	crc, err := storageCrc32c(bytes.NewReader(content))
+	if err != nil {
+		log.Printf("[WARN] Failed to compute crc32c checksum for content: %v", err)
+	}
+	return detectCrc32cPrefix + crc
+}
+
 func expandCustomerEncryption(input []interface{}) map[string]string {
 	expanded := make(map[string]string)
 	if input == nil {
@@ -1,11 +1,14 @@
 package google
 
 import (
+	"bytes"
 	"crypto/md5"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -640,3 +643,156 @@ func getNewTmpTestFile(t *testing.T, prefix string) *os.File {
 	}
 	return testFile
 }
+
+func TestFakeGcpStorageObject_basic(t *testing.T) {
+	srv := newFakeGcpServer(t)
+
+	source := filepath.Join(t.TempDir(), "object.txt")
+	if err := ioutil.WriteFile(source, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fakeGcpTest(t, srv, resource.TestCase{
+		CheckDestroy: fakeGcpCheckNoResources(srv, "storage/"),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeGcpStorageObject_basic(source, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_storage_bucket.bucket", "location", "US-CENTRAL1"),
+					resource.TestCheckResourceAttr("google_storage_bucket_object.object", "crc32c", "mnG7TA=="),
+					fakeGcpCheckContent(srv, "/storage/v1/b/fake-bucket/o/dir%2Fobject.txt?alt=media", func(b []byte) error {
+						if string(b) != "hello" {
+							return fmt.Errorf("expected the object to contain %q, got %q", "hello", b)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testFakeGcpStorageObject_basic(source, "prod"),
+				Check:  resource.TestCheckResourceAttr("google_storage_bucket.bucket", "labels.env", "prod"),
+			},
+			{
+				ResourceName:            "google_storage_bucket.bucket",
+				ImportStateId:           "fake-project/fake-bucket",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"labels", "terraform_labels", "force_destroy"},
+			},
+		},
+	})
+}
+
+func TestFakeGcpStorageObject_uploads(t *testing.T) {
+	srv := newFakeGcpServer(t)
+
+	// 1 MiB and a bit, so that it takes 5 chunks of 256 KiB.
+	data := bytes.Repeat([]byte("0123456789abcdef"), 65537)
+	source := filepath.Join(t.TempDir(), "artifact.bin")
+	if err := ioutil.WriteFile(source, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	crc, err := storageCrc32c(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fakeGcpTest(t, srv, resource.TestCase{
+		CheckDestroy: fakeGcpCheckNoResources(srv, "storage/"),
+		Steps: []resource.TestStep{
+			{
+				// A resumable upload survives failed chunks, which are
+				// retried alone.
+				PreConfig: func() { srv.FailUploadChunks(2) },
+				Config:    testFakeGcpStorageObject_uploads(source, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_storage_bucket_object.resumable", "crc32c", crc),
+					func(*terraform.State) error {
+						if got := srv.UploadedChunks(); got != 5 {
+							return fmt.Errorf("expected 5 chunks to be uploaded, got %d", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// A parallel composite upload leaves only the composed
+				// object, whose changes are detected without an MD5 hash.
+				Config: testFakeGcpStorageObject_uploads(source, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_storage_bucket_object.composite", "crc32c", crc),
+					resource.TestCheckResourceAttr("google_storage_bucket_object.composite", "detect_md5hash", detectCrc32cPrefix+crc),
+					func(*terraform.State) error {
+						if got := srv.Resources("storage/b/fake-bucket/o/composite.bin"); !reflect.DeepEqual(got, []string{"storage/b/fake-bucket/o/composite.bin"}) {
+							return fmt.Errorf("expected the parts of the composite object to be deleted, got %v", got)
+						}
+						return nil
+					},
+					fakeGcpCheckContent(srv, "/storage/v1/b/fake-bucket/o/composite.bin?alt=media", func(b []byte) error {
+						if !bytes.Equal(b, data) {
+							return fmt.Errorf("expected the composite object to contain the source file")
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := ioutil.WriteFile(source, []byte("changed"), 0644); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testFakeGcpStorageObject_uploads(source, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testFakeGcpStorageObject_basic(source, env string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+  name     = "fake-bucket"
+  location = "us-central1"
+
+  labels = {
+    env = "%s"
+  }
+}
+
+resource "google_storage_bucket_object" "object" {
+  name   = "dir/object.txt"
+  bucket = google_storage_bucket.bucket.name
+  source = %q
+}
+`, env, source)
+}
+
+func testFakeGcpStorageObject_uploads(source string, composite bool) string {
+	config := fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+  name     = "fake-bucket"
+  location = "us-central1"
+}
+
+resource "google_storage_bucket_object" "resumable" {
+  name              = "resumable.bin"
+  bucket            = google_storage_bucket.bucket.name
+  source            = %q
+  upload_chunk_size = 262144
+}
+`, source)
+	if composite {
+		config += fmt.Sprintf(`
+resource "google_storage_bucket_object" "composite" {
+  name                                = "composite.bin"
+  bucket                              = google_storage_bucket.bucket.name
+  source                              = %q
+  parallel_composite_upload_threshold = 1024
+  parallel_composite_upload_parts     = 4
+}
+`, source)
+	}
+	return config
+}
@@ -166,7 +166,7 @@ func resourceStorageHmacKeyCreate(d *schema.ResourceData, meta interface{}) erro
 
 	d.SetId(id)
 
-	err = PollingWaitTime(resourceStorageHmacKeyPollRead(d, meta), PollCheckForExistence, "Creating HmacKey", d.Timeout(schema.TimeoutCreate), 1)
+	err = PollingWaitTime(resourceStorageHmacKeyPollRead(d, meta), PollCheckForExistence, "Creating HmacKey", d.Timeout(schema.TimeoutCreate), 1, config.Polling.For("storage"))
 	if err != nil {
 		return fmt.Errorf("Error waiting to create HmacKey: %s", err)
 	}
@@ -36,8 +36,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"net/http"
 	"net/http/httputil"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -45,6 +47,125 @@ import (
 )
 
 const defaultRetryTransportTimeoutSec = 90
+const defaultRetryTransportInitialBackoffMs = 500
+
+// The smallest initial backoff and timeout a retry policy accepts: shorter
+// backoffs retry in a tight loop, and shorter timeouts leave no time for one.
+const minRetryInitialBackoff = 10 * time.Millisecond
+const minRetryTimeout = 1 * time.Second
+
+const (
+	RetryBackoffStrategyFibonacci   = "FIBONACCI"
+	RetryBackoffStrategyExponential = "EXPONENTIAL"
+)
+
+// RetryPolicy controls how the retryTransport spaces out and bounds its
+// retries of temporary errors.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of requests made, including the
+	// first one. Zero means attempts are only bounded by Timeout.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Strategy is one of RetryBackoffStrategyFibonacci or
+	// RetryBackoffStrategyExponential.
+	Strategy string
+	// Jitter enables full jitter, i.e. waiting a random duration between
+	// zero and the computed backoff, so parallel clients don't retry in lockstep.
+	Jitter bool
+	// Timeout bounds the whole retry loop if the request context has no deadline.
+	Timeout time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used when the provider doesn't
+// configure a `retry` block: Fibonacci backoff starting at 500ms, without
+// jitter, for up to 90 seconds.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialBackoff: defaultRetryTransportInitialBackoffMs * time.Millisecond,
+		Strategy:       RetryBackoffStrategyFibonacci,
+		Timeout:        defaultRetryTransportTimeoutSec * time.Second,
+	}
+}
+
+// backoff returns the wait before the retry following the given attempt
+// (starting at 1), before jitter is applied.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	var backoff time.Duration
+	switch p.Strategy {
+	case RetryBackoffStrategyExponential:
+		backoff = p.InitialBackoff
+		for i := 1; i < attempt; i++ {
+			backoff *= 2
+			if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+				break
+			}
+		}
+	default:
+		// Fibonacci backoff - 0.5, 1, 1.5, 2.5, 4, 6.5, 10.5, ...
+		backoff = p.InitialBackoff
+		nextBackoff := p.InitialBackoff
+		for i := 1; i < attempt; i++ {
+			lastBackoff := backoff
+			backoff = backoff + nextBackoff
+			nextBackoff = lastBackoff
+			if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+				break
+			}
+		}
+	}
+
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// waitDuration returns how long to wait after the given attempt, honouring
+// the Retry-After header of 429 and 503 responses over the computed backoff.
+func (p *RetryPolicy) waitDuration(attempt int, resp *http.Response) time.Duration {
+	if retryAfter, ok := retryAfterDuration(resp); ok {
+		return retryAfter
+	}
+
+	backoff := p.backoff(attempt)
+	if p.Jitter && backoff > 0 {
+		backoff = time.Duration(rand.Int63n(int64(backoff)))
+	}
+	return backoff
+}
+
+// retryAfterDuration parses the Retry-After header of a 429 or 503 response,
+// given either in seconds or as an HTTP date.
+func retryAfterDuration(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
 
 // NewTransportWithDefaultRetries constructs a default retryTransport that will retry common temporary errors
 func NewTransportWithDefaultRetries(t http.RoundTripper) *retryTransport {
@@ -71,20 +192,34 @@ func (t *retryTransport) WithAddedPredicates(predicates ...RetryErrorPredicateFu
 	return &copyT
 }
 
+// Returns a shallow copy of the retry transport using the given retry
+// policy. A nil policy means DefaultRetryPolicy is used.
+func (t *retryTransport) WithPolicy(policy *RetryPolicy) *retryTransport {
+	copyT := *t
+	copyT.policy = policy
+	return &copyT
+}
+
 type retryTransport struct {
 	retryPredicates []RetryErrorPredicateFunc
 	internal        http.RoundTripper
+	policy          *RetryPolicy
 }
 
 // RoundTrip implements the RoundTripper interface method.
 // It retries the given HTTP request based on the retry predicates
 // registered under the retryTransport.
 func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, respErr error) {
-	// Set timeout to default value.
+	policy := t.policy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	// Set timeout to the policy value.
 	ctx := req.Context()
 	var ccancel context.CancelFunc
 	if _, ok := ctx.Deadline(); !ok {
-		ctx, ccancel = context.WithTimeout(ctx, defaultRetryTransportTimeoutSec*time.Second)
+		ctx, ccancel = context.WithTimeout(ctx, policy.Timeout)
 		defer func() {
 			if ctx.Err() == nil {
 				// Cleanup child context created for retry loop if ctx not done.
@@ -94,8 +229,6 @@ func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, resp
 	}
 
 	attempts := 0
-	backoff := time.Millisecond * 500
-	nextBackoff := time.Millisecond * 500
 
 	// VCR depends on the original request body being consumed, so
 	// consume here. Since this won't affect the request itself,
@@ -120,6 +253,7 @@ Retry:
 		}
 
 		log.Printf("[DEBUG] Retry Transport: request attempt %d", attempts)
+		newRequest = newRequest.WithContext(context.WithValue(newRequest.Context(), retryAttemptKey{}, attempts+1))
 		// Do the wrapped Roundtrip. This is one request in the retry loop.
 		resp, respErr = t.internal.RoundTrip(newRequest)
 		attempts++
@@ -133,7 +267,12 @@ Retry:
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, last request failed with non-retryable error: %s", retryErr.Err)
 			break Retry
 		}
+		if policy.MaxAttempts > 0 && attempts >= policy.MaxAttempts {
+			log.Printf("[DEBUG] Retry Transport: Stopping retries, reached maximum of %d attempts", policy.MaxAttempts)
+			break Retry
+		}
 
+		backoff := policy.waitDuration(attempts, resp)
 		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", backoff)
 		select {
 		case <-ctx.Done():
@@ -141,11 +280,6 @@ Retry:
 			break Retry
 		case <-time.After(backoff):
 			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", backoff)
-
-			// Fibonnaci backoff - 0.5, 1, 1.5, 2.5, 4, 6.5, 10.5, ...
-			lastBackoff := backoff
-			backoff = backoff + nextBackoff
-			nextBackoff = lastBackoff
 			continue
 		}
 	}
@@ -153,6 +287,20 @@ Retry:
 	return resp, respErr
 }
 
+// retryAttemptKey is the context key for the attempt number of a request
+// sent by the retry transport.
+type retryAttemptKey struct{}
+
+// retryAttemptFromContext returns the attempt number, starting at 1, of a
+// request sent by the retry transport. Requests sent outside of the retry
+// transport are always on their first attempt.
+func retryAttemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(retryAttemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
 // copyHttpRequest provides an copy of the given HTTP request for one RoundTrip.
 // If the request has a non-empty body (io.ReadCloser), the body is deep copied
 // so it can be consumed.
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -217,6 +218,153 @@ func testRetryTransportHandler_returnAfter(t *testing.T, interval time.Duration,
 	})
 }
 
+// Check that retries stop once the maximum number of attempts is reached
+func TestRetryTransport_MaxAttempts(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(testRetryTransportCodeRetry)
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = &retryTransport{
+		internal:        http.DefaultTransport,
+		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+		policy: &RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			Strategy:       RetryBackoffStrategyExponential,
+			Timeout:        time.Second * 10,
+		},
+	}
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	cases := map[string]struct {
+		Policy   RetryPolicy
+		Expected []time.Duration
+	}{
+		"fibonacci": {
+			Policy: RetryPolicy{
+				InitialBackoff: time.Millisecond * 500,
+				Strategy:       RetryBackoffStrategyFibonacci,
+			},
+			Expected: []time.Duration{
+				time.Millisecond * 500,
+				time.Millisecond * 1000,
+				time.Millisecond * 1500,
+				time.Millisecond * 2500,
+				time.Millisecond * 4000,
+				time.Millisecond * 6500,
+			},
+		},
+		"exponential": {
+			Policy: RetryPolicy{
+				InitialBackoff: time.Second,
+				Strategy:       RetryBackoffStrategyExponential,
+			},
+			Expected: []time.Duration{
+				time.Second,
+				time.Second * 2,
+				time.Second * 4,
+				time.Second * 8,
+			},
+		},
+		"exponential with max": {
+			Policy: RetryPolicy{
+				InitialBackoff: time.Second,
+				MaxBackoff:     time.Second * 5,
+				Strategy:       RetryBackoffStrategyExponential,
+			},
+			Expected: []time.Duration{
+				time.Second,
+				time.Second * 2,
+				time.Second * 4,
+				time.Second * 5,
+				time.Second * 5,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		for i, expected := range tc.Expected {
+			if actual := tc.Policy.backoff(i + 1); actual != expected {
+				t.Errorf("bad: %s, attempt %d: expected %s, got %s", tn, i+1, expected, actual)
+			}
+		}
+	}
+}
+
+func TestRetryPolicy_jitter(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: time.Second,
+		Strategy:       RetryBackoffStrategyExponential,
+		Jitter:         true,
+	}
+
+	for i := 1; i < 5; i++ {
+		max := policy.backoff(i)
+		if actual := policy.waitDuration(i, nil); actual < 0 || actual >= max {
+			t.Errorf("attempt %d: expected wait in [0, %s), got %s", i, max, actual)
+		}
+	}
+}
+
+func TestRetryPolicy_retryAfter(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	cases := map[string]struct {
+		Code       int
+		RetryAfter string
+		Expected   time.Duration
+	}{
+		"429 with seconds": {
+			Code:       http.StatusTooManyRequests,
+			RetryAfter: "7",
+			Expected:   time.Second * 7,
+		},
+		"503 with seconds": {
+			Code:       http.StatusServiceUnavailable,
+			RetryAfter: "2",
+			Expected:   time.Second * 2,
+		},
+		"500 ignores header": {
+			Code:       http.StatusInternalServerError,
+			RetryAfter: "7",
+			Expected:   policy.backoff(1),
+		},
+		"429 without header": {
+			Code:     http.StatusTooManyRequests,
+			Expected: policy.backoff(1),
+		},
+		"429 with invalid header": {
+			Code:       http.StatusTooManyRequests,
+			RetryAfter: "soon",
+			Expected:   policy.backoff(1),
+		},
+	}
+
+	for tn, tc := range cases {
+		resp := &http.Response{
+			StatusCode: tc.Code,
+			Header:     http.Header{},
+		}
+		if tc.RetryAfter != "" {
+			resp.Header.Set("Retry-After", tc.RetryAfter)
+		}
+		if actual := policy.waitDuration(1, resp); actual != tc.Expected {
+			t.Errorf("bad: %s, expected %s, got %s", tn, tc.Expected, actual)
+		}
+	}
+}
+
 // Utils for checking
 func testRetryTransport_checkSuccess(t *testing.T, resp *http.Response, respErr error) {
 	if respErr != nil {
@@ -41,7 +41,7 @@ func runAdminV2OperationWaitTimeWithResponse(config *Config, op *run.GoogleLongr
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("cloud_run")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -55,5 +55,5 @@ func runAdminV2OperationWaitTime(config *Config, op *run.GoogleLongrunningOperat
 	if err != nil {
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("cloud_run"))
 }
@@ -0,0 +1,408 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Kinds of breaking schema changes reported by CompareSchemaSnapshots.
+const (
+	SchemaChangeResourceRemoved     = "resource_removed"
+	SchemaChangeDataSourceRemoved   = "data_source_removed"
+	SchemaChangeFieldRemoved        = "field_removed"
+	SchemaChangeRequiredFieldAdded  = "required_field_added"
+	SchemaChangeOptionalToRequired  = "optional_to_required"
+	SchemaChangeForceNewAdded       = "force_new_added"
+	SchemaChangeTypeChanged         = "type_changed"
+	SchemaChangeDefaultChanged      = "default_changed"
+	SchemaChangeValidationTightened = "validation_tightened"
+)
+
+// ProviderSchemaSnapshot records the schemas of the provider's resources and
+// data sources, from both the SDK and the plugin framework providers, in a
+// form that can be saved as JSON and compared with the snapshot of another
+// provider version.
+type ProviderSchemaSnapshot struct {
+	Resources   map[string]map[string]*SchemaFieldSnapshot `json:"resources"`
+	DataSources map[string]map[string]*SchemaFieldSnapshot `json:"data_sources"`
+}
+
+// SchemaFieldSnapshot records the properties of a field that matter to the
+// compatibility of configurations and state.
+type SchemaFieldSnapshot struct {
+	// Type is the name of an SDK schema.ValueType, such as "TypeString".
+	// Framework object types are recorded as "TypeObject".
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+	Computed bool   `json:"computed,omitempty"`
+	ForceNew bool   `json:"force_new,omitempty"`
+	// Default is the JSON encoding of the default value, if any.
+	Default  string `json:"default,omitempty"`
+	MaxItems int    `json:"max_items,omitempty"`
+	MinItems int    `json:"min_items,omitempty"`
+	// Validation names the validation functions of SDK fields, and describes
+	// the validators of framework fields.
+	Validation    []string `json:"validation,omitempty"`
+	ConflictsWith []string `json:"conflicts_with,omitempty"`
+	ExactlyOneOf  []string `json:"exactly_one_of,omitempty"`
+	AtLeastOneOf  []string `json:"at_least_one_of,omitempty"`
+	RequiredWith  []string `json:"required_with,omitempty"`
+	// Elem is the element of collections of primitives.
+	Elem *SchemaFieldSnapshot `json:"elem,omitempty"`
+	// Fields are the fields of nested blocks and objects.
+	Fields map[string]*SchemaFieldSnapshot `json:"fields,omitempty"`
+}
+
+// SchemaChange is a change between two schema snapshots that can break
+// existing configurations or state.
+type SchemaChange struct {
+	Kind string `json:"kind"`
+	// Type is "resource" or "data_source".
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// Field is the dot-separated path of the field, empty for changes to a
+	// whole resource or data source.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (c SchemaChange) String() string {
+	if c.Field == "" {
+		return fmt.Sprintf("%s %s: %s", c.Type, c.Name, c.Message)
+	}
+	return fmt.Sprintf("%s %s: %s: %s", c.Type, c.Name, c.Field, c.Message)
+}
+
+// SnapshotProviderSchema returns the snapshot of the schemas of this provider
+// version: the resources of ResourceMap, and the data sources of both the SDK
+// provider and the framework provider.
+func SnapshotProviderSchema(ctx context.Context) (*ProviderSchemaSnapshot, error) {
+	resources, err := ResourceMapWithErrors()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &ProviderSchemaSnapshot{
+		Resources:   map[string]map[string]*SchemaFieldSnapshot{},
+		DataSources: map[string]map[string]*SchemaFieldSnapshot{},
+	}
+	for name, r := range resources {
+		snapshot.Resources[name] = snapshotSDKSchema(r.Schema)
+	}
+	for name, r := range Provider().DataSourcesMap {
+		snapshot.DataSources[name] = snapshotSDKSchema(r.Schema)
+	}
+
+	p := New("snapshot")
+	var metadata provider.MetadataResponse
+	p.Metadata(ctx, provider.MetadataRequest{}, &metadata)
+	for _, f := range p.DataSources(ctx) {
+		ds := f()
+		var md datasource.MetadataResponse
+		ds.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: metadata.TypeName}, &md)
+		var resp datasource.SchemaResponse
+		ds.Schema(ctx, datasource.SchemaRequest{}, &resp)
+		if resp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("error reading the schema of %s: %v", md.TypeName, resp.Diagnostics)
+		}
+		snapshot.DataSources[md.TypeName] = snapshotFrameworkObject(ctx, resp.Schema.Attributes, resp.Schema.Blocks)
+	}
+	return snapshot, nil
+}
+
+func snapshotSDKSchema(m map[string]*schema.Schema) map[string]*SchemaFieldSnapshot {
+	fields := make(map[string]*SchemaFieldSnapshot, len(m))
+	for k, s := range m {
+		fields[k] = snapshotSDKField(s)
+	}
+	return fields
+}
+
+func snapshotSDKField(s *schema.Schema) *SchemaFieldSnapshot {
+	f := &SchemaFieldSnapshot{
+		Type:          s.Type.String(),
+		Required:      s.Required,
+		Optional:      s.Optional,
+		Computed:      s.Computed,
+		ForceNew:      s.ForceNew,
+		MaxItems:      s.MaxItems,
+		MinItems:      s.MinItems,
+		ConflictsWith: s.ConflictsWith,
+		ExactlyOneOf:  s.ExactlyOneOf,
+		AtLeastOneOf:  s.AtLeastOneOf,
+		RequiredWith:  s.RequiredWith,
+	}
+	if s.Default != nil {
+		b, _ := json.Marshal(s.Default)
+		f.Default = string(b)
+	}
+	if s.ValidateFunc != nil {
+		f.Validation = append(f.Validation, schemaFuncName(s.ValidateFunc))
+	}
+	if s.ValidateDiagFunc != nil {
+		f.Validation = append(f.Validation, schemaFuncName(s.ValidateDiagFunc))
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		f.Fields = snapshotSDKSchema(elem.Schema)
+	case *schema.Schema:
+		f.Elem = snapshotSDKField(elem)
+	}
+	return f
+}
+
+// schemaFuncName returns the qualified name of a function, such as
+// "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation.StringInSlice.func1".
+func schemaFuncName(f interface{}) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return ""
+	}
+	return fn.Name()
+}
+
+func snapshotFrameworkObject(ctx context.Context, attributes map[string]fwschema.Attribute, blocks map[string]fwschema.Block) map[string]*SchemaFieldSnapshot {
+	fields := make(map[string]*SchemaFieldSnapshot, len(attributes)+len(blocks))
+	for k, a := range attributes {
+		f := snapshotFrameworkType(a.GetType())
+		f.Required = a.IsRequired()
+		f.Optional = a.IsOptional()
+		f.Computed = a.IsComputed()
+		f.Validation = frameworkValidators(ctx, a)
+		switch a := a.(type) {
+		case fwschema.ListNestedAttribute:
+			f.Fields = snapshotFrameworkObject(ctx, a.NestedObject.Attributes, nil)
+		case fwschema.SetNestedAttribute:
+			f.Fields = snapshotFrameworkObject(ctx, a.NestedObject.Attributes, nil)
+		case fwschema.MapNestedAttribute:
+			f.Fields = snapshotFrameworkObject(ctx, a.NestedObject.Attributes, nil)
+		case fwschema.SingleNestedAttribute:
+			f.Fields = snapshotFrameworkObject(ctx, a.Attributes, nil)
+		}
+		fields[k] = f
+	}
+	for k, b := range blocks {
+		f := &SchemaFieldSnapshot{Validation: frameworkValidators(ctx, b)}
+		switch b := b.(type) {
+		case fwschema.ListNestedBlock:
+			f.Type = schema.TypeList.String()
+			f.Fields = snapshotFrameworkObject(ctx, b.NestedObject.Attributes, b.NestedObject.Blocks)
+		case fwschema.SetNestedBlock:
+			f.Type = schema.TypeSet.String()
+			f.Fields = snapshotFrameworkObject(ctx, b.NestedObject.Attributes, b.NestedObject.Blocks)
+		case fwschema.SingleNestedBlock:
+			f.Type = "TypeObject"
+			f.Fields = snapshotFrameworkObject(ctx, b.Attributes, b.Blocks)
+		default:
+			f.Type = fmt.Sprintf("%T", b)
+		}
+		fields[k] = f
+	}
+	return fields
+}
+
+func snapshotFrameworkType(t attr.Type) *SchemaFieldSnapshot {
+	switch t := t.(type) {
+	case basetypes.StringType:
+		return &SchemaFieldSnapshot{Type: schema.TypeString.String()}
+	case basetypes.BoolType:
+		return &SchemaFieldSnapshot{Type: schema.TypeBool.String()}
+	case basetypes.Int64Type:
+		return &SchemaFieldSnapshot{Type: schema.TypeInt.String()}
+	case basetypes.Float64Type, basetypes.NumberType:
+		return &SchemaFieldSnapshot{Type: schema.TypeFloat.String()}
+	case basetypes.ListType:
+		return &SchemaFieldSnapshot{Type: schema.TypeList.String(), Elem: snapshotFrameworkElem(t.ElemType)}
+	case basetypes.SetType:
+		return &SchemaFieldSnapshot{Type: schema.TypeSet.String(), Elem: snapshotFrameworkElem(t.ElemType)}
+	case basetypes.MapType:
+		return &SchemaFieldSnapshot{Type: schema.TypeMap.String(), Elem: snapshotFrameworkElem(t.ElemType)}
+	case basetypes.ObjectType:
+		f := &SchemaFieldSnapshot{Type: "TypeObject", Fields: map[string]*SchemaFieldSnapshot{}}
+		for k, at := range t.AttrTypes {
+			f.Fields[k] = snapshotFrameworkType(at)
+		}
+		return f
+	default:
+		return &SchemaFieldSnapshot{Type: t.String()}
+	}
+}
+
+// snapshotFrameworkElem returns the element of a collection, unless it is an
+// object whose fields were recorded from the nested attribute.
+func snapshotFrameworkElem(t attr.Type) *SchemaFieldSnapshot {
+	if _, ok := t.(basetypes.ObjectType); ok {
+		return nil
+	}
+	return snapshotFrameworkType(t)
+}
+
+// frameworkValidators describes the validators of a framework attribute or
+// block, which all hold them in a Validators field.
+func frameworkValidators(ctx context.Context, v interface{}) []string {
+	field := reflect.ValueOf(v).FieldByName("Validators")
+	if !field.IsValid() {
+		return nil
+	}
+	var descriptions []string
+	for i := 0; i < field.Len(); i++ {
+		if d, ok := field.Index(i).Interface().(interface{ Description(context.Context) string }); ok {
+			descriptions = append(descriptions, d.Description(ctx))
+		}
+	}
+	return descriptions
+}
+
+// CompareSchemaSnapshots returns the changes from old to new that can break
+// configurations or state written for old, sorted by resource and field.
+// Additions of optional fields and of resources are compatible and aren't
+// reported.
+func CompareSchemaSnapshots(old, new *ProviderSchemaSnapshot) []SchemaChange {
+	var changes []SchemaChange
+	changes = append(changes, compareSchemaMaps("resource", SchemaChangeResourceRemoved, old.Resources, new.Resources)...)
+	changes = append(changes, compareSchemaMaps("data_source", SchemaChangeDataSourceRemoved, old.DataSources, new.DataSources)...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Type != changes[j].Type {
+			return changes[i].Type < changes[j].Type
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func compareSchemaMaps(typ, removedKind string, old, new map[string]map[string]*SchemaFieldSnapshot) []SchemaChange {
+	var changes []SchemaChange
+	for name, oldFields := range old {
+		newFields, ok := new[name]
+		if !ok {
+			changes = append(changes, SchemaChange{Kind: removedKind, Type: typ, Name: name, Message: "removed"})
+			continue
+		}
+		for _, c := range compareSchemaFields(oldFields, newFields, nil) {
+			c.Type = typ
+			c.Name = name
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+func compareSchemaFields(old, new map[string]*SchemaFieldSnapshot, path []string) []SchemaChange {
+	var changes []SchemaChange
+	for k, o := range old {
+		fieldPath := append(append([]string{}, path...), k)
+		n, ok := new[k]
+		if !ok {
+			changes = append(changes, schemaFieldChange(SchemaChangeFieldRemoved, fieldPath, "removed"))
+			continue
+		}
+		changes = append(changes, compareSchemaField(o, n, fieldPath)...)
+	}
+	for k, n := range new {
+		if _, ok := old[k]; !ok && n.Required {
+			changes = append(changes, schemaFieldChange(SchemaChangeRequiredFieldAdded, append(append([]string{}, path...), k), "added as a required field"))
+		}
+	}
+	return changes
+}
+
+func compareSchemaField(old, new *SchemaFieldSnapshot, path []string) []SchemaChange {
+	if old.Type != new.Type {
+		// Types are different, other changes won't make sense
+		return []SchemaChange{schemaFieldChange(SchemaChangeTypeChanged, path, fmt.Sprintf("type changed from %s to %s", old.Type, new.Type))}
+	}
+
+	var changes []SchemaChange
+	if !old.Required && new.Required {
+		changes = append(changes, schemaFieldChange(SchemaChangeOptionalToRequired, path, "changed from optional to required"))
+	}
+	if !old.ForceNew && new.ForceNew {
+		changes = append(changes, schemaFieldChange(SchemaChangeForceNewAdded, path, "changes now force replacement"))
+	}
+	if old.Default != new.Default {
+		changes = append(changes, schemaFieldChange(SchemaChangeDefaultChanged, path, fmt.Sprintf("default changed from %s to %s", schemaDefaultString(old.Default), schemaDefaultString(new.Default))))
+	}
+	for _, msg := range tightenedValidation(old, new) {
+		changes = append(changes, schemaFieldChange(SchemaChangeValidationTightened, path, msg))
+	}
+
+	switch {
+	case old.Elem != nil && new.Elem != nil:
+		changes = append(changes, compareSchemaField(old.Elem, new.Elem, append(append([]string{}, path...), "elem"))...)
+	case old.Elem != nil || new.Elem != nil:
+		changes = append(changes, schemaFieldChange(SchemaChangeTypeChanged, path, "element type changed"))
+	}
+	changes = append(changes, compareSchemaFields(old.Fields, new.Fields, path)...)
+	return changes
+}
+
+// anonymousSchemaFuncRegexp matches the names of the functions declared
+// inline in resource and data source constructors, such as
+// "github.com/hashicorp/terraform-provider-google-beta/google-beta.ResourceComputeInstance.func3".
+// Their numbers change whenever a function is added to the constructor.
+var anonymousSchemaFuncRegexp = regexp.MustCompile(`\.(Resource|DataSource)\w*\.func\d+(\.\d+)*$`)
+
+// tightenedValidation describes the ways new accepts fewer values than old.
+// Changes within validation functions, such as new values in the list passed
+// to validation.StringInSlice, can't be detected. Functions declared inline in
+// constructors are ignored, as their names aren't stable.
+func tightenedValidation(old, new *SchemaFieldSnapshot) []string {
+	var msgs []string
+	for _, v := range new.Validation {
+		if anonymousSchemaFuncRegexp.MatchString(v) {
+			continue
+		}
+		if !stringInSlice(old.Validation, v) {
+			msgs = append(msgs, fmt.Sprintf("validation added: %s", v))
+		}
+	}
+	if new.MaxItems > 0 && (old.MaxItems == 0 || new.MaxItems < old.MaxItems) {
+		msgs = append(msgs, fmt.Sprintf("max_items lowered from %d to %d", old.MaxItems, new.MaxItems))
+	}
+	if new.MinItems > old.MinItems {
+		msgs = append(msgs, fmt.Sprintf("min_items raised from %d to %d", old.MinItems, new.MinItems))
+	}
+	for _, c := range []struct {
+		name     string
+		old, new []string
+	}{
+		{"conflicts_with", old.ConflictsWith, new.ConflictsWith},
+		{"exactly_one_of", old.ExactlyOneOf, new.ExactlyOneOf},
+		{"at_least_one_of", old.AtLeastOneOf, new.AtLeastOneOf},
+		{"required_with", old.RequiredWith, new.RequiredWith},
+	} {
+		for _, v := range c.new {
+			if !stringInSlice(c.old, v) {
+				msgs = append(msgs, fmt.Sprintf("%s added: %s", c.name, v))
+			}
+		}
+	}
+	return msgs
+}
+
+func schemaFieldChange(kind string, path []string, msg string) SchemaChange {
+	return SchemaChange{Kind: kind, Field: strings.Join(path, "."), Message: msg}
+}
+
+func schemaDefaultString(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
@@ -0,0 +1,226 @@
+package google
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func TestCompareSchemaSnapshots(t *testing.T) {
+	oldSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"size": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  10,
+		},
+		"mode": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"labels": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"config": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"tier": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+	newSchema := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"size": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"A", "B"}, false),
+		},
+		"labels": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeInt},
+		},
+		"config": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+					"level": {
+						Type:     schema.TypeInt,
+						Required: true,
+					},
+					"comment": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"tier": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+
+	old := &ProviderSchemaSnapshot{
+		Resources: map[string]map[string]*SchemaFieldSnapshot{
+			"google_thing":         snapshotSDKSchema(oldSchema),
+			"google_removed_thing": snapshotSDKSchema(oldSchema),
+		},
+		DataSources: map[string]map[string]*SchemaFieldSnapshot{
+			"google_thing": snapshotSDKSchema(oldSchema),
+		},
+	}
+	new := &ProviderSchemaSnapshot{
+		Resources: map[string]map[string]*SchemaFieldSnapshot{
+			"google_thing":     snapshotSDKSchema(newSchema),
+			"google_new_thing": snapshotSDKSchema(newSchema),
+		},
+		DataSources: map[string]map[string]*SchemaFieldSnapshot{},
+	}
+
+	var got []string
+	for _, c := range CompareSchemaSnapshots(old, new) {
+		got = append(got, c.Kind+" "+c.Name+" "+c.Field)
+	}
+	expected := []string{
+		"data_source_removed google_thing ",
+		"resource_removed google_removed_thing ",
+		"validation_tightened google_thing config",
+		"default_changed google_thing config.enabled",
+		"required_field_added google_thing config.level",
+		"field_removed google_thing description",
+		"type_changed google_thing labels.elem",
+		"force_new_added google_thing mode",
+		"validation_tightened google_thing mode",
+		"type_changed google_thing size",
+		"optional_to_required google_thing tier",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected changes:\n%q\ngot:\n%q", expected, got)
+	}
+
+	if changes := CompareSchemaSnapshots(old, old); len(changes) != 0 {
+		t.Errorf("expected no changes between identical snapshots, got %v", changes)
+	}
+}
+
+func TestTightenedValidation_anonymousFuncs(t *testing.T) {
+	const pkg = "github.com/hashicorp/terraform-provider-google-beta/google-beta."
+	old := &SchemaFieldSnapshot{Validation: []string{pkg + "ResourceComputeInstance.func3"}}
+	new := &SchemaFieldSnapshot{Validation: []string{
+		pkg + "ResourceComputeInstance.func4",
+		pkg + "DataSourceGoogleComputeInstance.func1.2",
+		"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation.StringInSlice.func1",
+	}}
+	expected := []string{"validation added: github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation.StringInSlice.func1"}
+	if got := tightenedValidation(old, new); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestSnapshotFrameworkObject(t *testing.T) {
+	ctx := context.Background()
+	s := fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{
+			"name": fwschema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{stringvalidator.LengthAtMost(63)},
+			},
+			"tags": fwschema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]fwschema.Block{
+			"keys": fwschema.ListNestedBlock{
+				NestedObject: fwschema.NestedBlockObject{
+					Attributes: map[string]fwschema.Attribute{
+						"id": fwschema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := snapshotFrameworkObject(ctx, s.Attributes, s.Blocks)
+	expected := map[string]*SchemaFieldSnapshot{
+		"name": {
+			Type:       "TypeString",
+			Required:   true,
+			Validation: []string{stringvalidator.LengthAtMost(63).Description(ctx)},
+		},
+		"tags": {
+			Type:     "TypeList",
+			Computed: true,
+			Elem:     &SchemaFieldSnapshot{Type: "TypeString"},
+		},
+		"keys": {
+			Type: "TypeList",
+			Fields: map[string]*SchemaFieldSnapshot{
+				"id": {Type: "TypeString", Computed: true},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected snapshot: %#v", got)
+	}
+}
+
+func TestSnapshotProviderSchema(t *testing.T) {
+	snapshot, err := SnapshotProviderSchema(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snapshot.Resources["google_compute_network"]; !ok {
+		t.Errorf("expected the snapshot to include google_compute_network")
+	}
+	// google_dns_keys is implemented with the plugin framework.
+	if f := snapshot.DataSources["google_dns_keys"]["managed_zone"]; f == nil || !f.Required {
+		t.Errorf("expected the snapshot to include the required google_dns_keys.managed_zone, got %#v", f)
+	}
+}
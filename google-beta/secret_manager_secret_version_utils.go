@@ -0,0 +1,114 @@
+package google
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// This file contains the hand-written parts of the generated SecretVersion
+// resource supporting secret_data_wo, the write-only variant of secret_data.
+//
+// The secret data is only kept in state, and read back, when it's configured
+// in secret_data. Versions using secret_data_wo, and imported versions, have
+// no secret data in state. Setting the secret data of an imported version
+// doesn't replace it: the configured data is compared with the version's when
+// applying, see verifySecretManagerSecretVersionImportedData.
+
+var secretManagerSecretVersionDataFields = []string{"secret_data", "secret_data_wo", "secret_data_wo_version"}
+
+// secretManagerSecretVersionReadsSecretData returns whether reading the
+// version must read its secret data back into secret_data.
+func secretManagerSecretVersionReadsSecretData(d TerraformResourceData) bool {
+	return d.Get("secret_data").(string) != ""
+}
+
+// secretManagerSecretVersionSecretData returns the configured secret data,
+// from secret_data_wo or secret_data.
+func secretManagerSecretVersionSecretData(d TerraformResourceData) string {
+	if v := getWriteOnlyString(d, "secret_data_wo"); v != "" {
+		return v
+	}
+	return d.Get("secret_data").(string)
+}
+
+// isImportedSecretManagerSecretVersion returns whether the version in state
+// has no secret data because it was imported. Disabled versions are excluded
+// as their secret data can't be read.
+func isImportedSecretManagerSecretVersion(d *schema.ResourceDiff) bool {
+	oldData, _ := d.GetChange("secret_data")
+	oldWriteOnly, _ := d.GetChange("secret_data_wo")
+	oldEnabled, _ := d.GetChange("enabled")
+	return oldData.(string) == "" && oldWriteOnly.(string) == "" && oldEnabled.(bool)
+}
+
+// resourceSecretManagerSecretVersionPayloadDiff replaces the version when its
+// secret data changes, as secret versions are immutable. The secret data of
+// an imported version isn't in state, so setting it is planned as an update
+// instead, which checks that it matches the version's. No API call is made
+// while planning, as the SDK doesn't pass provider_meta to CustomizeDiff.
+func resourceSecretManagerSecretVersionPayloadDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || isImportedSecretManagerSecretVersion(d) {
+		return nil
+	}
+
+	for _, k := range secretManagerSecretVersionDataFields {
+		if !d.HasChange(k) {
+			continue
+		}
+		if err := d.ForceNew(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifySecretManagerSecretVersionImportedData checks, when the secret data
+// of an imported version is set, that it matches the version's, so that it
+// can be recorded in state without replacing the version.
+func verifySecretManagerSecretVersionImportedData(d *schema.ResourceData, config *Config) error {
+	if !d.HasChanges(secretManagerSecretVersionDataFields...) {
+		return nil
+	}
+	configured := secretManagerSecretVersionSecretData(d)
+	if configured == "" {
+		return nil
+	}
+
+	userAgent, err := generateUserAgentString(d, config.UserAgent)
+	if err != nil {
+		return err
+	}
+
+	data, err := accessSecretManagerSecretVersion(config, userAgent, d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading the secret data of SecretVersion %q: %s", d.Id(), err)
+	}
+	if data != configured {
+		return fmt.Errorf("The secret data of SecretVersion %q doesn't match the configuration. Secret versions are immutable: replace the version, for instance with `terraform apply -replace`, to change its secret data.", d.Id())
+	}
+	return nil
+}
+
+// accessSecretManagerSecretVersion returns the secret data of the version
+// with the given name.
+func accessSecretManagerSecretVersion(config *Config, userAgent, name string) (string, error) {
+	url := config.SecretManagerBasePath + name + ":access"
+
+	parts := strings.Split(name, "/")
+	project := parts[1]
+
+	accessRes, err := SendRequest(config, "GET", project, url, userAgent, nil)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(accessRes["payload"].(map[string]interface{})["data"].(string))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
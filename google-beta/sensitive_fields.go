@@ -0,0 +1,31 @@
+package google
+
+// sensitiveFieldNames are the names of the request and response fields that
+// hold secrets, as sent by the APIs and by OAuth token exchanges. They're
+// redacted from audit log records and from recorded VCR cassettes.
+var sensitiveFieldNames = []string{
+	// OAuth token exchanges
+	"access_token",
+	"client_secret",
+	"id_token",
+	"refresh_token",
+	"subject_token",
+	// google_service_account_key
+	"privateKeyData",
+	"private_key",
+	// google_storage_hmac_key
+	"secret",
+	// google_sql_user, google_sql_database_instance
+	"password",
+	"rootPassword",
+	// google_compute_vpn_tunnel
+	"sharedSecret",
+	// google_compute_backend_service, google_compute_region_backend_service
+	"oauth2ClientSecret",
+	// google_storage_transfer_job
+	"secretAccessKey",
+	// google_active_directory_domain_trust
+	"trustHandshakeSecret",
+	// google_certificate_manager_certificate
+	"pemPrivateKey",
+}
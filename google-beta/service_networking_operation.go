@@ -31,5 +31,5 @@ func ServiceNetworkingOperationWaitTime(config *Config, op *servicenetworking.Op
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("service_networking"))
 }
@@ -74,7 +74,7 @@ func ServiceUsageOperationWaitTimeWithResponse(config *Config, op map[string]int
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("service_usage")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -90,5 +90,5 @@ func ServiceUsageOperationWaitTime(config *Config, op map[string]interface{}, pr
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("service_usage"))
 }
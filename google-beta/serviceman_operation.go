@@ -29,7 +29,7 @@ func ServiceManagementOperationWaitTime(config *Config, op *servicemanagement.Op
 		return nil, err
 	}
 
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("service_management")); err != nil {
 		return nil, err
 	}
 	return w.Op.Response, nil
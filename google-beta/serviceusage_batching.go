@@ -33,7 +33,7 @@ func BatchRequestEnableService(service string, project string, d *schema.Resourc
 		billingProject = bp
 	}
 
-	req := &BatchRequest{
+	req := &BatchRequest[[]string, struct{}]{
 		ResourceName: project,
 		Body:         []string{service},
 		CombineF:     combineServiceUsageServicesBatches,
@@ -41,7 +41,8 @@ func BatchRequestEnableService(service string, project string, d *schema.Resourc
 		DebugId:      fmt.Sprintf("Enable Project Service %q for project %q", service, project),
 	}
 
-	_, err = config.RequestBatcherServiceUsage.SendRequestWithTimeout(
+	_, err = SendBatchRequestWithTimeout(
+		config.RequestBatcherServiceUsage,
 		fmt.Sprintf(batchKeyTmplServiceUsageEnableServices, project),
 		req,
 		d.Timeout(schema.TimeoutCreate))
@@ -75,7 +76,7 @@ func tryEnableRenamedService(service, altName string, project string, d *schema.
 	return nil
 }
 
-func BatchRequestReadServices(project string, d *schema.ResourceData, config *Config) (interface{}, error) {
+func BatchRequestReadServices(project string, d *schema.ResourceData, config *Config) (map[string]struct{}, error) {
 	userAgent, err := generateUserAgentString(d, config.UserAgent)
 	if err != nil {
 		return nil, err
@@ -87,46 +88,34 @@ func BatchRequestReadServices(project string, d *schema.ResourceData, config *Co
 		billingProject = bp
 	}
 
-	req := &BatchRequest{
+	req := &BatchRequest[struct{}, map[string]struct{}]{
 		ResourceName: project,
-		Body:         nil,
+		Body:         struct{}{},
 		// Use empty CombineF since the request is exactly the same no matter how many services we read.
-		CombineF: func(body interface{}, toAdd interface{}) (interface{}, error) { return nil, nil },
+		CombineF: func(body struct{}, toAdd struct{}) (struct{}, error) { return struct{}{}, nil },
 		SendF:    sendListServices(config, billingProject, userAgent, d.Timeout(schema.TimeoutRead)),
 		DebugId:  fmt.Sprintf("List Project Services %s", project),
 	}
 
-	return config.RequestBatcherServiceUsage.SendRequestWithTimeout(
+	return SendBatchRequestWithTimeout(
+		config.RequestBatcherServiceUsage,
 		fmt.Sprintf(batchKeyTmplServiceUsageListServices, project),
 		req,
 		d.Timeout(schema.TimeoutRead))
 }
 
-func combineServiceUsageServicesBatches(srvsRaw interface{}, toAddRaw interface{}) (interface{}, error) {
-	srvs, ok := srvsRaw.([]string)
-	if !ok {
-		return nil, fmt.Errorf("Expected batch body type to be []string, got %v. This is a provider error.", srvsRaw)
-	}
-	toAdd, ok := toAddRaw.([]string)
-	if !ok {
-		return nil, fmt.Errorf("Expected new request body type to be []string, got %v. This is a provider error.", toAdd)
-	}
-
+func combineServiceUsageServicesBatches(srvs []string, toAdd []string) ([]string, error) {
 	return append(srvs, toAdd...), nil
 }
 
-func sendBatchFuncEnableServices(config *Config, userAgent, billingProject string, timeout time.Duration) BatcherSendFunc {
-	return func(project string, toEnableRaw interface{}) (interface{}, error) {
-		toEnable, ok := toEnableRaw.([]string)
-		if !ok {
-			return nil, fmt.Errorf("Expected batch body type to be []string, got %v. This is a provider error.", toEnableRaw)
-		}
-		return nil, EnableServiceUsageProjectServices(toEnable, project, billingProject, userAgent, config, timeout)
+func sendBatchFuncEnableServices(config *Config, userAgent, billingProject string, timeout time.Duration) BatcherSendFunc[[]string, struct{}] {
+	return func(project string, toEnable []string) (struct{}, error) {
+		return struct{}{}, EnableServiceUsageProjectServices(toEnable, project, billingProject, userAgent, config, timeout)
 	}
 }
 
-func sendListServices(config *Config, billingProject, userAgent string, timeout time.Duration) BatcherSendFunc {
-	return func(project string, _ interface{}) (interface{}, error) {
+func sendListServices(config *Config, billingProject, userAgent string, timeout time.Duration) BatcherSendFunc[struct{}, map[string]struct{}] {
+	return func(project string, _ struct{}) (map[string]struct{}, error) {
 		return ListCurrentlyEnabledServices(project, billingProject, userAgent, config, timeout)
 	}
 }
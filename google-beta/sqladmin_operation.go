@@ -115,7 +115,7 @@ func SqlAdminOperationWaitTime(config *Config, res interface{}, project, activit
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("sql"))
 }
 
 // SqlAdminOperationError wraps sqladmin.OperationError and implements the
@@ -0,0 +1,233 @@
+package google
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/storage/v1"
+)
+
+// storageObjectDefaultParallelParts is the number of parts of a parallel
+// composite upload when parallel_composite_upload_parts isn't set.
+const storageObjectDefaultParallelParts = 8
+
+// storageObjectMaxComposeParts is the maximum number of objects composed by
+// a single request.
+const storageObjectMaxComposeParts = 32
+
+// detectCrc32cPrefix prefixes the CRC32C checksum stored in detect_md5hash for
+// composite objects, which have no MD5 hash.
+const detectCrc32cPrefix = "crc32c:"
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// storageObjectUploadOptions are the settings of google_storage_bucket_object
+// controlling how its data is uploaded.
+type storageObjectUploadOptions struct {
+	// ChunkSize is the size of the chunks of resumable uploads. Data larger
+	// than a chunk is uploaded with a resumable upload, one chunk at a time.
+	ChunkSize int
+	// ChunkRetryDeadline is how long the upload of a chunk is retried for.
+	ChunkRetryDeadline time.Duration
+	// ParallelThreshold is the size from which data is uploaded in
+	// ParallelParts parts uploaded concurrently, then composed into the object.
+	ParallelThreshold int64
+	ParallelParts     int
+}
+
+func expandStorageObjectUploadOptions(d *schema.ResourceData) (storageObjectUploadOptions, error) {
+	opts := storageObjectUploadOptions{
+		ChunkSize:         d.Get("upload_chunk_size").(int),
+		ParallelThreshold: int64(d.Get("parallel_composite_upload_threshold").(int)),
+		ParallelParts:     d.Get("parallel_composite_upload_parts").(int),
+	}
+	if v, ok := d.GetOk("upload_chunk_retry_deadline"); ok {
+		deadline, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return opts, fmt.Errorf("Error parsing upload_chunk_retry_deadline: %s", err)
+		}
+		opts.ChunkRetryDeadline = deadline
+	}
+	if opts.ParallelParts == 0 {
+		opts.ParallelParts = storageObjectDefaultParallelParts
+	}
+	return opts, nil
+}
+
+func validateStorageObjectUploadChunkSize(v interface{}, k string) (ws []string, errs []error) {
+	if size := v.(int); size < 0 || size%googleapi.MinUploadChunkSize != 0 {
+		errs = append(errs, fmt.Errorf("%q must be a multiple of %d bytes, got %d", k, googleapi.MinUploadChunkSize, size))
+	}
+	return
+}
+
+// uploadStorageObject uploads the size bytes of data as the object name, with
+// the metadata of object. The upload is checked against the CRC32C checksum
+// of data, and the uploaded object is deleted if they don't match.
+func uploadStorageObject(objectsService *storage.ObjectsService, object *storage.Object, name string, data io.ReaderAt, size int64, opts storageObjectUploadOptions, encryption map[string]string) (*storage.Object, error) {
+	crc, err := storageCrc32c(io.NewSectionReader(data, 0, size))
+	if err != nil {
+		return nil, err
+	}
+
+	var res *storage.Object
+	if opts.ParallelThreshold > 0 && size >= opts.ParallelThreshold && size >= int64(opts.ParallelParts) {
+		res, err = parallelCompositeUploadStorageObject(objectsService, object, name, data, size, opts, encryption)
+	} else {
+		res, err = insertStorageObject(objectsService, object, name, data, 0, size, crc, opts, encryption)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Crc32c != crc {
+		deleteCall := objectsService.Delete(object.Bucket, name)
+		if err := deleteCall.Do(); err != nil {
+			log.Printf("[WARN] Failed to delete object %s with a bad checksum: %s", name, err)
+		}
+		return nil, fmt.Errorf("the CRC32C checksum of object %s, %q, doesn't match the checksum of the data uploaded, %q", name, res.Crc32c, crc)
+	}
+	return res, nil
+}
+
+// insertStorageObject uploads size bytes of data from off as the object name.
+// Cloud Storage rejects the upload if the data received doesn't match crc.
+func insertStorageObject(objectsService *storage.ObjectsService, object *storage.Object, name string, data io.ReaderAt, off, size int64, crc string, opts storageObjectUploadOptions, encryption map[string]string) (*storage.Object, error) {
+	o := *object
+	o.Crc32c = crc
+
+	var mediaOptions []googleapi.MediaOption
+	if opts.ChunkSize > 0 {
+		mediaOptions = append(mediaOptions, googleapi.ChunkSize(opts.ChunkSize))
+	}
+	if opts.ChunkRetryDeadline > 0 {
+		mediaOptions = append(mediaOptions, googleapi.ChunkRetryDeadline(opts.ChunkRetryDeadline))
+	}
+
+	insertCall := objectsService.Insert(o.Bucket, &o)
+	insertCall.Name(name)
+	insertCall.Media(io.NewSectionReader(data, off, size), mediaOptions...)
+	// Progress is only reported by resumable uploads, after each chunk.
+	insertCall.ProgressUpdater(func(current, _ int64) {
+		log.Printf("[DEBUG] Uploaded %d of %d bytes of object %s", current, size, name)
+	})
+
+	// This is done late as we need to add headers to enable customer encryption
+	if len(encryption) > 0 {
+		setEncryptionHeaders(encryption, insertCall.Header())
+	}
+
+	res, err := insertCall.Do()
+	if err != nil {
+		return nil, fmt.Errorf("Error uploading object %s: %s", name, err)
+	}
+	return res, nil
+}
+
+// parallelCompositeUploadStorageObject uploads data in opts.ParallelParts
+// parts concurrently, as temporary objects that are then composed into the
+// object name and deleted.
+func parallelCompositeUploadStorageObject(objectsService *storage.ObjectsService, object *storage.Object, name string, data io.ReaderAt, size int64, opts storageObjectUploadOptions, encryption map[string]string) (*storage.Object, error) {
+	bucket := object.Bucket
+	partSize := (size + int64(opts.ParallelParts) - 1) / int64(opts.ParallelParts)
+	prefix := fmt.Sprintf("%s.tfparts-%s/", name, resource.UniqueId())
+
+	var sources []*storage.ComposeRequestSourceObjects
+	for off := int64(0); off < size; off += partSize {
+		sources = append(sources, &storage.ComposeRequestSourceObjects{Name: fmt.Sprintf("%s%d", prefix, len(sources))})
+	}
+
+	// The parts are deleted whether or not they were all uploaded and
+	// composed. Parts that weren't uploaded aren't found, which is fine.
+	defer func() {
+		for _, source := range sources {
+			if err := objectsService.Delete(bucket, source.Name).Do(); err != nil && !IsGoogleApiErrorWithCode(err, 404) {
+				log.Printf("[WARN] Failed to delete part %s of object %s: %s", source.Name, name, err)
+			}
+		}
+	}()
+
+	log.Printf("[DEBUG] Uploading object %s in %d parts of %d bytes", name, len(sources), partSize)
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		off := int64(i) * partSize
+		n := partSize
+		if off+n > size {
+			n = size - off
+		}
+
+		wg.Add(1)
+		go func(i int, partName string, off, n int64) {
+			defer wg.Done()
+			crc, err := storageCrc32c(io.NewSectionReader(data, off, n))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			part := &storage.Object{
+				Bucket:      bucket,
+				ContentType: "application/octet-stream",
+				KmsKeyName:  object.KmsKeyName,
+			}
+			_, errs[i] = insertStorageObject(objectsService, part, partName, data, off, n, crc, opts, encryption)
+		}(i, source.Name, off, n)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	destination := *object
+	destination.KmsKeyName = ""
+	if destination.ContentType == "" {
+		head := make([]byte, 512)
+		n, err := data.ReadAt(head, 0)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		destination.ContentType = http.DetectContentType(head[:n])
+	}
+
+	composeCall := objectsService.Compose(bucket, name, &storage.ComposeRequest{
+		Destination:   &destination,
+		SourceObjects: sources,
+	})
+	if object.KmsKeyName != "" {
+		composeCall.KmsKeyName(object.KmsKeyName)
+	}
+	if len(encryption) > 0 {
+		setEncryptionHeaders(encryption, composeCall.Header())
+	}
+
+	res, err := composeCall.Do()
+	if err != nil {
+		return nil, fmt.Errorf("Error composing object %s from its parts: %s", name, err)
+	}
+	return res, nil
+}
+
+// storageCrc32c returns the CRC32C checksum of r, base64 encoded as in the
+// Storage API.
+func storageCrc32c(r io.Reader) (string, error) {
+	h := crc32.New(crc32cTable)
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("Error computing CRC32C checksum: %s", err)
+	}
+	sum := make([]byte, 4)
+	binary.BigEndian.PutUint32(sum, h.Sum32())
+	return base64.StdEncoding.EncodeToString(sum), nil
+}
@@ -45,7 +45,7 @@ func TagsLocationOperationWaitTimeWithResponse(config *Config, op map[string]int
 	if err != nil {
 		return err
 	}
-	if err := OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := OperationWait(w, activity, timeout, config.Polling.For("tags_location")); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
@@ -61,7 +61,7 @@ func TagsLocationOperationWaitTime(config *Config, op map[string]interface{}, ac
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return OperationWait(w, activity, timeout, config.PollInterval)
+	return OperationWait(w, activity, timeout, config.Polling.For("tags_location"))
 }
 
 func GetLocationFromOpName(opName string) string {
@@ -173,7 +173,7 @@ func MuxedProviders(testName string) (func() tfprotov5.ProviderServer, error) {
 
 	providers := []func() tfprotov5.ProviderServer{
 		providerserver.NewProtocol5(NewFrameworkTestProvider(testName)), // framework provider
-		GetSDKProvider(testName).GRPCProvider,                           // sdk provider
+		WithPlanWarnings(GetSDKProvider(testName).GRPCProvider),         // sdk provider
 	}
 
 	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
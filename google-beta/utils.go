@@ -140,9 +140,10 @@ func isNotFoundGrpcError(err error) bool {
 	return false
 }
 
-// expandLabels pulls the value of "labels" out of a TerraformResourceData as a map[string]string.
+// expandLabels pulls the value of "effective_labels" out of a TerraformResourceData as a map[string]string.
+// "effective_labels" holds the resource labels merged with the provider default labels, see SetLabelsDiff.
 func expandLabels(d TerraformResourceData) map[string]string {
-	return expandStringMap(d, "labels")
+	return expandStringMap(d, "effective_labels")
 }
 
 // expandEnvironmentVariables pulls the value of "environment_variables" out of a schema.ResourceData as a map[string]string.
@@ -435,8 +436,8 @@ func generateUserAgentString(d TerraformResourceData, currentUserAgent string) (
 		return currentUserAgent, err
 	}
 
-	if m.ModuleName != "" {
-		return strings.Join([]string{currentUserAgent, m.ModuleName}, " "), nil
+	if m.ModuleName != nil && *m.ModuleName != "" {
+		return strings.Join([]string{currentUserAgent, *m.ModuleName}, " "), nil
 	}
 
 	return currentUserAgent, nil
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -78,6 +79,25 @@ func TestValidateRFC3339Time(t *testing.T) {
 	}
 }
 
+func TestValidateDurationAtLeast(t *testing.T) {
+	cases := []StringValidationTestCase{
+		// No errors
+		{TestName: "minimum", Value: "1s"},
+		{TestName: "above minimum", Value: "1m30s"},
+
+		// With errors
+		{TestName: "zero", Value: "0s", ExpectError: true},
+		{TestName: "below minimum", Value: "999ms", ExpectError: true},
+		{TestName: "negative", Value: "-5s", ExpectError: true},
+		{TestName: "not a duration", Value: "soon", ExpectError: true},
+	}
+
+	es := testStringValidationCases(cases, validateDurationAtLeast(time.Second))
+	if len(es) > 0 {
+		t.Errorf("Failed to validate durations: %v", es)
+	}
+}
+
 func TestValidateRFC1035Name(t *testing.T) {
 	cases := []struct {
 		TestName    string
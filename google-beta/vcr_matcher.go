@@ -0,0 +1,275 @@
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var vcrReplayMissesLock = sync.Mutex{}
+
+// vcrReplayMisses holds, per test, the requests that matched no recorded
+// interaction so far, with their diffs against the recorded candidates.
+var vcrReplayMisses = map[string]map[*http.Request]*vcrReplayMiss{}
+
+type vcrReplayMiss struct {
+	method string
+	url    string
+	// diffs has one entry per recorded interaction with the same method and
+	// URL as the request.
+	diffs [][]string
+}
+
+// vcrRequestMatcher returns the matcher replaying the cassette of testName.
+// Requests match a recorded interaction when their method and URL are the
+// same, and their bodies are the same once normalised: JSON key order and
+// fields set to their default value are ignored, and secrets are redacted as
+// they are when recording.
+func vcrRequestMatcher(ctx context.Context, testName string) cassette.Matcher {
+	return func(r *http.Request, i cassette.Request) bool {
+		if r.Method != i.Method {
+			return false
+		}
+		if r.URL.String() != i.URL {
+			recordVcrReplayMiss(testName, r, nil)
+			return false
+		}
+		if r.Body == nil {
+			clearVcrReplayMiss(testName, r)
+			return true
+		}
+		contentType := r.Header.Get("Content-Type")
+		// If body contains media, don't try to compare
+		if strings.Contains(contentType, "multipart/related") {
+			clearVcrReplayMiss(testName, r)
+			return true
+		}
+
+		var b bytes.Buffer
+		if _, err := b.ReadFrom(r.Body); err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Failed to read request body from cassette: %v", err))
+			return false
+		}
+		r.Body = ioutil.NopCloser(&b)
+
+		diff := vcrBodyDiff(contentType, i.Body, b.String())
+		if len(diff) > 0 {
+			tflog.Debug(ctx, fmt.Sprintf("Request body doesn't match the cassette: %s", strings.Join(diff, "; ")))
+			recordVcrReplayMiss(testName, r, diff)
+			return false
+		}
+		clearVcrReplayMiss(testName, r)
+		return true
+	}
+}
+
+// vcrBodyDiff compares a request body with a recorded one, returning the
+// differences found, or nil if they match.
+func vcrBodyDiff(contentType, recorded, actual string) []string {
+	if actual == recorded {
+		return nil
+	}
+	scrub := vcrScrubConfig()
+	if !strings.Contains(contentType, "application/json") {
+		if scrubbed, _ := scrub.scrubBody(actual); scrubbed == recorded {
+			return nil
+		}
+		return []string{fmt.Sprintf("body: recorded %q, got %q", recorded, actual)}
+	}
+
+	var recordedJson, actualJson interface{}
+	if err := json.Unmarshal([]byte(recorded), &recordedJson); err != nil {
+		return []string{fmt.Sprintf("body: recorded invalid JSON %q: %s", recorded, err)}
+	}
+	if err := json.Unmarshal([]byte(actual), &actualJson); err != nil {
+		return []string{fmt.Sprintf("body: got invalid JSON %q: %s", actual, err)}
+	}
+	recordedJson, _ = scrub.scrubJSON(recordedJson)
+	actualJson, _ = scrub.scrubJSON(actualJson)
+	return diffVcrJSON("body", normalizeVcrJSON(recordedJson), normalizeVcrJSON(actualJson))
+}
+
+// normalizeVcrJSON drops the object fields set to the default value of their
+// JSON type, as the APIs treat them the same as unset fields.
+func normalizeVcrJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, field := range v {
+			field = normalizeVcrJSON(field)
+			if !isEmptyVcrJSON(field) {
+				m[k] = field
+			}
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			l = append(l, normalizeVcrJSON(item))
+		}
+		return l
+	}
+	return v
+}
+
+func isEmptyVcrJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// diffVcrJSON lists the differences between two decoded JSON values, one per
+// line, each prefixed with the path of the value that differs.
+func diffVcrJSON(path string, recorded, actual interface{}) []string {
+	switch r := recorded.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := map[string]struct{}{}
+		for k := range r {
+			keys[k] = struct{}{}
+		}
+		for k := range a {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		var diffs []string
+		for _, k := range sorted {
+			rv, rok := r[k]
+			av, aok := a[k]
+			switch {
+			case !aok:
+				diffs = append(diffs, fmt.Sprintf("%s.%s: missing from request, recorded %s", path, k, vcrJSONString(rv)))
+			case !rok:
+				diffs = append(diffs, fmt.Sprintf("%s.%s: not recorded, got %s", path, k, vcrJSONString(av)))
+			default:
+				diffs = append(diffs, diffVcrJSON(path+"."+k, rv, av)...)
+			}
+		}
+		return diffs
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+		var diffs []string
+		for j := 0; j < len(r) || j < len(a); j++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, j)
+			switch {
+			case j >= len(a):
+				diffs = append(diffs, fmt.Sprintf("%s: missing from request, recorded %s", itemPath, vcrJSONString(r[j])))
+			case j >= len(r):
+				diffs = append(diffs, fmt.Sprintf("%s: not recorded, got %s", itemPath, vcrJSONString(a[j])))
+			default:
+				diffs = append(diffs, diffVcrJSON(itemPath, r[j], a[j])...)
+			}
+		}
+		return diffs
+	}
+
+	if !reflect.DeepEqual(recorded, actual) {
+		return []string{fmt.Sprintf("%s: recorded %s, got %s", path, vcrJSONString(recorded), vcrJSONString(actual))}
+	}
+	return nil
+}
+
+func vcrJSONString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// recordVcrReplayMiss records that r didn't match a recorded interaction. diff
+// is nil if the interaction has a different URL.
+func recordVcrReplayMiss(testName string, r *http.Request, diff []string) {
+	vcrReplayMissesLock.Lock()
+	defer vcrReplayMissesLock.Unlock()
+	misses, ok := vcrReplayMisses[testName]
+	if !ok {
+		misses = map[*http.Request]*vcrReplayMiss{}
+		vcrReplayMisses[testName] = misses
+	}
+	miss, ok := misses[r]
+	if !ok {
+		miss = &vcrReplayMiss{method: r.Method, url: r.URL.String()}
+		misses[r] = miss
+	}
+	if diff != nil {
+		miss.diffs = append(miss.diffs, diff)
+	}
+}
+
+func clearVcrReplayMiss(testName string, r *http.Request) {
+	vcrReplayMissesLock.Lock()
+	defer vcrReplayMissesLock.Unlock()
+	delete(vcrReplayMisses[testName], r)
+}
+
+// logVcrReplayMisses logs the requests of a failed test that matched no
+// recorded interaction, with how they differ from the recorded ones.
+func logVcrReplayMisses(t *testing.T) {
+	vcrReplayMissesLock.Lock()
+	misses := vcrReplayMisses[t.Name()]
+	vcrReplayMissesLock.Unlock()
+	for _, report := range vcrReplayMissReports(misses) {
+		t.Log(report)
+	}
+}
+
+func vcrReplayMissReports(misses map[*http.Request]*vcrReplayMiss) []string {
+	var reports []string
+	for _, miss := range misses {
+		var b strings.Builder
+		fmt.Fprintf(&b, "VCR found no recorded interaction for %s %s", miss.method, miss.url)
+		if len(miss.diffs) == 0 {
+			b.WriteString(", no unreplayed interaction was recorded with this method and URL")
+		}
+		for n, diff := range miss.diffs {
+			fmt.Fprintf(&b, "\n  recorded interaction %d of %d with this method and URL differs:", n+1, len(miss.diffs))
+			for _, line := range diff {
+				fmt.Fprintf(&b, "\n    %s", line)
+			}
+		}
+		reports = append(reports, b.String())
+	}
+	sort.Strings(reports)
+	return reports
+}
+
+func deleteVcrReplayMisses(testName string) {
+	vcrReplayMissesLock.Lock()
+	defer vcrReplayMissesLock.Unlock()
+	delete(vcrReplayMisses, testName)
+}
@@ -0,0 +1,116 @@
+package google
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+)
+
+func TestVcrBodyDiff(t *testing.T) {
+	cases := map[string]struct {
+		contentType string
+		recorded    string
+		actual      string
+		want        []string
+	}{
+		"identical": {
+			contentType: "application/json",
+			recorded:    `{"a":1}`,
+			actual:      `{"a":1}`,
+		},
+		"reordered and default-valued fields": {
+			contentType: "application/json",
+			recorded:    `{"name":"n","labels":{},"config":{"enabled":false,"size":2}}`,
+			actual:      `{"config":{"size":2},"name":"n","description":""}`,
+		},
+		"redacted secret": {
+			contentType: "application/json",
+			recorded:    `{"name":"u","password":"REDACTED"}`,
+			actual:      `{"name":"u","password":"hunter2"}`,
+		},
+		"changed fields": {
+			contentType: "application/json",
+			recorded:    `{"name":"n","config":{"size":2},"tags":["a","b"]}`,
+			actual:      `{"name":"m","config":{"zone":"z"},"tags":["a"]}`,
+			want: []string{
+				"body.config.size: missing from request, recorded 2",
+				`body.config.zone: not recorded, got "z"`,
+				`body.name: recorded "n", got "m"`,
+				`body.tags[1]: missing from request, recorded "b"`,
+			},
+		},
+		"changed type": {
+			contentType: "application/json",
+			recorded:    `{"size":"2"}`,
+			actual:      `{"size":2}`,
+			want:        []string{`body.size: recorded "2", got 2`},
+		},
+		"redacted form": {
+			contentType: "application/x-www-form-urlencoded",
+			recorded:    "client_secret=REDACTED&grant_type=refresh_token",
+			actual:      "grant_type=refresh_token&client_secret=xyz",
+		},
+		"changed text": {
+			contentType: "text/plain",
+			recorded:    "a",
+			actual:      "b",
+			want:        []string{`body: recorded "a", got "b"`},
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := vcrBodyDiff(tc.contentType, tc.recorded, tc.actual); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected diff %q, got %q", tn, tc.want, got)
+		}
+	}
+}
+
+func TestVcrRequestMatcher(t *testing.T) {
+	testName := t.Name()
+	defer deleteVcrReplayMisses(testName)
+	matcher := vcrRequestMatcher(context.Background(), testName)
+	url := "https://compute.googleapis.com/compute/v1/projects/p/global/networks"
+
+	newRequest := func(body string) *http.Request {
+		r, err := http.NewRequest("POST", url, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Content-Type", "application/json")
+		return r
+	}
+	first := cassette.Request{Method: "POST", URL: url, Body: `{"name":"first"}`}
+	second := cassette.Request{Method: "POST", URL: url, Body: `{"name":"second","autoCreateSubnetworks":false}`}
+
+	r := newRequest(`{"name":"second"}`)
+	if matcher(r, first) {
+		t.Errorf("expected the request not to match the first interaction")
+	}
+	if !matcher(r, second) {
+		t.Errorf("expected the request to match the second interaction")
+	}
+	// The body is read again for each recorded interaction
+	r = newRequest(`{"name":"third"}`)
+	for _, i := range []cassette.Request{first, second} {
+		if matcher(r, i) {
+			t.Errorf("expected the request not to match %s", i.Body)
+		}
+	}
+
+	misses := vcrReplayMisses[testName]
+	if len(misses) != 1 {
+		t.Fatalf("expected 1 unmatched request, got %d", len(misses))
+	}
+	want := "VCR found no recorded interaction for POST " + url + `
+  recorded interaction 1 of 2 with this method and URL differs:
+    body.name: recorded "first", got "third"
+  recorded interaction 2 of 2 with this method and URL differs:
+    body.name: recorded "second", got "third"`
+	if got := vcrReplayMissReports(misses); !reflect.DeepEqual(got, []string{want}) {
+		t.Errorf("expected report:\n%s\ngot:\n%s", want, strings.Join(got, "\n"))
+	}
+}
@@ -0,0 +1,237 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dnaeon/go-vcr/cassette"
+)
+
+// vcrRedacted replaces secrets in recorded cassettes.
+const vcrRedacted = "REDACTED"
+
+// VcrScrubConfig lists the headers and JSON fields whose values are redacted
+// from recorded cassettes, wherever they appear in a request or a response.
+type VcrScrubConfig struct {
+	// Headers are matched case-insensitively.
+	Headers []string
+	// JSONFields are matched against the keys of JSON objects at any depth,
+	// and against form fields.
+	JSONFields []string
+}
+
+var defaultVcrScrubConfig = VcrScrubConfig{
+	Headers: []string{
+		"Authorization",
+		"Cookie",
+		"Set-Cookie",
+		"X-Goog-Api-Key",
+	},
+	JSONFields: sensitiveFieldNames,
+}
+
+// vcrCredentialPatterns match credentials in cassettes that scrubbing may
+// have missed, such as a secret in an error message.
+var vcrCredentialPatterns = map[string]*regexp.Regexp{
+	"private key":         regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+	"OAuth access token":  regexp.MustCompile(`ya29\.[0-9A-Za-z_\-]{20,}`),
+	"OAuth refresh token": regexp.MustCompile(`1//[0-9A-Za-z_\-]{20,}`),
+	"API key":             regexp.MustCompile(`AIza[0-9A-Za-z_\-]{35}`),
+	"service account key": regexp.MustCompile(`"type":\s*"service_account"|ewogICJ0eXBlIjogInNlcnZpY2VfYWNjb3VudCIs`),
+}
+
+// vcrScrubConfig returns the default scrubbing rules, extended with the
+// comma-separated VCR_SCRUB_HEADERS and VCR_SCRUB_JSON_FIELDS environment
+// variables.
+func vcrScrubConfig() VcrScrubConfig {
+	cfg := VcrScrubConfig{
+		Headers:    append([]string{}, defaultVcrScrubConfig.Headers...),
+		JSONFields: append([]string{}, defaultVcrScrubConfig.JSONFields...),
+	}
+	if v := os.Getenv("VCR_SCRUB_HEADERS"); v != "" {
+		cfg.Headers = append(cfg.Headers, strings.Split(v, ",")...)
+	}
+	if v := os.Getenv("VCR_SCRUB_JSON_FIELDS"); v != "" {
+		cfg.JSONFields = append(cfg.JSONFields, strings.Split(v, ",")...)
+	}
+	return cfg
+}
+
+func (cfg VcrScrubConfig) isJSONField(k string) bool {
+	for _, f := range cfg.JSONFields {
+		if k == f {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubInteraction redacts secrets from a recorded interaction, returning
+// whether anything was redacted.
+func (cfg VcrScrubConfig) scrubInteraction(i *cassette.Interaction) bool {
+	scrubbed := false
+	for _, h := range []map[string][]string{i.Request.Headers, i.Response.Headers} {
+		scrubbed = cfg.scrubHeaders(h) || scrubbed
+	}
+
+	var ok bool
+	if i.Request.Body, ok = cfg.scrubBody(i.Request.Body); ok {
+		scrubbed = true
+	}
+	if i.Response.Body, ok = cfg.scrubBody(i.Response.Body); ok {
+		scrubbed = true
+	}
+	for k, values := range i.Request.Form {
+		if cfg.isJSONField(k) && !(len(values) == 1 && values[0] == vcrRedacted) {
+			i.Request.Form[k] = []string{vcrRedacted}
+			scrubbed = true
+		}
+	}
+	return scrubbed
+}
+
+func (cfg VcrScrubConfig) scrubHeaders(headers map[string][]string) bool {
+	scrubbed := false
+	for k, values := range headers {
+		for _, h := range cfg.Headers {
+			if strings.EqualFold(k, h) {
+				for j := range values {
+					if values[j] != vcrRedacted {
+						values[j] = vcrRedacted
+						scrubbed = true
+					}
+				}
+			}
+		}
+	}
+	return scrubbed
+}
+
+// scrubBody redacts the configured fields of a JSON or form-encoded body.
+// Other bodies are returned unchanged.
+func (cfg VcrScrubConfig) scrubBody(body string) (string, bool) {
+	trimmed := strings.TrimSpace(body)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var v interface{}
+		if err := json.Unmarshal([]byte(body), &v); err != nil {
+			return body, false
+		}
+		v, scrubbed := cfg.scrubJSON(v)
+		if !scrubbed {
+			return body, false
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return body, false
+		}
+		return string(b), true
+	}
+
+	if form, err := url.ParseQuery(body); err == nil && strings.Contains(body, "=") {
+		scrubbed := false
+		for k := range form {
+			if cfg.isJSONField(k) && form.Get(k) != vcrRedacted {
+				form.Set(k, vcrRedacted)
+				scrubbed = true
+			}
+		}
+		if scrubbed {
+			return form.Encode(), true
+		}
+	}
+	return body, false
+}
+
+func (cfg VcrScrubConfig) scrubJSON(v interface{}) (interface{}, bool) {
+	scrubbed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if cfg.isJSONField(k) && field != nil {
+				if _, isObject := field.(map[string]interface{}); !isObject && field != vcrRedacted {
+					v[k] = vcrRedacted
+					scrubbed = true
+					continue
+				}
+			}
+			var ok bool
+			if v[k], ok = cfg.scrubJSON(field); ok {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for j := range v {
+			var ok bool
+			if v[j], ok = cfg.scrubJSON(v[j]); ok {
+				scrubbed = true
+			}
+		}
+	}
+	return v, scrubbed
+}
+
+// scrubCassette redacts secrets from the cassette saved at path, without
+// the .yaml extension.
+func scrubCassette(path string, cfg VcrScrubConfig) error {
+	c, err := cassette.Load(path)
+	if err != nil {
+		return fmt.Errorf("error loading cassette %s: %s", path, err)
+	}
+	scrubbed := false
+	for _, i := range c.Interactions {
+		scrubbed = cfg.scrubInteraction(i) || scrubbed
+	}
+	if !scrubbed {
+		return nil
+	}
+	if err := c.Save(); err != nil {
+		return fmt.Errorf("error saving cassette %s: %s", path, err)
+	}
+	return nil
+}
+
+// LintCassette reports the secrets left in the cassette file at path: values
+// of the fields scrubbing redacts, and strings matching credential patterns.
+func LintCassette(path string) ([]string, error) {
+	name := strings.TrimSuffix(path, ".yaml")
+	c, err := cassette.Load(name)
+	if err != nil {
+		return nil, fmt.Errorf("error loading cassette %s: %s", path, err)
+	}
+
+	cfg := vcrScrubConfig()
+	var findings []string
+	for n, i := range c.Interactions {
+		where := fmt.Sprintf("%s: interaction %d (%s %s)", path, n, i.Request.Method, i.Request.URL)
+		contents := []string{i.Request.URL, i.Request.Body, i.Response.Body}
+		for _, h := range []map[string][]string{i.Request.Headers, i.Response.Headers} {
+			for _, values := range h {
+				contents = append(contents, values...)
+			}
+		}
+
+		var patterns []string
+		for name, re := range vcrCredentialPatterns {
+			for _, s := range contents {
+				if re.MatchString(s) {
+					patterns = append(patterns, name)
+					break
+				}
+			}
+		}
+		sort.Strings(patterns)
+		for _, name := range patterns {
+			findings = append(findings, fmt.Sprintf("%s contains a %s", where, name))
+		}
+
+		if cfg.scrubInteraction(i) {
+			findings = append(findings, fmt.Sprintf("%s has unredacted headers or fields", where))
+		}
+	}
+	return findings, nil
+}
@@ -3,10 +3,8 @@ package google
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
@@ -20,7 +18,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/dnaeon/go-vcr/recorder"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -146,6 +143,11 @@ func VcrTest(t *testing.T, c resource.TestCase) {
 
 // We need to explicitly close the VCR recorder to save the cassette
 func closeRecorder(t *testing.T) {
+	if t.Failed() && os.Getenv("VCR_MODE") == "REPLAYING" {
+		logVcrReplayMisses(t)
+	}
+	deleteVcrReplayMisses(t.Name())
+
 	configsLock.RLock()
 	config, ok := configs[t.Name()]
 	configsLock.RUnlock()
@@ -158,6 +160,12 @@ func closeRecorder(t *testing.T) {
 				t.Error(err)
 			}
 			envPath := os.Getenv("VCR_PATH")
+			if os.Getenv("VCR_MODE") == "RECORDING" {
+				// Redact secrets before the cassette can be checked in
+				if err := scrubCassette(filepath.Join(envPath, vcrFileName(t.Name())), vcrScrubConfig()); err != nil {
+					t.Error(err)
+				}
+			}
 
 			sourcesLock.RLock()
 			vcrSource, ok := sources[t.Name()]
@@ -191,6 +199,12 @@ func closeRecorder(t *testing.T) {
 				t.Error(err)
 			}
 			envPath := os.Getenv("VCR_PATH")
+			if os.Getenv("VCR_MODE") == "RECORDING" {
+				// Redact secrets before the cassette can be checked in
+				if err := scrubCassette(filepath.Join(envPath, vcrFileName(t.Name())), vcrScrubConfig()); err != nil {
+					t.Error(err)
+				}
+			}
 
 			sourcesLock.RLock()
 			vcrSource, ok := sources[t.Name()]
@@ -294,7 +308,7 @@ func reformConfigWithProvider(config, provider string) string {
 	return string(resourceHeader.ReplaceAll(configBytes, providerReplacementBytes))
 }
 
-func HandleVCRConfiguration(ctx context.Context, testName string, rndTripper http.RoundTripper, pollInterval time.Duration) (time.Duration, http.RoundTripper, fwDiags.Diagnostics) {
+func HandleVCRConfiguration(ctx context.Context, testName string, rndTripper http.RoundTripper, polling PollingConfig) (PollingConfig, http.RoundTripper, fwDiags.Diagnostics) {
 	var diags fwDiags.Diagnostics
 	var vcrMode recorder.Mode
 	switch vcrEnv := os.Getenv("VCR_MODE"); vcrEnv {
@@ -303,68 +317,28 @@ func HandleVCRConfiguration(ctx context.Context, testName string, rndTripper htt
 	case "REPLAYING":
 		vcrMode = recorder.ModeReplaying
 		// When replaying, set the poll interval low to speed up tests
-		pollInterval = 10 * time.Millisecond
+		polling = PollingConfig{Default: FixedPollingStrategy(10 * time.Millisecond)}
 	default:
 		tflog.Debug(ctx, fmt.Sprintf("No valid environment var set for VCR_MODE, expected RECORDING or REPLAYING, skipping VCR. VCR_MODE: %s", vcrEnv))
-		return pollInterval, rndTripper, diags
+		return polling, rndTripper, diags
 	}
 
 	envPath := os.Getenv("VCR_PATH")
 	if envPath == "" {
 		tflog.Debug(ctx, "No environment var set for VCR_PATH, skipping VCR")
-		return pollInterval, rndTripper, diags
+		return polling, rndTripper, diags
 	}
 	path := filepath.Join(envPath, vcrFileName(testName))
 
 	rec, err := recorder.NewAsMode(path, vcrMode, rndTripper)
 	if err != nil {
 		diags.AddError("error creating record as new mode", err.Error())
-		return pollInterval, rndTripper, diags
+		return polling, rndTripper, diags
 	}
 	// Defines how VCR will match requests to responses.
-	rec.SetMatcher(func(r *http.Request, i cassette.Request) bool {
-		// Default matcher compares method and URL only
-		if !cassette.DefaultMatcher(r, i) {
-			return false
-		}
-		if r.Body == nil {
-			return true
-		}
-		contentType := r.Header.Get("Content-Type")
-		// If body contains media, don't try to compare
-		if strings.Contains(contentType, "multipart/related") {
-			return true
-		}
-
-		var b bytes.Buffer
-		if _, err := b.ReadFrom(r.Body); err != nil {
-			tflog.Debug(ctx, fmt.Sprintf("Failed to read request body from cassette: %v", err))
-			return false
-		}
-		r.Body = ioutil.NopCloser(&b)
-		reqBody := b.String()
-		// If body matches identically, we are done
-		if reqBody == i.Body {
-			return true
-		}
-
-		// JSON might be the same, but reordered. Try parsing json and comparing
-		if strings.Contains(contentType, "application/json") {
-			var reqJson, cassetteJson interface{}
-			if err := json.Unmarshal([]byte(reqBody), &reqJson); err != nil {
-				tflog.Debug(ctx, fmt.Sprintf("Failed to unmarshall request json: %v", err))
-				return false
-			}
-			if err := json.Unmarshal([]byte(i.Body), &cassetteJson); err != nil {
-				tflog.Debug(ctx, fmt.Sprintf("Failed to unmarshall cassette json: %v", err))
-				return false
-			}
-			return reflect.DeepEqual(reqJson, cassetteJson)
-		}
-		return false
-	})
+	rec.SetMatcher(vcrRequestMatcher(ctx, testName))
 
-	return pollInterval, rec, diags
+	return polling, rec, diags
 }
 
 // MuxedProviders configures the providers, thus, if we want the providers to be configured
@@ -398,7 +372,7 @@ func (p *frameworkTestProvider) Configure(ctx context.Context, req provider.Conf
 		}
 
 		var diags fwDiags.Diagnostics
-		p.pollInterval, p.client.Transport, diags = HandleVCRConfiguration(ctx, p.TestName, p.client.Transport, p.pollInterval)
+		p.polling, p.client.Transport, diags = HandleVCRConfiguration(ctx, p.TestName, p.client.Transport, p.polling)
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -461,7 +435,7 @@ func getCachedConfig(ctx context.Context, d *schema.ResourceData, configureFunc
 
 	var fwD fwDiags.Diagnostics
 	config := c.(*Config)
-	config.PollInterval, config.Client.Transport, fwD = HandleVCRConfiguration(ctx, testName, config.Client.Transport, config.PollInterval)
+	config.Polling, config.Client.Transport, fwD = HandleVCRConfiguration(ctx, testName, config.Client.Transport, config.Polling)
 	if fwD.HasError() {
 		diags = append(diags, *frameworkDiagsToSdkDiags(fwD)...)
 		return nil, diags
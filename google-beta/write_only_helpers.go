@@ -0,0 +1,44 @@
+package google
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// writeOnlyStateMarker is stored in state in place of the value of a
+// write-only field. Write-only fields hold secrets that are sent to the API
+// but only ever read from the configuration, so that they never land in state.
+const writeOnlyStateMarker = "write-only"
+
+// writeOnlyStateFunc is the StateFunc of write-only fields. Every value is
+// stored as the same marker, so changing the value alone doesn't produce a
+// diff; secrets are rotated by changing the version field accompanying the
+// write-only field.
+func writeOnlyStateFunc(v interface{}) string {
+	if s, _ := v.(string); s == "" {
+		return ""
+	}
+	return writeOnlyStateMarker
+}
+
+// getWriteOnlyString returns the configured value of the write-only field
+// key, or "" if it isn't set. d is a *schema.ResourceData or, in a
+// CustomizeDiff function, a *schema.ResourceDiff.
+func getWriteOnlyString(d interface{ Get(string) interface{} }, key string) string {
+	if rd, ok := d.(interface{ GetRawConfig() cty.Value }); ok {
+		if raw := rd.GetRawConfig(); !raw.IsNull() && raw.IsKnown() {
+			v := raw.GetAttr(key)
+			if v.IsNull() || !v.IsKnown() {
+				return ""
+			}
+			return v.AsString()
+		}
+	}
+
+	// Terraform always sends the configuration, but it isn't available when
+	// the provider is called directly, as in unit tests. The value of the diff
+	// is then the configured value, as long as the field is being changed.
+	if v, _ := d.Get(key).(string); v != writeOnlyStateMarker {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWriteOnlyStateFunc(t *testing.T) {
+	if got := writeOnlyStateFunc("s3cr3t"); got != writeOnlyStateMarker {
+		t.Errorf("expected a value to be stored as %q, got %q", writeOnlyStateMarker, got)
+	}
+	if got := writeOnlyStateFunc(""); got != "" {
+		t.Errorf("expected an empty value to be stored as is, got %q", got)
+	}
+}
+
+func TestGetWriteOnlyString(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"password_wo": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			StateFunc: writeOnlyStateFunc,
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{"password_wo": "s3cr3t"})
+	if got := getWriteOnlyString(d, "password_wo"); got != "s3cr3t" {
+		t.Errorf("expected the configured value, got %q", got)
+	}
+	d.SetId("user")
+	if state := d.State(); state.Attributes["password_wo"] != writeOnlyStateMarker {
+		t.Errorf("expected the state to hold %q, got %q", writeOnlyStateMarker, state.Attributes["password_wo"])
+	}
+
+	d = schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+	if got := getWriteOnlyString(d, "password_wo"); got != "" {
+		t.Errorf("expected no value, got %q", got)
+	}
+}
@@ -30,8 +30,8 @@ func main() {
 
 	// concat with sdkv2 provider
 	providers := []func() tfprotov5.ProviderServer{
-		providerserver.NewProtocol5(google.New(version)), // framework provider
-		google.Provider().GRPCProvider,                   // sdk provider
+		providerserver.NewProtocol5(google.New(version)),        // framework provider
+		google.WithPlanWarnings(google.Provider().GRPCProvider), // sdk provider
 	}
 
 	// use the muxer
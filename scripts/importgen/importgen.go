@@ -0,0 +1,486 @@
+// importgen generates Terraform configuration importing the existing resources
+// of a project, folder or organization.
+//
+// Example usage: go run importgen.go -scope projects/my-project -out imported.tf
+//
+// Resources are enumerated with the Cloud Asset Inventory, through the
+// google_cloud_asset_resources_search_all data source, so the Cloud Asset API
+// must be enabled. Each resource found is imported and read with the provider,
+// and written as a Terraform 1.5 import {} block followed by its configuration.
+// Computed-only attributes are left out of the configuration.
+//
+// Only the 18 asset types listed in assetTypeResources are supported, and the
+// resources of other types found in the scope are counted and skipped. Of each
+// set of conflicting fields, such as allow and deny, only one is written,
+// preferring fields that aren't computed by the API. Sensitive fields are left
+// out, and the labels of resources with effective_labels are written from it,
+// without the goog- labels added by Google services. The generated
+// configuration is a starting point: run `terraform plan` to review the
+// remaining differences with the live resources.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	google "github.com/hashicorp/terraform-provider-google-beta/google-beta"
+)
+
+// assetTypeResources maps Cloud Asset Inventory asset types to the resources
+// managing them.
+//
+// Managed DNS zones aren't supported: their asset names hold the zone's
+// numeric id, which google_dns_managed_zone can't be imported by.
+var assetTypeResources = map[string]assetTypeResource{
+	"artifactregistry.googleapis.com/Repository": {"google_artifact_registry_repository", relativeResourceName},
+	"bigquery.googleapis.com/Dataset":            {"google_bigquery_dataset", relativeResourceName},
+	"cloudkms.googleapis.com/KeyRing":            {"google_kms_key_ring", relativeResourceName},
+	"compute.googleapis.com/Address":             {"google_compute_address", relativeResourceName},
+	"compute.googleapis.com/Disk":                {"google_compute_disk", relativeResourceName},
+	"compute.googleapis.com/Firewall":            {"google_compute_firewall", relativeResourceName},
+	"compute.googleapis.com/GlobalAddress":       {"google_compute_global_address", relativeResourceName},
+	"compute.googleapis.com/Instance":            {"google_compute_instance", relativeResourceName},
+	"compute.googleapis.com/InstanceTemplate":    {"google_compute_instance_template", relativeResourceName},
+	"compute.googleapis.com/Network":             {"google_compute_network", relativeResourceName},
+	"compute.googleapis.com/Router":              {"google_compute_router", relativeResourceName},
+	"compute.googleapis.com/Subnetwork":          {"google_compute_subnetwork", relativeResourceName},
+	"container.googleapis.com/Cluster":           {"google_container_cluster", containerClusterImportId},
+	"pubsub.googleapis.com/Subscription":         {"google_pubsub_subscription", relativeResourceName},
+	"pubsub.googleapis.com/Topic":                {"google_pubsub_topic", relativeResourceName},
+	"secretmanager.googleapis.com/Secret":        {"google_secret_manager_secret", relativeResourceName},
+	"sqladmin.googleapis.com/Instance":           {"google_sql_database_instance", relativeResourceName},
+	"storage.googleapis.com/Bucket":              {"google_storage_bucket", relativeResourceName},
+}
+
+type assetTypeResource struct {
+	resource string
+	// importId converts an asset name to an id the resource's importer
+	// accepts.
+	importId func(assetName string) (string, error)
+}
+
+// relativeResourceName returns the relative resource name in an asset name
+// //{service}.googleapis.com/{relative resource name}, e.g.
+// projects/p/global/networks/n, or the bucket name for a storage bucket.
+func relativeResourceName(assetName string) (string, error) {
+	if !strings.HasPrefix(assetName, "//") {
+		return "", fmt.Errorf("unexpected asset name %q", assetName)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(assetName, "//"), "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", fmt.Errorf("unexpected asset name %q", assetName)
+	}
+	return parts[1], nil
+}
+
+// containerClusterImportId returns the import id of a cluster. The asset
+// names of zonal clusters have a zones/{zone} segment, while the importer
+// expects locations/{zone}.
+func containerClusterImportId(assetName string) (string, error) {
+	name, err := relativeResourceName(assetName)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(name, "/")
+	if len(parts) != 6 || parts[0] != "projects" || (parts[2] != "zones" && parts[2] != "locations") || parts[4] != "clusters" {
+		return "", fmt.Errorf("unexpected cluster asset name %q", assetName)
+	}
+	parts[2] = "locations"
+	return strings.Join(parts, "/"), nil
+}
+
+var (
+	scopeFlag      = flag.String("scope", "", "the scope to search, e.g. projects/my-project, folders/123 or organizations/123")
+	assetTypesFlag = flag.String("asset-types", "", "comma-separated asset types to import, defaults to all the supported ones")
+	projectFlag    = flag.String("project", "", "the provider's default project")
+	outFlag        = flag.String("out", "", "the file to write the configuration to, defaults to stdout")
+)
+
+const usage = `Usage: go run ./scripts/importgen -scope SCOPE [-asset-types TYPES] [-project PROJECT] [-out FILE]
+
+Generates import blocks and configuration for the existing resources in SCOPE.
+
+Only these asset types are supported, resources of other types are counted
+and skipped:
+  %s
+
+Of conflicting fields, only one is written. Sensitive fields are left out and
+must be set manually. Labels added by Google services (goog-*) aren't written.
+
+Flags:
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, strings.Join(supportedAssetTypes(), "\n  "))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *scopeFlag == "" {
+		fmt.Println("-scope must be set")
+		flag.Usage()
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	// Without -asset-types, every resource is listed so that the unsupported
+	// ones can be reported.
+	var assetTypes []string
+	if *assetTypesFlag != "" {
+		assetTypes = strings.Split(*assetTypesFlag, ",")
+		for _, t := range assetTypes {
+			if _, ok := assetTypeResources[t]; !ok {
+				log.Fatalf("Unsupported asset type %q, expected one of: %s", t, strings.Join(supportedAssetTypes(), ", "))
+			}
+		}
+	}
+
+	provider := google.Provider()
+	providerConfig := map[string]interface{}{}
+	if *projectFlag != "" {
+		providerConfig["project"] = *projectFlag
+	}
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(providerConfig)); diags.HasError() {
+		log.Fatalf("Error configuring the provider: %v", diags)
+	}
+	meta := provider.Meta()
+
+	assets, err := searchAssets(provider, meta, *scopeFlag, assetTypes)
+	if err != nil {
+		log.Fatalf("Error searching resources in %s: %s", *scopeFlag, err)
+	}
+	log.Printf("Found %d resources in %s", len(assets), *scopeFlag)
+
+	var out strings.Builder
+	labels := map[string]int{}
+	failed := 0
+	unsupported := map[string]int{}
+	for _, a := range assets {
+		t, ok := assetTypeResources[a.assetType]
+		if !ok {
+			unsupported[a.assetType]++
+			failed++
+			continue
+		}
+		resourceType := t.resource
+		r := provider.ResourcesMap[resourceType]
+		id, err := t.importId(a.name)
+		if err != nil {
+			log.Printf("[WARN] Skipping %s %s: %s", resourceType, a.name, err)
+			failed++
+			continue
+		}
+		d, err := importResource(ctx, r, id, meta)
+		if err != nil {
+			log.Printf("[WARN] Skipping %s %s: %s", resourceType, a.name, err)
+			failed++
+			continue
+		}
+
+		label := resourceLabel(a, labels)
+		fmt.Fprintf(&out, "import {\n  to = %s.%s\n  id = %s\n}\n\n", resourceType, label, hclString(id))
+		fmt.Fprintf(&out, "resource %q %q {\n", resourceType, label)
+		get := importedValue(d, r.Schema)
+		writeBody(&out, r.Schema, "", get, func(k string) bool {
+			return !isZero(get(k))
+		})
+		out.WriteString("}\n\n")
+	}
+
+	for _, t := range sortedKeys(unsupported) {
+		log.Printf("[WARN] Skipped %d resources of unsupported asset type %s", unsupported[t], t)
+	}
+
+	config := hclwrite.Format([]byte(out.String()))
+	if *outFlag == "" {
+		os.Stdout.Write(config)
+	} else if err := ioutil.WriteFile(*outFlag, config, 0644); err != nil {
+		log.Fatalf("Error writing %s: %s", *outFlag, err)
+	}
+	log.Printf("Generated configuration for %d resources, %d skipped", len(assets)-failed, failed)
+}
+
+// importedValue returns a function getting the imported value of a field. The
+// labels of resources with effective_labels aren't read into labels on import,
+// so they're taken from effective_labels, without the goog- labels added by
+// Google services.
+func importedValue(d *schema.ResourceData, s map[string]*schema.Schema) func(string) interface{} {
+	return func(k string) interface{} {
+		if _, ok := s["effective_labels"]; !ok || k != "labels" {
+			return d.Get(k)
+		}
+		labels := map[string]interface{}{}
+		for k, v := range d.Get("effective_labels").(map[string]interface{}) {
+			if !strings.HasPrefix(k, "goog-") {
+				labels[k] = v
+			}
+		}
+		return labels
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func supportedAssetTypes() []string {
+	var types []string
+	for t := range assetTypeResources {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+type asset struct {
+	name        string
+	assetType   string
+	displayName string
+}
+
+// searchAssets lists the resources of the given asset types in scope, or all of
+// them if assetTypes is empty, with the google_cloud_asset_resources_search_all
+// data source.
+func searchAssets(provider *schema.Provider, meta interface{}, scope string, assetTypes []string) ([]asset, error) {
+	ds := provider.DataSourcesMap["google_cloud_asset_resources_search_all"]
+	d := ds.Data(nil)
+	if err := d.Set("scope", scope); err != nil {
+		return nil, err
+	}
+	if err := d.Set("asset_types", assetTypes); err != nil {
+		return nil, err
+	}
+	if err := ds.Read(d, meta); err != nil {
+		return nil, err
+	}
+
+	var assets []asset
+	for _, raw := range d.Get("results").([]interface{}) {
+		result := raw.(map[string]interface{})
+		assets = append(assets, asset{
+			name:        result["name"].(string),
+			assetType:   result["asset_type"].(string),
+			displayName: result["display_name"].(string),
+		})
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].name < assets[j].name })
+	return assets, nil
+}
+
+// importResource imports and reads a resource as `terraform import` would.
+func importResource(ctx context.Context, r *schema.Resource, id string, meta interface{}) (*schema.ResourceData, error) {
+	if r.Importer == nil {
+		return nil, fmt.Errorf("import is not supported")
+	}
+
+	d := r.Data(nil)
+	d.SetId(id)
+	var imported []*schema.ResourceData
+	var err error
+	if r.Importer.StateContext != nil {
+		imported, err = r.Importer.StateContext(ctx, d, meta)
+	} else if r.Importer.State != nil {
+		imported, err = r.Importer.State(d, meta)
+	} else {
+		imported = []*schema.ResourceData{d}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(imported) != 1 {
+		return nil, fmt.Errorf("expected to import 1 resource, got %d", len(imported))
+	}
+
+	if imported[0].State() == nil {
+		return nil, fmt.Errorf("resource not found")
+	}
+	state, diags := r.RefreshWithoutUpgrade(ctx, imported[0].State(), meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%v", diags)
+	}
+	if state == nil || state.ID == "" {
+		return nil, fmt.Errorf("resource not found")
+	}
+	return r.Data(state), nil
+}
+
+var invalidLabelChars = regexp.MustCompile("[^a-z0-9_]+")
+
+// resourceLabel returns a unique resource label derived from the resource's
+// name.
+func resourceLabel(a asset, seen map[string]int) string {
+	name := a.displayName
+	if name == "" {
+		name = a.name[strings.LastIndex(a.name, "/")+1:]
+	}
+	label := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+
+	seen[label]++
+	if n := seen[label]; n > 1 {
+		label = fmt.Sprintf("%s_%d", label, n)
+	}
+	return label
+}
+
+// isComputedOnly reports whether a field can't be set in configuration.
+func isComputedOnly(s *schema.Schema) bool {
+	return s.Computed && !s.Optional && !s.Required
+}
+
+// writeBody writes the arguments of a resource or nested block with the given
+// schema. prefix is the path of the block, e.g. "" for a resource or
+// "network_interface.0." for its first network_interface block. get returns
+// the value of a field, and isSet whether it's set to a non-zero value.
+//
+// Of a set of fields that conflict with each other, through ConflictsWith or
+// ExactlyOneOf, only the first one set is written. Fields that aren't computed
+// come first, as computed ones are likely defaulted by the API rather than
+// configured.
+func writeBody(out *strings.Builder, s map[string]*schema.Schema, prefix string, get func(string) interface{}, isSet func(string) bool) {
+	var keys []string
+	for k, field := range s {
+		if isComputedOnly(field) || field.Deprecated != "" || (!field.Required && !isSet(k)) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if ci, cj := s[keys[i]].Computed, s[keys[j]].Computed; ci != cj {
+			return cj
+		}
+		return keys[i] < keys[j]
+	})
+
+	written := map[string]bool{}
+	var args []string
+	for _, k := range keys {
+		field := s[k]
+		if conflictWritten(field, prefix+k, written) {
+			continue
+		}
+		written[prefix+k] = true
+		args = append(args, k)
+	}
+	sort.Strings(args)
+
+	for _, k := range args {
+		field := s[k]
+		if field.Sensitive {
+			fmt.Fprintf(out, "# %s is sensitive and must be set manually\n", k)
+			continue
+		}
+
+		v := get(k)
+		if elem, ok := field.Elem.(*schema.Resource); ok && (field.Type == schema.TypeList || field.Type == schema.TypeSet) {
+			for i, raw := range listValue(v) {
+				block, _ := raw.(map[string]interface{})
+				fmt.Fprintf(out, "%s {\n", k)
+				writeBody(out, elem.Schema, fmt.Sprintf("%s%s.%d.", prefix, k, i), func(k string) interface{} { return block[k] }, func(k string) bool {
+					return !isZero(block[k])
+				})
+				out.WriteString("}\n")
+			}
+			continue
+		}
+		fmt.Fprintf(out, "%s = %s\n", k, hclValue(v))
+	}
+}
+
+// conflictWritten reports whether a field conflicting with the field at path
+// was already written.
+func conflictWritten(field *schema.Schema, path string, written map[string]bool) bool {
+	for _, c := range append(append([]string{}, field.ConflictsWith...), field.ExactlyOneOf...) {
+		if c != path && written[c] {
+			return true
+		}
+	}
+	return false
+}
+
+func listValue(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+func isZero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return len(listValue(v)) == 0
+}
+
+// hclValue renders a value read from a schema.ResourceData as an HCL
+// expression.
+func hclValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return hclString(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]interface{}:
+		var keys []string
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s = %s\n", hclString(k), hclValue(v[k]))
+		}
+		b.WriteString("}")
+		return b.String()
+	}
+
+	var items []string
+	for _, item := range listValue(v) {
+		items = append(items, hclValue(item))
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences.
+func hclString(s string) string {
+	s = strconv.Quote(s)
+	s = strings.ReplaceAll(s, "${", "$${")
+	return strings.ReplaceAll(s, "%{", "%%{")
+}
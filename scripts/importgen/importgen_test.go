@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	google "github.com/hashicorp/terraform-provider-google-beta/google-beta"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+func TestImportIds(t *testing.T) {
+	cases := map[string]struct {
+		AssetName   string
+		Expected    string
+		ExpectError bool
+	}{
+		"artifactregistry.googleapis.com/Repository": {
+			AssetName: "//artifactregistry.googleapis.com/projects/p/locations/us-central1/repositories/r",
+			Expected:  "projects/p/locations/us-central1/repositories/r",
+		},
+		"bigquery.googleapis.com/Dataset": {
+			AssetName: "//bigquery.googleapis.com/projects/p/datasets/d",
+			Expected:  "projects/p/datasets/d",
+		},
+		"cloudkms.googleapis.com/KeyRing": {
+			AssetName: "//cloudkms.googleapis.com/projects/p/locations/global/keyRings/k",
+			Expected:  "projects/p/locations/global/keyRings/k",
+		},
+		"compute.googleapis.com/Address": {
+			AssetName: "//compute.googleapis.com/projects/p/regions/us-central1/addresses/a",
+			Expected:  "projects/p/regions/us-central1/addresses/a",
+		},
+		"compute.googleapis.com/Disk": {
+			AssetName: "//compute.googleapis.com/projects/p/zones/us-central1-a/disks/d",
+			Expected:  "projects/p/zones/us-central1-a/disks/d",
+		},
+		"compute.googleapis.com/Firewall": {
+			AssetName: "//compute.googleapis.com/projects/p/global/firewalls/f",
+			Expected:  "projects/p/global/firewalls/f",
+		},
+		"compute.googleapis.com/GlobalAddress": {
+			AssetName: "//compute.googleapis.com/projects/p/global/addresses/a",
+			Expected:  "projects/p/global/addresses/a",
+		},
+		"compute.googleapis.com/Instance": {
+			AssetName: "//compute.googleapis.com/projects/p/zones/us-central1-a/instances/i",
+			Expected:  "projects/p/zones/us-central1-a/instances/i",
+		},
+		"compute.googleapis.com/InstanceTemplate": {
+			AssetName: "//compute.googleapis.com/projects/p/global/instanceTemplates/t",
+			Expected:  "projects/p/global/instanceTemplates/t",
+		},
+		"compute.googleapis.com/Network": {
+			AssetName: "//compute.googleapis.com/projects/p/global/networks/n",
+			Expected:  "projects/p/global/networks/n",
+		},
+		"compute.googleapis.com/Router": {
+			AssetName: "//compute.googleapis.com/projects/p/regions/us-central1/routers/r",
+			Expected:  "projects/p/regions/us-central1/routers/r",
+		},
+		"compute.googleapis.com/Subnetwork": {
+			AssetName: "//compute.googleapis.com/projects/p/regions/us-central1/subnetworks/s",
+			Expected:  "projects/p/regions/us-central1/subnetworks/s",
+		},
+		"container.googleapis.com/Cluster": {
+			AssetName: "//container.googleapis.com/projects/p/zones/us-central1-a/clusters/c",
+			Expected:  "projects/p/locations/us-central1-a/clusters/c",
+		},
+		"pubsub.googleapis.com/Subscription": {
+			AssetName: "//pubsub.googleapis.com/projects/p/subscriptions/s",
+			Expected:  "projects/p/subscriptions/s",
+		},
+		"pubsub.googleapis.com/Topic": {
+			AssetName: "//pubsub.googleapis.com/projects/p/topics/t",
+			Expected:  "projects/p/topics/t",
+		},
+		"secretmanager.googleapis.com/Secret": {
+			AssetName: "//secretmanager.googleapis.com/projects/p/secrets/s",
+			Expected:  "projects/p/secrets/s",
+		},
+		"sqladmin.googleapis.com/Instance": {
+			AssetName: "//cloudsql.googleapis.com/projects/p/instances/i",
+			Expected:  "projects/p/instances/i",
+		},
+		"storage.googleapis.com/Bucket": {
+			AssetName: "//storage.googleapis.com/my-bucket",
+			Expected:  "my-bucket",
+		},
+	}
+
+	for assetType := range assetTypeResources {
+		if _, ok := cases[assetType]; !ok {
+			t.Errorf("no import id test case for supported asset type %s", assetType)
+		}
+	}
+	for assetType, tc := range cases {
+		got, err := assetTypeResources[assetType].importId(tc.AssetName)
+		if err != nil {
+			t.Errorf("%s failed; unexpected error: %s", assetType, err)
+		} else if got != tc.Expected {
+			t.Errorf("%s failed; expected %q, got %q", assetType, tc.Expected, got)
+		}
+	}
+
+	for _, name := range []string{"projects/p/topics/t", "//storage.googleapis.com", "//container.googleapis.com/projects/p/clusters/c"} {
+		if got, err := containerClusterImportId(name); err == nil {
+			t.Errorf("expected an error converting %q, got %q", name, got)
+		}
+	}
+}
+
+func TestWriteBody(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"name":        {Type: schema.TypeString, Required: true},
+		"description": {Type: schema.TypeString, Optional: true},
+		"unset":       {Type: schema.TypeString, Optional: true},
+		"self_link":   {Type: schema.TypeString, Computed: true},
+		"region":      {Type: schema.TypeString, Optional: true, Computed: true},
+		"password":    {Type: schema.TypeString, Optional: true, Sensitive: true},
+		"old_field":   {Type: schema.TypeString, Optional: true, Deprecated: "use name"},
+		"labels":      {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"allow":       {Type: schema.TypeString, Optional: true, ExactlyOneOf: []string{"allow", "deny"}},
+		"deny":        {Type: schema.TypeString, Optional: true, ExactlyOneOf: []string{"allow", "deny"}},
+		"ranges":      {Type: schema.TypeString, Optional: true, Computed: true, ConflictsWith: []string{"tags"}},
+		"tags":        {Type: schema.TypeString, Optional: true, ConflictsWith: []string{"ranges"}},
+		"block": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"size":        {Type: schema.TypeInt, Optional: true},
+					"enabled":     {Type: schema.TypeBool, Optional: true},
+					"fingerprint": {Type: schema.TypeString, Computed: true},
+					"mode":        {Type: schema.TypeString, Optional: true, ConflictsWith: []string{"block.0.policy"}},
+					"policy":      {Type: schema.TypeString, Optional: true, ConflictsWith: []string{"block.0.mode"}},
+				},
+			},
+		},
+	}
+	values := map[string]interface{}{
+		"name":        "n",
+		"description": "with ${template}",
+		"self_link":   "https://example.com/n",
+		"region":      "us-central1",
+		"password":    "hunter2",
+		"old_field":   "o",
+		"labels":      map[string]interface{}{"env": "test"},
+		"allow":       "tcp",
+		"deny":        "udp",
+		"ranges":      "0.0.0.0/0",
+		"tags":        "web",
+		"block": []interface{}{
+			map[string]interface{}{"size": 10, "enabled": false, "fingerprint": "abc", "mode": "m", "policy": "p"},
+		},
+	}
+
+	var out strings.Builder
+	writeBody(&out, s, "", func(k string) interface{} { return values[k] }, func(k string) bool {
+		return !isZero(values[k])
+	})
+
+	expected := `allow = "tcp"
+block {
+mode = "m"
+size = 10
+}
+description = "with $${template}"
+labels = {
+"env" = "test"
+}
+name = "n"
+# password is sensitive and must be set manually
+region = "us-central1"
+tags = "web"
+`
+	if got := out.String(); got != expected {
+		t.Errorf("expected body:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+// TestWriteBody_validates checks that the configuration generated for an
+// imported resource passes the provider's validation, as `terraform validate`
+// would run it.
+func TestWriteBody_validates(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"google_compute_firewall": {
+			"name":      "fw",
+			"network":   "projects/p/global/networks/default",
+			"project":   "p",
+			"direction": "INGRESS",
+			"priority":  1000,
+			"allow": []interface{}{
+				map[string]interface{}{"protocol": "tcp", "ports": []interface{}{"80"}},
+			},
+			// The API returns both sides of these conflicts.
+			"deny": []interface{}{
+				map[string]interface{}{"protocol": "udp"},
+			},
+			"source_ranges":           []interface{}{"0.0.0.0/0"},
+			"source_tags":             []interface{}{"web"},
+			"source_service_accounts": []interface{}{"sa@p.iam.gserviceaccount.com"},
+			"self_link":               "https://www.googleapis.com/compute/v1/projects/p/global/firewalls/fw",
+		},
+		"google_storage_bucket": {
+			"name":          "b",
+			"location":      "US",
+			"project":       "p",
+			"storage_class": "STANDARD",
+			"effective_labels": map[string]interface{}{
+				"env":          "test",
+				"goog-managed": "true",
+			},
+			"url": "gs://b",
+		},
+	}
+
+	provider := google.Provider()
+	for resourceType, state := range cases {
+		r := provider.ResourcesMap[resourceType]
+		d := schema.TestResourceDataRaw(t, r.Schema, state)
+		get := importedValue(d, r.Schema)
+
+		var out strings.Builder
+		writeBody(&out, r.Schema, "", get, func(k string) bool {
+			return !isZero(get(k))
+		})
+
+		file, diags := hclsyntax.ParseConfig([]byte(out.String()), resourceType+".tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("%s: invalid HCL: %s\n%s", resourceType, diags, out.String())
+		}
+		raw := hclBodyToRaw(t, file.Body.(*hclsyntax.Body))
+		if diags := r.Validate(terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+			t.Errorf("%s: generated configuration is invalid: %v\n%s", resourceType, diags, out.String())
+		}
+		if resourceType == "google_storage_bucket" && !strings.Contains(out.String(), `labels = {
+"env" = "test"
+}`) {
+			t.Errorf("%s: expected the labels without goog- labels, got:\n%s", resourceType, out.String())
+		}
+	}
+}
+
+// hclBodyToRaw converts an HCL body to the raw configuration format of
+// terraform.NewResourceConfigRaw.
+func hclBodyToRaw(t *testing.T, body *hclsyntax.Body) map[string]interface{} {
+	raw := map[string]interface{}{}
+	for name, attr := range body.Attributes {
+		v, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			t.Fatalf("invalid value for %s: %s", name, diags)
+		}
+		b, err := ctyjson.Marshal(v, v.Type())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var value interface{}
+		if err := json.Unmarshal(b, &value); err != nil {
+			t.Fatal(err)
+		}
+		raw[name] = value
+	}
+	for _, block := range body.Blocks {
+		blocks, _ := raw[block.Type].([]interface{})
+		raw[block.Type] = append(blocks, hclBodyToRaw(t, block.Body))
+	}
+	return raw
+}
@@ -0,0 +1,140 @@
+// schemacheck checks that a provider version is compatible with the schemas of
+// an earlier one, so that provider upgrades can be gated on it.
+//
+// Example usage:
+//
+//	git checkout v4.60.0 && go run ./scripts/schemacheck dump -out old.json
+//	git checkout main && go run ./scripts/schemacheck compare -old old.json
+//
+// dump writes a snapshot of the schemas of every resource and data source of
+// the provider, from both the SDK and the plugin framework providers. compare
+// reports the changes from the old snapshot to the new one, by default the
+// current provider, that can break existing configurations or state: removed
+// resources and fields, fields made required, fields that now force
+// replacement, type and default changes, and tighter validation. The report is
+// written to stdout as JSON, and schemacheck exits with status 1 if it contains
+// any change.
+//
+// Validation functions of SDK fields are compared by name only, and functions
+// declared inline in resource and data source constructors are ignored, as
+// their generated names (such as ResourceComputeInstance.func3) change
+// whenever the constructor does. These changes are therefore not reported:
+//
+//   - changes to the arguments of a validation function, such as values
+//     removed from the list passed to validation.StringInSlice, a stricter
+//     pattern passed to validation.StringMatch or a lower bound raised in
+//     validation.IntBetween;
+//   - validation added or tightened in a function declared inline in a
+//     constructor, or in the body of a named validation function;
+//   - validation done in CustomizeDiff functions or by the API;
+//   - changes to DiffSuppressFunc, StateFunc and the other schema functions.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	google "github.com/hashicorp/terraform-provider-google-beta/google-beta"
+)
+
+type report struct {
+	Breaking bool                  `json:"breaking"`
+	Changes  []google.SchemaChange `json:"changes"`
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	switch os.Args[1] {
+	case "dump":
+		fs := flag.NewFlagSet("dump", flag.ExitOnError)
+		out := fs.String("out", "", "the file to write the snapshot to, stdout if unset")
+		fs.Parse(os.Args[2:])
+		dump(*out)
+	case "compare":
+		fs := flag.NewFlagSet("compare", flag.ExitOnError)
+		oldPath := fs.String("old", "", "the snapshot of the earlier provider version")
+		newPath := fs.String("new", "", "the snapshot of the later provider version, the current provider if unset")
+		fs.Parse(os.Args[2:])
+		if *oldPath == "" {
+			usage()
+		}
+		compare(*oldPath, *newPath)
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: schemacheck dump [-out file] | schemacheck compare -old file [-new file]")
+	os.Exit(2)
+}
+
+func dump(out string) {
+	snapshot, err := google.SnapshotProviderSchema(context.Background())
+	if err != nil {
+		log.Fatalf("Error reading the provider schema: %s", err)
+	}
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if out == "" {
+		fmt.Println(string(b))
+		return
+	}
+	if err := ioutil.WriteFile(out, b, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func compare(oldPath, newPath string) {
+	old, err := readSnapshot(oldPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var new *google.ProviderSchemaSnapshot
+	if newPath == "" {
+		new, err = google.SnapshotProviderSchema(context.Background())
+	} else {
+		new, err = readSnapshot(newPath)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := report{Changes: google.CompareSchemaSnapshots(old, new)}
+	if r.Changes == nil {
+		r.Changes = []google.SchemaChange{}
+	}
+	r.Breaking = len(r.Changes) > 0
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
+	for _, c := range r.Changes {
+		log.Print(c)
+	}
+	if r.Breaking {
+		os.Exit(1)
+	}
+}
+
+func readSnapshot(path string) (*google.ProviderSchemaSnapshot, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot google.ProviderSchemaSnapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return nil, fmt.Errorf("error reading snapshot %s: %s", path, err)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,62 @@
+// vcrlint checks VCR cassettes for secrets before they are checked in.
+//
+// Example usage: go run vcrlint.go $VCR_PATH
+//
+// Each argument is a cassette file or a directory searched for cassettes.
+// vcrlint exits with a non-zero status if a cassette contains a string matching
+// a credential pattern, such as a private key or an OAuth token, or a header or
+// field that recording should have redacted. The redacted headers and fields
+// can be extended with the VCR_SCRUB_HEADERS and VCR_SCRUB_JSON_FIELDS
+// environment variables, as when recording.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	google "github.com/hashicorp/terraform-provider-google-beta/google-beta"
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Println("usage: vcrlint <cassette or directory>...")
+		os.Exit(1)
+	}
+
+	var cassettes []string
+	for _, arg := range flag.Args() {
+		err := filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && strings.HasSuffix(path, ".yaml") {
+				cassettes = append(cassettes, path)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("Error listing cassettes in %s: %s", arg, err)
+		}
+	}
+
+	failed := false
+	for _, path := range cassettes {
+		findings, err := google.LintCassette(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, f := range findings {
+			fmt.Println(f)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+	log.Printf("Checked %d cassettes, no secrets found", len(cassettes))
+}